@@ -14,6 +14,7 @@ import (
 	"github.com/iwvelando/finance-forecast/internal/forecast"
 	"github.com/iwvelando/finance-forecast/internal/optimizer"
 	"github.com/iwvelando/finance-forecast/internal/server"
+	"github.com/iwvelando/finance-forecast/internal/simulation"
 	"github.com/iwvelando/finance-forecast/pkg/constants"
 	"github.com/iwvelando/finance-forecast/pkg/output"
 	"github.com/iwvelando/finance-forecast/pkg/validation"
@@ -102,6 +103,9 @@ func main() {
 	serverConfigPath := flag.String("server-config", constants.DefaultServerConfigFile, "path to server configuration file")
 	emergencyMonthsFlag := flag.String("emergency-months", "", "override emergency fund recommendation duration in months (e.g. 6). Set to 0 to disable recommendations.")
 	optimizeFlag := flag.Bool("optimize", false, "optimize configured parameters before forecasting")
+	monteCarloFlag := flag.String("monte-carlo", "", "run N Monte Carlo iterations with sampled investment returns and report percentile bands (overrides monteCarlo.iterations)")
+	monteCarloSeedFlag := flag.String("monte-carlo-seed", "", "seed for Monte Carlo sampling (overrides monteCarlo.seed)")
+	backtestFlag := flag.Bool("backtest", false, "replay the forecast against every historical start year for investments with a returns series")
 	showVersion := flag.Bool("version", false, "print application version and exit")
 	flag.Parse()
 
@@ -120,6 +124,26 @@ func main() {
 		emergencyMonthsOverride = &months
 	}
 
+	var monteCarloIterationsOverride *int
+	if *monteCarloFlag != "" {
+		iterations, err := strconv.Atoi(*monteCarloFlag)
+		if err != nil || iterations < 0 {
+			fmt.Printf("{\"op\": \"main\", \"level\": \"fatal\", \"msg\": \"invalid value for --monte-carlo\", \"value\": \"%s\", \"error\": \"%v\"}\n", *monteCarloFlag, err)
+			return
+		}
+		monteCarloIterationsOverride = &iterations
+	}
+
+	var monteCarloSeedOverride *int64
+	if *monteCarloSeedFlag != "" {
+		seed, err := strconv.ParseInt(*monteCarloSeedFlag, 10, 64)
+		if err != nil {
+			fmt.Printf("{\"op\": \"main\", \"level\": \"fatal\", \"msg\": \"invalid value for --monte-carlo-seed\", \"value\": \"%s\", \"error\": \"%v\"}\n", *monteCarloSeedFlag, err)
+			return
+		}
+		monteCarloSeedOverride = &seed
+	}
+
 	if *serve {
 		runServer(*addr, *maxUpload, *serverConfigPath, *configLocation, *logLevel)
 		return
@@ -134,6 +158,12 @@ func main() {
 	if emergencyMonthsOverride != nil {
 		conf.Recommendations.EmergencyFundMonths = *emergencyMonthsOverride
 	}
+	if monteCarloIterationsOverride != nil {
+		conf.MonteCarlo.Iterations = *monteCarloIterationsOverride
+	}
+	if monteCarloSeedOverride != nil {
+		conf.MonteCarlo.Seed = *monteCarloSeedOverride
+	}
 
 	// Initialize logging based on config and CLI override
 	logger, err := initializeLogger(conf.Logging, *logLevel)
@@ -206,6 +236,47 @@ func main() {
 		}
 	}
 
+	var monteCarloResult *simulation.MonteCarloResult
+	if conf.MonteCarlo.Iterations > 0 {
+		runner, runnerErr := simulation.NewMonteCarloRunner(logger, conf, simulation.MonteCarloOptions{
+			Iterations: conf.MonteCarlo.Iterations,
+			Seed:       conf.MonteCarlo.Seed,
+		})
+		if runnerErr != nil {
+			logger.Fatal("failed to initialize Monte Carlo simulation",
+				zap.String("op", "main"),
+				zap.Error(runnerErr),
+			)
+		}
+
+		monteCarloResult, runnerErr = runner.Run()
+		if runnerErr != nil {
+			logger.Fatal("Monte Carlo simulation failed",
+				zap.String("op", "main"),
+				zap.Error(runnerErr),
+			)
+		}
+	}
+
+	var backtestResult *simulation.BacktestResult
+	if backtestFlag != nil && *backtestFlag {
+		runner, runnerErr := simulation.NewBacktestRunner(logger, conf)
+		if runnerErr != nil {
+			logger.Fatal("failed to initialize backtest",
+				zap.String("op", "main"),
+				zap.Error(runnerErr),
+			)
+		}
+
+		backtestResult, runnerErr = runner.Run()
+		if runnerErr != nil {
+			logger.Fatal("backtest failed",
+				zap.String("op", "main"),
+				zap.Error(runnerErr),
+			)
+		}
+	}
+
 	// Run the simulation to get the Forecast.
 	results, err := forecast.GetForecast(logger, *conf)
 	if err != nil {
@@ -219,14 +290,66 @@ func main() {
 		optimizationResult.Apply(results)
 	}
 
-	// Handle output.
-	switch outputFormat {
-	case constants.OutputFormatPretty:
-		output.PrettyFormat(results)
-	case constants.OutputFormatCSV:
-		output.CsvFormat(results)
+	sweepResult, err := simulation.SummarizeSweeps(conf, results)
+	if err != nil {
+		logger.Fatal("failed to summarize sweeps",
+			zap.String("op", "main"),
+			zap.Error(err),
+		)
 	}
 
+	// Handle output. Backtest and Monte Carlo summaries replace the monthly
+	// output; every computed result is printed, separated by a blank line.
+	printed := false
+	separate := func() {
+		if printed {
+			fmt.Println()
+		}
+		printed = true
+	}
+
+	if backtestResult != nil {
+		separate()
+		switch outputFormat {
+		case constants.OutputFormatPretty:
+			output.PrettyBacktest(backtestResult)
+		case constants.OutputFormatCSV:
+			output.BacktestCsvFormat(backtestResult)
+		}
+	}
+
+	if monteCarloResult != nil {
+		separate()
+		switch outputFormat {
+		case constants.OutputFormatPretty:
+			output.PrettyMonteCarlo(monteCarloResult)
+		case constants.OutputFormatCSV:
+			output.MonteCarloCsvFormat(monteCarloResult)
+		}
+	}
+
+	// Scenarios generated by sweeps are reported as grids after the
+	// configured scenarios.
+	scenarioResults := simulation.WithoutSweepScenarios(conf, results)
+	if backtestResult == nil && monteCarloResult == nil && (sweepResult == nil || len(scenarioResults) > 0) {
+		separate()
+		switch outputFormat {
+		case constants.OutputFormatPretty:
+			output.PrettyFormat(scenarioResults)
+		case constants.OutputFormatCSV:
+			output.CsvFormat(scenarioResults)
+		}
+	}
+
+	if sweepResult != nil {
+		separate()
+		switch outputFormat {
+		case constants.OutputFormatPretty:
+			output.PrettySweeps(sweepResult)
+		case constants.OutputFormatCSV:
+			output.SweepCsvFormat(sweepResult)
+		}
+	}
 }
 
 func runServer(addr string, maxUpload string, serverConfigPath string, configPath string, logLevel string) {
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+	"github.com/iwvelando/finance-forecast/pkg/loans"
+	"github.com/iwvelando/finance-forecast/pkg/returns"
+)
+
+// AdjustableRate makes a loan's interestRate the initial rate of an
+// adjustable-rate loan. After FixedMonths payments the rate resets every
+// AdjustmentInterval months (12 by default) to the index plus Margin, limited
+// by PeriodicCap per reset and LifetimeCap above the initial rate, and the
+// payment is recalculated over the remaining term. The index is the constant
+// Index, stepped by IndexSchedule; Monte Carlo runs add a random walk with
+// IndexVolatility (annual standard deviation in percentage points).
+type AdjustableRate struct {
+	FixedMonths        int                `yaml:"fixedMonths" mapstructure:"fixedMonths"`
+	AdjustmentInterval int                `yaml:"adjustmentInterval,omitempty" mapstructure:"adjustmentInterval"`
+	Index              float64            `yaml:"index" mapstructure:"index"`
+	IndexSchedule      []IndexStep        `yaml:"indexSchedule,omitempty" mapstructure:"indexSchedule"`
+	IndexVolatility    float64            `yaml:"indexVolatility,omitempty" mapstructure:"indexVolatility"`
+	Margin             float64            `yaml:"margin" mapstructure:"margin"`
+	PeriodicCap        float64            `yaml:"periodicCap,omitempty" mapstructure:"periodicCap"`
+	LifetimeCap        float64            `yaml:"lifetimeCap,omitempty" mapstructure:"lifetimeCap"`
+	IndexPath          map[string]float64 `yaml:"-" mapstructure:"-"` // sampled per Monte Carlo iteration
+}
+
+// IndexStep sets an adjustable-rate index from Date onward.
+type IndexStep struct {
+	Date string  `yaml:"date" mapstructure:"date"`
+	Rate float64 `yaml:"rate" mapstructure:"rate"`
+}
+
+// Clone returns a deep copy of the adjustable rate.
+func (a *AdjustableRate) Clone() *AdjustableRate {
+	if a == nil {
+		return nil
+	}
+	clone := *a
+	if a.IndexSchedule != nil {
+		clone.IndexSchedule = append([]IndexStep(nil), a.IndexSchedule...)
+	}
+	if a.IndexPath != nil {
+		clone.IndexPath = make(map[string]float64, len(a.IndexPath))
+		for date, rate := range a.IndexPath {
+			clone.IndexPath[date] = rate
+		}
+	}
+	return &clone
+}
+
+// Stochastic reports whether Monte Carlo runs sample the loan's index.
+func (a *AdjustableRate) Stochastic() bool {
+	return a != nil && a.IndexVolatility > 0
+}
+
+// SampleIndexPath replaces IndexPath with a random walk around the configured
+// index over dates, floored at zero.
+func (a *AdjustableRate) SampleIndexPath(dates []string, seed int64) error {
+	sampler, err := returns.NewSampler(returns.Model{Distribution: returns.DistributionNormal, Volatility: a.IndexVolatility}, seed)
+	if err != nil {
+		return err
+	}
+	base := a.toLoans()
+	base.IndexPath = nil
+	path := make(map[string]float64, len(dates))
+	shock := 0.0
+	for _, date := range dates {
+		shock += sampler.Monthly() * constants.PercentageMultiplier
+		path[date] = math.Max(base.IndexAt(date)+shock, 0)
+	}
+	a.IndexPath = path
+	return nil
+}
+
+// validate checks the adjustment schedule, caps, and index steps.
+func (a *AdjustableRate) validate(loanName string) error {
+	if a.FixedMonths < 1 {
+		return fmt.Errorf("loan %s: adjustableRate fixedMonths must be at least 1", loanName)
+	}
+	if a.AdjustmentInterval < 0 {
+		return fmt.Errorf("loan %s: adjustableRate adjustmentInterval cannot be negative", loanName)
+	}
+	if a.PeriodicCap < 0 || a.LifetimeCap < 0 || a.IndexVolatility < 0 {
+		return fmt.Errorf("loan %s: adjustableRate periodicCap, lifetimeCap, and indexVolatility cannot be negative", loanName)
+	}
+	previous := ""
+	for _, step := range a.IndexSchedule {
+		if _, err := time.Parse(DateTimeLayout, step.Date); err != nil {
+			return fmt.Errorf("loan %s: adjustableRate index step %q: %w", loanName, step.Date, err)
+		}
+		if step.Date <= previous {
+			return fmt.Errorf("loan %s: adjustableRate indexSchedule dates must increase", loanName)
+		}
+		previous = step.Date
+	}
+	return nil
+}
+
+// toLoans converts the adjustable rate for the loans package.
+func (a *AdjustableRate) toLoans() *loans.AdjustableRate {
+	if a == nil {
+		return nil
+	}
+	arm := &loans.AdjustableRate{
+		FixedMonths:        a.FixedMonths,
+		AdjustmentInterval: a.AdjustmentInterval,
+		Index:              a.Index,
+		IndexPath:          a.IndexPath,
+		Margin:             a.Margin,
+		PeriodicCap:        a.PeriodicCap,
+		LifetimeCap:        a.LifetimeCap,
+	}
+	for _, step := range a.IndexSchedule {
+		arm.IndexSchedule = append(arm.IndexSchedule, loans.IndexStep(step))
+	}
+	return arm
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestLoanAdjustableRateValidation(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-01"}}
+	tests := []struct {
+		name    string
+		arm     AdjustableRate
+		wantErr string
+	}{
+		{"valid", AdjustableRate{FixedMonths: 60, Index: 4, Margin: 2.5, PeriodicCap: 1, LifetimeCap: 5}, ""},
+		{"no fixed period", AdjustableRate{Index: 4, Margin: 2}, "fixedMonths must be at least 1"},
+		{"negative interval", AdjustableRate{FixedMonths: 12, AdjustmentInterval: -1}, "adjustmentInterval cannot be negative"},
+		{"negative cap", AdjustableRate{FixedMonths: 12, PeriodicCap: -1}, "cannot be negative"},
+		{"bad step date", AdjustableRate{FixedMonths: 12, IndexSchedule: []IndexStep{{Date: "soon", Rate: 5}}}, "index step"},
+		{"steps out of order", AdjustableRate{FixedMonths: 12, IndexSchedule: []IndexStep{{Date: "2027-01", Rate: 5}, {Date: "2026-01", Rate: 4}}}, "dates must increase"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arm := tt.arm
+			loan := &Loan{Name: "Mortgage", StartDate: "2025-01", Principal: 100000, InterestRate: 5, Term: 360, AdjustableRate: &arm}
+			err := loan.GetAmortizationSchedule(zap.NewNop(), conf)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("GetAmortizationSchedule() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("GetAmortizationSchedule() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanAdjustableRateSchedule(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-01"}}
+	loan := &Loan{
+		Name:         "Mortgage",
+		StartDate:    "2025-01",
+		Principal:    100000,
+		InterestRate: 5,
+		Term:         60,
+		AdjustableRate: &AdjustableRate{
+			FixedMonths:   12,
+			Index:         3,
+			IndexSchedule: []IndexStep{{Date: "2026-06", Rate: 5}},
+			Margin:        2,
+		},
+	}
+	if err := loan.GetAmortizationSchedule(zap.NewNop(), conf); err != nil {
+		t.Fatalf("GetAmortizationSchedule() error = %v", err)
+	}
+	tests := []struct {
+		date string
+		want float64
+	}{
+		{"2025-12", 5},
+		{"2026-01", 5},
+		{"2026-12", 5},
+		{"2027-01", 7},
+	}
+	for _, tt := range tests {
+		if got := loan.AmortizationSchedule[tt.date].InterestRate; got != tt.want {
+			t.Errorf("InterestRate[%s] = %.2f, want %.2f", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestAdjustableRateSampleIndexPath(t *testing.T) {
+	dates := []string{"2025-02", "2025-03", "2025-04", "2025-05"}
+
+	steady := &AdjustableRate{Index: 3, IndexSchedule: []IndexStep{{Date: "2025-04", Rate: 4}}}
+	if err := steady.SampleIndexPath(dates, 1); err != nil {
+		t.Fatalf("SampleIndexPath() error = %v", err)
+	}
+	if steady.IndexPath["2025-03"] != 3 || steady.IndexPath["2025-05"] != 4 {
+		t.Errorf("expected the configured index without volatility, got %v", steady.IndexPath)
+	}
+
+	first := &AdjustableRate{Index: 0.1, IndexVolatility: 50}
+	second := first.Clone()
+	if err := first.SampleIndexPath(dates, 5); err != nil {
+		t.Fatalf("SampleIndexPath() error = %v", err)
+	}
+	if err := second.SampleIndexPath(dates, 5); err != nil {
+		t.Fatalf("SampleIndexPath() error = %v", err)
+	}
+	for _, date := range dates {
+		if first.IndexPath[date] != second.IndexPath[date] {
+			t.Errorf("expected the same seed to sample the same path at %s", date)
+		}
+		if first.IndexPath[date] < 0 {
+			t.Errorf("index at %s = %.2f, want it floored at zero", date, first.IndexPath[date])
+		}
+	}
+}
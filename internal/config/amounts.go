@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+	"github.com/iwvelando/finance-forecast/pkg/mathutil"
+)
+
+// Flows an event's percentOf may refer to.
+const (
+	PercentOfIncome = "income"
+	PercentOfEvent  = "event"
+)
+
+// AmountStep replaces an event's amount from Date onward, such as a new
+// salary after a promotion. Growth compounds from the step's date.
+type AmountStep struct {
+	Date   string  `yaml:"date" mapstructure:"date"`
+	Amount float64 `yaml:"amount" mapstructure:"amount"`
+}
+
+// parsedAmountStep is an AmountStep with its date parsed.
+type parsedAmountStep struct {
+	date   time.Time
+	amount float64
+}
+
+// EffectiveGrowthRate returns the yearly percentage by which the event amount
+// grows. An explicit growthRate or annualGrowthRate is added on top of
+// inflation when the event is also indexed to inflation.
+func (event Event) EffectiveGrowthRate(inflation float64) float64 {
+	rate := event.GrowthRate + event.AnnualGrowthRate
+	if event.IndexToInflation {
+		rate += inflation
+	}
+	return rate
+}
+
+// validateGrowth checks the growth settings and returns the parsed amountSchedule.
+func (event Event) validateGrowth() ([]parsedAmountStep, error) {
+	if event.GrowthRate != 0 && event.AnnualGrowthRate != 0 {
+		return nil, fmt.Errorf("event %s: specify either growthRate or annualGrowthRate, not both", event.Name)
+	}
+	if event.GrowthMonth < 0 || event.GrowthMonth > constants.MonthsPerYear {
+		return nil, fmt.Errorf("event %s: growthMonth must be between 1 and 12, got %d", event.Name, event.GrowthMonth)
+	}
+
+	steps := make([]parsedAmountStep, 0, len(event.AmountSchedule))
+	for _, step := range event.AmountSchedule {
+		date, err := time.Parse(DateTimeLayout, step.Date)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: amountSchedule date %q: %w", event.Name, step.Date, err)
+		}
+		if len(steps) > 0 && !date.After(steps[len(steps)-1].date) {
+			return nil, fmt.Errorf("event %s: amountSchedule dates must be in increasing order", event.Name)
+		}
+		steps = append(steps, parsedAmountStep{date: date, amount: step.Amount})
+	}
+	return steps, nil
+}
+
+// growthSteps counts how many times growth applies between from and date:
+// once per full year elapsed, or once every time growthMonth comes around
+// after from when it is set.
+func (event Event) growthSteps(from, date time.Time) int {
+	if event.GrowthMonth == 0 {
+		return datetime.MonthsBetween(from, date) / constants.MonthsPerYear
+	}
+	steps := 0
+	for year := from.Year(); year <= date.Year(); year++ {
+		raise := time.Date(year, time.Month(event.GrowthMonth), 1, 0, 0, 0, 0, time.UTC)
+		if raise.After(from) && !raise.After(date) {
+			steps++
+		}
+	}
+	return steps
+}
+
+// formAmountList fills AmountList for events whose amount grows or follows an
+// amountSchedule. Before the first step the amount is Amount grown from start;
+// after a step it is the step's amount grown from the step's date.
+func (event *Event) formAmountList(steps []parsedAmountStep, start time.Time, inflation float64) {
+	event.AmountList = nil
+	growth := event.EffectiveGrowthRate(inflation)
+	if growth == 0 && len(steps) == 0 {
+		return
+	}
+
+	amounts := make([]float64, len(event.DateList))
+	for i, date := range event.DateList {
+		base, from := event.Amount, start
+		for _, step := range steps {
+			if step.date.After(date) {
+				break
+			}
+			base, from = step.amount, step.date
+		}
+		amounts[i] = mathutil.CompoundGrowth(base, growth, event.growthSteps(from, date))
+	}
+	event.AmountList = amounts
+}
+
+// PercentageBased reports whether the event's amount is a percentage of other
+// flows rather than a fixed amount.
+func (event Event) PercentageBased() bool {
+	return event.PercentOf != ""
+}
+
+// PercentOfSource parses percentOf as income, the total of all positive
+// events that month, or event:<name>. It returns the kind and, for events, the
+// event's name.
+func (event Event) PercentOfSource() (string, string, error) {
+	kind, name, found := strings.Cut(strings.TrimSpace(event.PercentOf), ":")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	name = strings.TrimSpace(name)
+	switch {
+	case kind == PercentOfIncome && !found:
+		return kind, "", nil
+	case kind == PercentOfEvent && name != "":
+		return kind, name, nil
+	default:
+		return "", "", fmt.Errorf("event %s: percentOf %q must be income or event:<name>", event.Name, event.PercentOf)
+	}
+}
+
+// validatePercentOf checks that a percentOf event sets a percentage in place
+// of its amount and leaves growth to the flows it follows.
+func (event Event) validatePercentOf() error {
+	if !event.PercentageBased() {
+		return nil
+	}
+	kind, name, err := event.PercentOfSource()
+	if err != nil {
+		return err
+	}
+	if kind == PercentOfEvent && name == event.Name {
+		return fmt.Errorf("event %s: cannot be a percentage of itself", event.Name)
+	}
+	if event.Percentage == 0 {
+		return fmt.Errorf("event %s: percentOf requires a percentage", event.Name)
+	}
+	if event.Amount != 0 || len(event.AmountSchedule) > 0 {
+		return fmt.Errorf("event %s: specify either amount or percentOf, not both", event.Name)
+	}
+	if event.EffectiveGrowthRate(0) != 0 || event.GrowthMonth != 0 || event.IndexToInflation {
+		return fmt.Errorf("event %s: percentOf amounts follow the referenced flows and cannot set growth", event.Name)
+	}
+	return nil
+}
+
+// requirePercentOf rejects a percentage on an event that does not say what it
+// is a percentage of. Only investment withdrawals take a bare percentage.
+func (event Event) requirePercentOf() error {
+	if event.Percentage != 0 && !event.PercentageBased() {
+		return fmt.Errorf("event %s: percentage requires percentOf", event.Name)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Anchor kinds and milestones accepted in DateAnchor.After.
+const (
+	AnchorKindEvent = "event"
+	AnchorKindLoan  = "loan"
+
+	AnchorMilestoneStart  = "start"
+	AnchorMilestoneEnd    = "end"
+	AnchorMilestonePayoff = "payoff"
+)
+
+// DateAnchor ties an event's start or end to a milestone of another event or
+// loan, such as "loan:Mortgage.payoff" or "event:Income.end". Anchors are
+// resolved while the forecast runs, so they follow early payoffs and other
+// anchored events. The anchored month is the milestone month plus Offset.
+type DateAnchor struct {
+	After  string `yaml:"after" mapstructure:"after"`
+	Offset int    `yaml:"offset,omitempty" mapstructure:"offset"`
+}
+
+// Clone returns a copy of the anchor.
+func (a *DateAnchor) Clone() *DateAnchor {
+	if a == nil {
+		return nil
+	}
+	clone := *a
+	return &clone
+}
+
+// AnchorRef is a parsed DateAnchor reference.
+type AnchorRef struct {
+	Kind      string
+	Name      string
+	Milestone string
+}
+
+// Ref parses the anchor's After reference as kind:name.milestone.
+func (a DateAnchor) Ref() (AnchorRef, error) {
+	kind, rest, found := strings.Cut(strings.TrimSpace(a.After), ":")
+	dot := strings.LastIndex(rest, ".")
+	if !found || dot <= 0 {
+		return AnchorRef{}, fmt.Errorf("anchor %q must look like event:<name>.<milestone> or loan:<name>.<milestone>", a.After)
+	}
+	ref := AnchorRef{
+		Kind:      strings.ToLower(strings.TrimSpace(kind)),
+		Name:      strings.TrimSpace(rest[:dot]),
+		Milestone: strings.ToLower(strings.TrimSpace(rest[dot+1:])),
+	}
+	switch ref.Kind {
+	case AnchorKindEvent:
+		if ref.Milestone != AnchorMilestoneStart && ref.Milestone != AnchorMilestoneEnd {
+			return AnchorRef{}, fmt.Errorf("anchor %q: event milestones are start and end", a.After)
+		}
+	case AnchorKindLoan:
+		if ref.Milestone != AnchorMilestoneStart && ref.Milestone != AnchorMilestonePayoff {
+			return AnchorRef{}, fmt.Errorf("anchor %q: loan milestones are start and payoff", a.After)
+		}
+	default:
+		return AnchorRef{}, fmt.Errorf("anchor %q: kind must be event or loan", a.After)
+	}
+	return ref, nil
+}
+
+// Anchored reports whether the event's start or end depends on an anchor.
+func (event Event) Anchored() bool {
+	return event.StartAnchor != nil || event.EndAnchor != nil
+}
+
+// validateAnchors checks that anchors parse and do not conflict with dates or ages.
+func (event Event) validateAnchors() error {
+	if event.StartAnchor != nil && (event.StartDate != "" || event.StartAge != 0) {
+		return fmt.Errorf("event %s: specify only one of startDate, startAge, or startAnchor", event.Name)
+	}
+	if event.EndAnchor != nil && (event.EndDate != "" || event.EndAge != 0) {
+		return fmt.Errorf("event %s: specify only one of endDate, endAge, or endAnchor", event.Name)
+	}
+	for _, anchor := range []*DateAnchor{event.StartAnchor, event.EndAnchor} {
+		if anchor == nil {
+			continue
+		}
+		ref, err := anchor.Ref()
+		if err != nil {
+			return fmt.Errorf("event %s: %w", event.Name, err)
+		}
+		if ref.Kind == AnchorKindEvent && ref.Name == event.Name {
+			return fmt.Errorf("event %s: cannot anchor to itself", event.Name)
+		}
+	}
+	return nil
+}
+
+// FormAnchoredDateList builds the schedule of an anchored event once its
+// anchors have been resolved to start and end months. Either month is ignored
+// when the matching anchor is unset. An end before the start leaves the event
+// with no dates.
+func (event *Event) FormAnchoredDateList(conf Configuration, fixedTime, start, end time.Time) error {
+	resolved := *event
+	resolved.StartAnchor, resolved.EndAnchor = nil, nil
+	if event.StartAnchor != nil {
+		resolved.StartDate = start.Format(DateTimeLayout)
+	}
+	if event.EndAnchor != nil {
+		resolved.EndDate = end.Format(DateTimeLayout)
+	}
+	if err := resolved.FormDateListWithFixedTime(conf, fixedTime); err != nil {
+		return err
+	}
+	if len(resolved.DateList) > 0 && resolved.EndDate != "" {
+		if last, err := time.Parse(DateTimeLayout, resolved.EndDate); err == nil && last.Before(resolved.DateList[0]) {
+			resolved.DateList, resolved.AmountList = []time.Time{}, nil
+		}
+	}
+	event.DateList, event.AmountList = resolved.DateList, resolved.AmountList
+	return nil
+}
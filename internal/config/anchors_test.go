@@ -0,0 +1,100 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+func TestDateAnchorRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		after   string
+		want    AnchorRef
+		wantErr bool
+	}{
+		{"loan payoff", "loan:5678 Street Address.payoff", AnchorRef{Kind: AnchorKindLoan, Name: "5678 Street Address", Milestone: AnchorMilestonePayoff}, false},
+		{"event end", "event:Income.end", AnchorRef{Kind: AnchorKindEvent, Name: "Income", Milestone: AnchorMilestoneEnd}, false},
+		{"dotted name", "Event:Job v2.0.Start", AnchorRef{Kind: AnchorKindEvent, Name: "Job v2.0", Milestone: AnchorMilestoneStart}, false},
+		{"missing kind", "Income.end", AnchorRef{}, true},
+		{"missing milestone", "event:Income", AnchorRef{}, true},
+		{"unknown kind", "asset:House.sale", AnchorRef{}, true},
+		{"event payoff", "event:Income.payoff", AnchorRef{}, true},
+		{"loan end", "loan:Car.end", AnchorRef{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DateAnchor{After: tt.after}.Ref()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ref() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Ref() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnchoredEventDateList(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-12"}}
+	fixedTime := datetime.MustParseTime(DateTimeLayout, "2025-01")
+	anchor := &DateAnchor{After: "loan:Car.payoff", Offset: 1}
+
+	tests := []struct {
+		name    string
+		event   Event
+		wantErr bool
+	}{
+		{"start anchor", Event{Name: "Save", Amount: -100, Frequency: 1, StartAnchor: anchor}, false},
+		{"end anchor with start date", Event{Name: "Job", Amount: 100, Frequency: 1, StartDate: "2025-01", EndAnchor: anchor}, false},
+		{"start anchor and start date", Event{Name: "Bad", Frequency: 1, StartDate: "2025-01", StartAnchor: anchor}, true},
+		{"end anchor and end age", Event{Name: "Bad", Frequency: 1, EndAge: 60, EndAnchor: anchor}, true},
+		{"self anchor", Event{Name: "Bad", Frequency: 1, StartAnchor: &DateAnchor{After: "event:Bad.end"}}, true},
+		{"malformed anchor", Event{Name: "Bad", Frequency: 1, StartAnchor: &DateAnchor{After: "payoff"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := tt.event
+			err := event.FormDateListWithFixedTime(conf, fixedTime)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormDateListWithFixedTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(event.DateList) != 0 {
+				t.Errorf("anchored event scheduled before the forecast: %d dates", len(event.DateList))
+			}
+		})
+	}
+}
+
+func TestFormAnchoredDateList(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-12"}}
+	fixedTime := datetime.MustParseTime(DateTimeLayout, "2025-01")
+	month := func(value string) time.Time { return datetime.MustParseTime(DateTimeLayout, value) }
+
+	event := Event{
+		Name:        "Save",
+		Amount:      -100,
+		Frequency:   1,
+		StartAnchor: &DateAnchor{After: "loan:Car.payoff", Offset: 1},
+		EndAnchor:   &DateAnchor{After: "event:Job.end"},
+	}
+	if err := event.FormAnchoredDateList(conf, fixedTime, month("2026-03"), month("2026-08")); err != nil {
+		t.Fatalf("FormAnchoredDateList() error = %v", err)
+	}
+	if len(event.DateList) != 6 || event.DateList[0] != month("2026-03") {
+		t.Errorf("DateList = %v, want 2026-03 through 2026-08", event.DateList)
+	}
+	if event.StartDate != "" || event.StartAnchor == nil {
+		t.Error("FormAnchoredDateList() changed the event's configuration")
+	}
+
+	if err := event.FormAnchoredDateList(conf, fixedTime, month("2026-03"), month("2026-01")); err != nil {
+		t.Fatalf("FormAnchoredDateList() error = %v", err)
+	}
+	if len(event.DateList) != 0 {
+		t.Errorf("end before start scheduled %d dates, want none", len(event.DateList))
+	}
+}
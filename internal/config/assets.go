@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+	"github.com/iwvelando/finance-forecast/pkg/mathutil"
+)
+
+// Asset is something owned outside cash and investments, such as a home or a
+// car. Its value counts toward total net worth from PurchaseDate until it is
+// sold, growing by AppreciationRate, an annual percentage that is negative
+// for depreciating assets. Paying for the asset is modeled separately with an
+// event or a loan.
+type Asset struct {
+	Name             string  `yaml:"name" mapstructure:"name"`
+	PurchaseDate     string  `yaml:"purchaseDate" mapstructure:"purchaseDate"`
+	PurchaseValue    float64 `yaml:"purchaseValue" mapstructure:"purchaseValue"`
+	AppreciationRate float64 `yaml:"appreciationRate,omitempty" mapstructure:"appreciationRate"`
+	SaleDate         string  `yaml:"saleDate,omitempty" mapstructure:"saleDate"`
+	SaleCosts        float64 `yaml:"saleCosts,omitempty" mapstructure:"saleCosts"`
+	Account          string  `yaml:"account,omitempty" mapstructure:"account"`
+}
+
+// ValueAt returns the asset's value in the given month, or zero before it is
+// purchased. Sales are not considered.
+func (asset Asset) ValueAt(date string) float64 {
+	purchase, err := time.Parse(DateTimeLayout, asset.PurchaseDate)
+	if err != nil {
+		return 0
+	}
+	current, err := time.Parse(DateTimeLayout, date)
+	if err != nil || current.Before(purchase) {
+		return 0
+	}
+	return mathutil.Appreciate(asset.PurchaseValue, asset.AppreciationRate, datetime.MonthsBetween(purchase, current))
+}
+
+// Validate checks the asset's value and dates.
+func (asset Asset) Validate() error {
+	if asset.Name == "" {
+		return fmt.Errorf("asset name cannot be empty")
+	}
+	if asset.PurchaseValue <= 0 {
+		return fmt.Errorf("asset %s: purchaseValue must be greater than zero", asset.Name)
+	}
+	if asset.SaleCosts < 0 {
+		return fmt.Errorf("asset %s: saleCosts cannot be negative", asset.Name)
+	}
+	purchase, err := time.Parse(DateTimeLayout, asset.PurchaseDate)
+	if err != nil {
+		return fmt.Errorf("asset %s: invalid purchaseDate %q, expected YYYY-MM", asset.Name, asset.PurchaseDate)
+	}
+	if asset.SaleDate != "" {
+		sale, err := time.Parse(DateTimeLayout, asset.SaleDate)
+		if err != nil {
+			return fmt.Errorf("asset %s: invalid saleDate %q, expected YYYY-MM", asset.Name, asset.SaleDate)
+		}
+		if sale.Before(purchase) {
+			return fmt.Errorf("asset %s: saleDate %s is before purchaseDate %s", asset.Name, asset.SaleDate, asset.PurchaseDate)
+		}
+	}
+	return nil
+}
+
+// FindAsset returns the named asset from the first list that has it.
+func FindAsset(name string, assets ...[]Asset) *Asset {
+	for _, list := range assets {
+		for i := range list {
+			if list[i].Name == name {
+				return &list[i]
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAssets checks the common and scenario assets and the loans linked
+// to them. Scenario loans may link to scenario or common assets; common loans
+// only to common assets.
+func (conf *Configuration) ValidateAssets() error {
+	if conf == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+
+	checkAssets := func(scope string, assets []Asset) error {
+		names := make(map[string]bool, len(assets))
+		for _, asset := range assets {
+			if err := asset.Validate(); err != nil {
+				return fmt.Errorf("%s %w", scope, err)
+			}
+			if names[asset.Name] {
+				return fmt.Errorf("%s asset %s: duplicate name", scope, asset.Name)
+			}
+			names[asset.Name] = true
+		}
+		return nil
+	}
+	checkLoans := func(scope string, loans []Loan, assets ...[]Asset) error {
+		for _, loan := range loans {
+			if loan.Asset == "" {
+				continue
+			}
+			asset := FindAsset(loan.Asset, assets...)
+			if asset == nil {
+				return fmt.Errorf("%s loan %s: unknown asset %q", scope, loan.Name, loan.Asset)
+			}
+			if loan.SellProperty && asset.SaleDate != "" {
+				return fmt.Errorf("%s loan %s: asset %s is sold by the loan and cannot also set saleDate", scope, loan.Name, asset.Name)
+			}
+		}
+		return nil
+	}
+
+	if err := checkAssets("common", conf.Common.Assets); err != nil {
+		return err
+	}
+	if err := checkLoans("common", conf.Common.Loans, conf.Common.Assets); err != nil {
+		return err
+	}
+	for _, scenario := range conf.Scenarios {
+		scope := fmt.Sprintf("scenario %s", scenario.Name)
+		if err := checkAssets(scope, scenario.Assets); err != nil {
+			return err
+		}
+		if err := checkLoans(scope, scenario.Loans, scenario.Assets, conf.Common.Assets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkAsset prices the sale of the loan's linked asset. The asset's saleCosts
+// apply when the loan sets no sellCostsNet, and a sale on earlyPayoffDate uses
+// the asset's value that month in place of sellPrice. Sales triggered by
+// earlyPayoffThreshold are priced when they happen.
+func (loan *Loan) linkAsset(assets ...[]Asset) error {
+	if loan.Asset == "" {
+		return nil
+	}
+	asset := FindAsset(loan.Asset, assets...)
+	if asset == nil {
+		return fmt.Errorf("loan %s: unknown asset %q", loan.Name, loan.Asset)
+	}
+	if loan.SellCostsNet == 0 {
+		loan.SellCostsNet = asset.SaleCosts
+	}
+	if loan.EarlyPayoffDate != "" {
+		loan.SellPrice = asset.ValueAt(loan.EarlyPayoffDate)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"math"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestAssetValueAt(t *testing.T) {
+	asset := Asset{Name: "Car", PurchaseDate: "2025-01", PurchaseValue: 20000, AppreciationRate: -15}
+
+	tests := []struct {
+		date string
+		want float64
+	}{
+		{"2024-12", 0},
+		{"2025-01", 20000},
+		{"2026-01", 17000},
+		{"2027-01", 14450},
+		{"2025-07", 20000 * math.Sqrt(0.85)},
+	}
+	for _, tt := range tests {
+		if got := asset.ValueAt(tt.date); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ValueAt(%s) = %.4f, want %.4f", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestValidateAssets(t *testing.T) {
+	house := Asset{Name: "House", PurchaseDate: "2020-05", PurchaseValue: 300000, AppreciationRate: 3}
+
+	tests := []struct {
+		name    string
+		conf    Configuration
+		wantErr bool
+	}{
+		{
+			name: "valid common and scenario assets",
+			conf: Configuration{
+				Common: Common{Assets: []Asset{house}},
+				Scenarios: []Scenario{{
+					Name:   "Move",
+					Assets: []Asset{{Name: "House", PurchaseDate: "2026-01", PurchaseValue: 400000}},
+					Loans:  []Loan{{Name: "Mortgage", Asset: "House", SellProperty: true}},
+				}},
+			},
+		},
+		{
+			name:    "missing name",
+			conf:    Configuration{Common: Common{Assets: []Asset{{PurchaseDate: "2020-05", PurchaseValue: 1}}}},
+			wantErr: true,
+		},
+		{
+			name:    "missing purchase value",
+			conf:    Configuration{Common: Common{Assets: []Asset{{Name: "House", PurchaseDate: "2020-05"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid purchase date",
+			conf:    Configuration{Common: Common{Assets: []Asset{{Name: "House", PurchaseDate: "2020", PurchaseValue: 1}}}},
+			wantErr: true,
+		},
+		{
+			name:    "sale before purchase",
+			conf:    Configuration{Common: Common{Assets: []Asset{{Name: "House", PurchaseDate: "2020-05", PurchaseValue: 1, SaleDate: "2019-01"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "negative sale costs",
+			conf:    Configuration{Common: Common{Assets: []Asset{{Name: "House", PurchaseDate: "2020-05", PurchaseValue: 1, SaleCosts: -1}}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name",
+			conf:    Configuration{Common: Common{Assets: []Asset{house, house}}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown linked asset",
+			conf:    Configuration{Common: Common{Loans: []Loan{{Name: "Mortgage", Asset: "House"}}}},
+			wantErr: true,
+		},
+		{
+			name: "common loan cannot link a scenario asset",
+			conf: Configuration{
+				Common:    Common{Loans: []Loan{{Name: "Mortgage", Asset: "House"}}},
+				Scenarios: []Scenario{{Name: "Base", Assets: []Asset{house}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "asset sold by loan and saleDate",
+			conf: Configuration{Common: Common{
+				Assets: []Asset{{Name: "House", PurchaseDate: "2020-05", PurchaseValue: 1, SaleDate: "2030-01"}},
+				Loans:  []Loan{{Name: "Mortgage", Asset: "House", SellProperty: true}},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.conf.ValidateAssets(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAssets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProcessLoansLinkedAsset(t *testing.T) {
+	conf := Configuration{
+		Common: Common{
+			DeathDate: "2030-01",
+			Assets:    []Asset{{Name: "House", PurchaseDate: "2024-01", PurchaseValue: 200000, AppreciationRate: 5, SaleCosts: 12000}},
+		},
+		Scenarios: []Scenario{{
+			Name: "Sell",
+			Loans: []Loan{
+				{Name: "Mortgage", StartDate: "2025-01", Principal: 150000, InterestRate: 6, Term: 360, EarlyPayoffDate: "2026-01", SellProperty: true, Asset: "House"},
+				{Name: "Threshold", StartDate: "2025-01", Principal: 150000, InterestRate: 6, Term: 360, SellCostsNet: 9000, Asset: "House"},
+			},
+		}},
+	}
+	if err := conf.ProcessLoans(zap.NewNop()); err != nil {
+		t.Fatalf("ProcessLoans() error = %v", err)
+	}
+
+	mortgage := conf.Scenarios[0].Loans[0]
+	if math.Abs(mortgage.SellPrice-220500) > 1e-6 || mortgage.SellCostsNet != 12000 {
+		t.Errorf("mortgage sells for %.2f with %.2f costs, want 220500.00 with 12000.00", mortgage.SellPrice, mortgage.SellCostsNet)
+	}
+	if threshold := conf.Scenarios[0].Loans[1]; threshold.SellCostsNet != 9000 {
+		t.Errorf("explicit sellCostsNet replaced with %.2f", threshold.SellCostsNet)
+	}
+
+	conf.Scenarios[0].Loans[0].Asset = "Boat"
+	if err := conf.ProcessLoans(zap.NewNop()); err == nil {
+		t.Error("expected an error for an unknown asset")
+	}
+}
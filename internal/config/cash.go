@@ -0,0 +1,123 @@
+package config
+
+import "fmt"
+
+// CashAccount describes a cash account such as checking or high-yield savings.
+// The first configured account is the default for events and loans that do not
+// name an account, and it also receives common.startingValue.
+type CashAccount struct {
+	Name          string     `yaml:"name" mapstructure:"name"`
+	StartingValue float64    `yaml:"startingValue,omitempty" mapstructure:"startingValue"`
+	InterestRate  float64    `yaml:"interestRate,omitempty" mapstructure:"interestRate"` // annual percentage yield
+	Sweep         *CashSweep `yaml:"sweep,omitempty" mapstructure:"sweep"`
+}
+
+// CashSweep moves money between this account and another at the end of each
+// month. Any balance above Above is moved to To, and when the balance drops
+// below Below it is topped back up from To as far as To's balance allows.
+type CashSweep struct {
+	To    string   `yaml:"to" mapstructure:"to"`
+	Above *float64 `yaml:"above,omitempty" mapstructure:"above"`
+	Below *float64 `yaml:"below,omitempty" mapstructure:"below"`
+}
+
+// ValidateCashAccounts checks account names, sweep targets, and the accounts
+// referenced by events and loans.
+func (conf *Configuration) ValidateCashAccounts() error {
+	if conf == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+
+	names := make(map[string]bool, len(conf.Common.CashAccounts))
+	for i, account := range conf.Common.CashAccounts {
+		if account.Name == "" {
+			return fmt.Errorf("cash account %d: name cannot be empty", i)
+		}
+		if names[account.Name] {
+			return fmt.Errorf("cash account %s: duplicate name", account.Name)
+		}
+		names[account.Name] = true
+	}
+
+	for _, account := range conf.Common.CashAccounts {
+		sweep := account.Sweep
+		if sweep == nil {
+			continue
+		}
+		if sweep.To == "" || !names[sweep.To] {
+			return fmt.Errorf("cash account %s: unknown sweep target %q", account.Name, sweep.To)
+		}
+		if sweep.To == account.Name {
+			return fmt.Errorf("cash account %s: cannot sweep into itself", account.Name)
+		}
+		if sweep.Above == nil && sweep.Below == nil {
+			return fmt.Errorf("cash account %s: sweep requires above and/or below", account.Name)
+		}
+		if sweep.Above != nil && sweep.Below != nil && *sweep.Below > *sweep.Above {
+			return fmt.Errorf("cash account %s: sweep below (%.2f) exceeds above (%.2f)", account.Name, *sweep.Below, *sweep.Above)
+		}
+	}
+
+	checkEvents := func(scope string, events []Event) error {
+		for _, event := range events {
+			if event.Account != "" && !names[event.Account] {
+				return fmt.Errorf("%s event %s: unknown cash account %q", scope, event.Name, event.Account)
+			}
+		}
+		return nil
+	}
+	checkLoans := func(scope string, loans []Loan) error {
+		for _, loan := range loans {
+			if loan.Account != "" && !names[loan.Account] {
+				return fmt.Errorf("%s loan %s: unknown cash account %q", scope, loan.Name, loan.Account)
+			}
+		}
+		return nil
+	}
+	checkRevolving := func(scope string, debts []RevolvingDebt) error {
+		for _, debt := range debts {
+			if debt.Account != "" && !names[debt.Account] {
+				return fmt.Errorf("%s revolving debt %s: unknown cash account %q", scope, debt.Name, debt.Account)
+			}
+		}
+		return nil
+	}
+	checkAssets := func(scope string, assets []Asset) error {
+		for _, asset := range assets {
+			if asset.Account != "" && !names[asset.Account] {
+				return fmt.Errorf("%s asset %s: unknown cash account %q", scope, asset.Name, asset.Account)
+			}
+		}
+		return nil
+	}
+
+	if err := checkEvents("common", conf.Common.Events); err != nil {
+		return err
+	}
+	if err := checkLoans("common", conf.Common.Loans); err != nil {
+		return err
+	}
+	if err := checkRevolving("common", conf.Common.RevolvingDebts); err != nil {
+		return err
+	}
+	if err := checkAssets("common", conf.Common.Assets); err != nil {
+		return err
+	}
+	for _, scenario := range conf.Scenarios {
+		scope := fmt.Sprintf("scenario %s", scenario.Name)
+		if err := checkEvents(scope, scenario.Events); err != nil {
+			return err
+		}
+		if err := checkLoans(scope, scenario.Loans); err != nil {
+			return err
+		}
+		if err := checkRevolving(scope, scenario.RevolvingDebts); err != nil {
+			return err
+		}
+		if err := checkAssets(scope, scenario.Assets); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
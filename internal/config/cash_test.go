@@ -0,0 +1,99 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCashAccounts(t *testing.T) {
+	above := 5000.0
+	below := 1000.0
+
+	tests := []struct {
+		name    string
+		conf    Configuration
+		wantErr string
+	}{
+		{
+			name: "no accounts",
+			conf: Configuration{},
+		},
+		{
+			name: "valid sweep and references",
+			conf: Configuration{
+				Common: Common{
+					CashAccounts: []CashAccount{
+						{Name: "Checking", Sweep: &CashSweep{To: "Savings", Above: &above, Below: &below}},
+						{Name: "Savings", InterestRate: 4.5},
+					},
+					Events: []Event{{Name: "Salary", Account: "Checking"}},
+				},
+				Scenarios: []Scenario{{Name: "Base", Loans: []Loan{{Name: "Car", Account: "Savings"}}}},
+			},
+		},
+		{
+			name:    "empty name",
+			conf:    Configuration{Common: Common{CashAccounts: []CashAccount{{}}}},
+			wantErr: "name cannot be empty",
+		},
+		{
+			name:    "duplicate name",
+			conf:    Configuration{Common: Common{CashAccounts: []CashAccount{{Name: "A"}, {Name: "A"}}}},
+			wantErr: "duplicate name",
+		},
+		{
+			name:    "unknown sweep target",
+			conf:    Configuration{Common: Common{CashAccounts: []CashAccount{{Name: "A", Sweep: &CashSweep{To: "B", Above: &above}}}}},
+			wantErr: "unknown sweep target",
+		},
+		{
+			name:    "sweep into itself",
+			conf:    Configuration{Common: Common{CashAccounts: []CashAccount{{Name: "A", Sweep: &CashSweep{To: "A", Above: &above}}}}},
+			wantErr: "cannot sweep into itself",
+		},
+		{
+			name: "sweep without thresholds",
+			conf: Configuration{Common: Common{CashAccounts: []CashAccount{
+				{Name: "A", Sweep: &CashSweep{To: "B"}},
+				{Name: "B"},
+			}}},
+			wantErr: "requires above and/or below",
+		},
+		{
+			name: "below exceeds above",
+			conf: Configuration{Common: Common{CashAccounts: []CashAccount{
+				{Name: "A", Sweep: &CashSweep{To: "B", Above: &below, Below: &above}},
+				{Name: "B"},
+			}}},
+			wantErr: "exceeds above",
+		},
+		{
+			name: "unknown event account",
+			conf: Configuration{
+				Common:    Common{CashAccounts: []CashAccount{{Name: "Checking"}}},
+				Scenarios: []Scenario{{Name: "Base", Events: []Event{{Name: "Bonus", Account: "Savings"}}}},
+			},
+			wantErr: "scenario Base event Bonus: unknown cash account",
+		},
+		{
+			name:    "account referenced without accounts configured",
+			conf:    Configuration{Common: Common{Loans: []Loan{{Name: "Car", Account: "Checking"}}}},
+			wantErr: "common loan Car: unknown cash account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.conf.ValidateCashAccounts()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateCashAccounts() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateCashAccounts() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
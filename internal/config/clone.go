@@ -0,0 +1,239 @@
+package config
+
+import "time"
+
+// Clone returns a deep copy of the configuration. Forecasting mutates loan
+// schedules when early payoff thresholds fire, so callers that run repeated
+// simulations against the same configuration should operate on clones.
+func (conf *Configuration) Clone() *Configuration {
+	if conf == nil {
+		return nil
+	}
+
+	clone := *conf
+	clone.Common.CashAccounts = cloneCashAccounts(conf.Common.CashAccounts)
+	if conf.Household != nil {
+		household := *conf.Household
+		household.Members = append([]Member(nil), conf.Household.Members...)
+		clone.Household = &household
+	}
+	if conf.Taxes != nil {
+		taxes := *conf.Taxes
+		taxes.StandardDeduction = cloneFloatPtr(conf.Taxes.StandardDeduction)
+		taxes.Brackets = append([]TaxBracket(nil), conf.Taxes.Brackets...)
+		clone.Taxes = &taxes
+	}
+	if conf.ShortfallPolicy != nil {
+		policy := *conf.ShortfallPolicy
+		policy.Order = append([]string(nil), conf.ShortfallPolicy.Order...)
+		clone.ShortfallPolicy = &policy
+	}
+	clone.Common.Events = cloneEvents(conf.Common.Events)
+	clone.Common.Loans = cloneLoans(conf.Common.Loans)
+	clone.Common.RevolvingDebts = cloneRevolvingDebts(conf.Common.RevolvingDebts)
+	clone.Common.Investments = cloneInvestments(conf.Common.Investments)
+	clone.Common.Assets = cloneAssets(conf.Common.Assets)
+	clone.Common.Triggers = cloneTriggers(conf.Common.Triggers)
+	clone.Common.DebtPayoff = conf.Common.DebtPayoff.Clone()
+
+	if conf.Scenarios != nil {
+		clone.Scenarios = make([]Scenario, len(conf.Scenarios))
+		for i, scenario := range conf.Scenarios {
+			clone.Scenarios[i] = scenario.Clone()
+		}
+	}
+	if conf.Sweeps != nil {
+		clone.Sweeps = make([]ParameterSweep, len(conf.Sweeps))
+		for i, sweep := range conf.Sweeps {
+			clone.Sweeps[i] = sweep.Clone()
+		}
+	}
+
+	return &clone
+}
+
+// Clone returns a deep copy of the scenario.
+func (scenario Scenario) Clone() Scenario {
+	clone := scenario
+	clone.Events = cloneEvents(scenario.Events)
+	clone.Loans = cloneLoans(scenario.Loans)
+	clone.RevolvingDebts = cloneRevolvingDebts(scenario.RevolvingDebts)
+	clone.Investments = cloneInvestments(scenario.Investments)
+	clone.Assets = cloneAssets(scenario.Assets)
+	clone.Triggers = cloneTriggers(scenario.Triggers)
+	clone.DebtPayoff = scenario.DebtPayoff.Clone()
+	clone.Remove = scenario.Remove.Clone()
+	return clone
+}
+
+// Clone returns a deep copy of the event.
+func (event Event) Clone() Event {
+	clone := event
+	if event.DateList != nil {
+		clone.DateList = append([]time.Time(nil), event.DateList...)
+	}
+	if event.AmountList != nil {
+		clone.AmountList = append([]float64(nil), event.AmountList...)
+	}
+	if event.AmountSchedule != nil {
+		clone.AmountSchedule = append([]AmountStep(nil), event.AmountSchedule...)
+	}
+	if event.Optimizer != nil {
+		optimizer := *event.Optimizer
+		optimizer.Min = cloneFloatPtr(event.Optimizer.Min)
+		optimizer.Max = cloneFloatPtr(event.Optimizer.Max)
+		clone.Optimizer = &optimizer
+	}
+	clone.SocialSecurity = event.SocialSecurity.Clone()
+	clone.StartAnchor = event.StartAnchor.Clone()
+	clone.EndAnchor = event.EndAnchor.Clone()
+	return clone
+}
+
+// Clone returns a deep copy of the loan, including its amortization schedule.
+func (loan Loan) Clone() Loan {
+	clone := loan
+	clone.ExtraPrincipalPayments = cloneEvents(loan.ExtraPrincipalPayments)
+	if loan.Refinances != nil {
+		clone.Refinances = append([]Refinance(nil), loan.Refinances...)
+	}
+	clone.AdjustableRate = loan.AdjustableRate.Clone()
+	if loan.AmortizationSchedule != nil {
+		clone.AmortizationSchedule = make(map[string]Payment, len(loan.AmortizationSchedule))
+		for date, payment := range loan.AmortizationSchedule {
+			clone.AmortizationSchedule[date] = payment
+		}
+	}
+	if loan.Notes != nil {
+		clone.Notes = make(map[string][]string, len(loan.Notes))
+		for date, notes := range loan.Notes {
+			clone.Notes[date] = append([]string(nil), notes...)
+		}
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the investment.
+func (investment Investment) Clone() Investment {
+	clone := investment
+	clone.Contributions = cloneEvents(investment.Contributions)
+	clone.Withdrawals = cloneEvents(investment.Withdrawals)
+	if investment.Returns != nil {
+		model := *investment.Returns
+		model.Mean = cloneFloatPtr(investment.Returns.Mean)
+		if investment.Returns.Seed != nil {
+			seed := *investment.Returns.Seed
+			model.Seed = &seed
+		}
+		clone.Returns = &model
+	}
+	if investment.ReturnPath != nil {
+		clone.ReturnPath = make(map[string]float64, len(investment.ReturnPath))
+		for date, value := range investment.ReturnPath {
+			clone.ReturnPath[date] = value
+		}
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the cash account.
+func (account CashAccount) Clone() CashAccount {
+	clone := account
+	if account.Sweep != nil {
+		sweep := *account.Sweep
+		sweep.Above = cloneFloatPtr(account.Sweep.Above)
+		sweep.Below = cloneFloatPtr(account.Sweep.Below)
+		clone.Sweep = &sweep
+	}
+	return clone
+}
+
+func cloneCashAccounts(accounts []CashAccount) []CashAccount {
+	if accounts == nil {
+		return nil
+	}
+	clone := make([]CashAccount, len(accounts))
+	for i, account := range accounts {
+		clone[i] = account.Clone()
+	}
+	return clone
+}
+
+func cloneEvents(events []Event) []Event {
+	if events == nil {
+		return nil
+	}
+	clone := make([]Event, len(events))
+	for i, event := range events {
+		clone[i] = event.Clone()
+	}
+	return clone
+}
+
+func cloneLoans(loans []Loan) []Loan {
+	if loans == nil {
+		return nil
+	}
+	clone := make([]Loan, len(loans))
+	for i, loan := range loans {
+		clone[i] = loan.Clone()
+	}
+	return clone
+}
+
+func cloneInvestments(investments []Investment) []Investment {
+	if investments == nil {
+		return nil
+	}
+	clone := make([]Investment, len(investments))
+	for i, investment := range investments {
+		clone[i] = investment.Clone()
+	}
+	return clone
+}
+
+func cloneAssets(assets []Asset) []Asset {
+	if assets == nil {
+		return nil
+	}
+	clone := make([]Asset, len(assets))
+	copy(clone, assets)
+	return clone
+}
+
+func cloneRevolvingDebts(debts []RevolvingDebt) []RevolvingDebt {
+	if debts == nil {
+		return nil
+	}
+	clone := make([]RevolvingDebt, len(debts))
+	for i, debt := range debts {
+		clone[i] = debt
+		clone[i].Charges = cloneEvents(debt.Charges)
+	}
+	return clone
+}
+
+func cloneTriggers(triggers []Trigger) []Trigger {
+	if triggers == nil {
+		return nil
+	}
+	clone := make([]Trigger, len(triggers))
+	for i, trigger := range triggers {
+		clone[i] = trigger
+		clone[i].When.Above = cloneFloatPtr(trigger.When.Above)
+		clone[i].When.Below = cloneFloatPtr(trigger.When.Below)
+		if trigger.Transfer != nil {
+			transfer := *trigger.Transfer
+			clone[i].Transfer = &transfer
+		}
+	}
+	return clone
+}
+
+func cloneFloatPtr(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	v := *value
+	return &v
+}
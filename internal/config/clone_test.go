@@ -0,0 +1,180 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/loans"
+)
+
+func TestConfigurationClone(t *testing.T) {
+	minValue := 10.0
+	mean := 6.0
+	sweepAbove := 5000.0
+	cola := 2.5
+	conf := &Configuration{
+		StartDate: "2025-01",
+		Sweeps:    []ParameterSweep{{Name: "Rates", Scenario: "Base", Axes: []SweepAxis{{Targets: []string{"loan:Car.interestRate"}, From: 4, To: 6, Step: 1}}}},
+		Household: &Household{Members: []Member{{Name: "Alex", BirthDate: "1980-04"}}},
+		Common: Common{
+			StartingValue: 1000,
+			DeathDate:     "2030-01",
+			CashAccounts: []CashAccount{
+				{Name: "Checking", Sweep: &CashSweep{To: "Savings", Above: &sweepAbove}},
+				{Name: "Savings", InterestRate: 4.5},
+			},
+			Events: []Event{
+				{
+					Name:      "Income",
+					Amount:    100,
+					Frequency: 1,
+					DateList:  []time.Time{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			Loans: []Loan{
+				{
+					Name:                 "Car",
+					EarlyPayoffThreshold: 500,
+					Refinances:           []Refinance{{Date: "2026-01", InterestRate: 4, Term: 36}},
+					AdjustableRate:       &AdjustableRate{FixedMonths: 12, IndexSchedule: []IndexStep{{Date: "2026-01", Rate: 4}}, IndexPath: map[string]float64{"2026-01": 4}},
+					AmortizationSchedule: map[string]Payment{"2025-01": {Payment: 300}},
+					Notes:                map[string][]string{"2026-01": {"refinanced"}},
+				},
+			},
+			Investments: []Investment{
+				{
+					Name:       "Brokerage",
+					Returns:    &ReturnModel{Mean: &mean, Volatility: 12},
+					ReturnPath: map[string]float64{"2025-01": 0.01},
+				},
+			},
+		},
+		Scenarios: []Scenario{
+			{
+				Name:           "Base",
+				Active:         true,
+				Extends:        "Parent",
+				Remove:         &ScenarioRemovals{Loans: []string{"Car"}},
+				Assets:         []Asset{{Name: "House", PurchaseDate: "2020-01", PurchaseValue: 300000}},
+				RevolvingDebts: []RevolvingDebt{{Name: "Card", Balance: 2000, MinimumPercent: 2, Charges: []Event{{Name: "Groceries", Amount: 400, Frequency: 1}}}},
+				DebtPayoff: &DebtPayoff{
+					Strategy: "custom",
+					Budget:   200,
+					Order:    []string{"Car"},
+					Report:   &loans.PayoffReport{Strategy: "custom", Loans: []loans.PayoffOutcome{{Name: "Car", Payoff: "2027-01"}}},
+				},
+				Triggers: []Trigger{{Name: "Invest", When: TriggerCondition{Balance: "liquid", Above: &sweepAbove}, Transfer: &TriggerTransfer{To: "Brokerage", Amount: 100}}},
+				Events: []Event{
+					{
+						Name:           "Bonus",
+						Amount:         50,
+						Frequency:      12,
+						AmountSchedule: []AmountStep{{Date: "2026-01", Amount: 75}},
+						Optimizer:      &OptimizerConfig{Field: OptimizerFieldAmount, Min: &minValue},
+					},
+					{
+						Name:           "Social Security",
+						SocialSecurity: &SocialSecurity{PIA: 2000, ClaimingAge: 67, COLA: &cola, Spouse: &SpouseBenefits{Member: "Sam", ClaimingAge: 67}},
+						EndAnchor:      &DateAnchor{After: "loan:Car.payoff", Offset: 1},
+					},
+				},
+			},
+		},
+	}
+
+	clone := conf.Clone()
+
+	clone.Common.Events[0].DateList[0] = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clone.Common.Loans[0].AmortizationSchedule["2025-01"] = Payment{Payment: 1}
+	clone.Common.Loans[0].EarlyPayoffThreshold = 0
+	clone.Common.Loans[0].Refinances[0].Term = 1
+	clone.Common.Loans[0].Notes["2026-01"][0] = "changed"
+	clone.Common.Loans[0].AdjustableRate.IndexSchedule[0].Rate = 9
+	clone.Common.Loans[0].AdjustableRate.IndexPath["2026-01"] = 9
+	*clone.Common.Investments[0].Returns.Mean = 1
+	clone.Common.Investments[0].ReturnPath["2025-01"] = 0.5
+	*clone.Scenarios[0].Events[0].Optimizer.Min = 99
+	clone.Scenarios[0].Events[0].AmountSchedule[0].Amount = 1
+	clone.Scenarios[0].Name = "Changed"
+	clone.Scenarios[0].Assets[0].PurchaseValue = 1
+	clone.Scenarios[0].RevolvingDebts[0].Charges[0].Amount = 1
+	clone.Scenarios[0].DebtPayoff.Order[0] = "Boat"
+	clone.Scenarios[0].DebtPayoff.Report.Loans[0].Payoff = "2026-01"
+	clone.Scenarios[0].Remove.Loans[0] = "Boat"
+	clone.Sweeps[0].Axes[0].Targets[0] = "loan:Boat.interestRate"
+	*clone.Common.CashAccounts[0].Sweep.Above = 1
+	clone.Household.Members[0].Name = "Sam"
+	*clone.Scenarios[0].Events[1].SocialSecurity.COLA = 0
+	clone.Scenarios[0].Events[1].SocialSecurity.Spouse.ClaimingAge = 62
+	clone.Scenarios[0].Events[1].EndAnchor.Offset = 3
+	*clone.Scenarios[0].Triggers[0].When.Above = 1
+	clone.Scenarios[0].Triggers[0].Transfer.Amount = 1
+
+	if got := conf.Common.Events[0].DateList[0].Year(); got != 2025 {
+		t.Errorf("original event date list mutated, year = %d", got)
+	}
+	if got := conf.Common.Loans[0].AmortizationSchedule["2025-01"].Payment; got != 300 {
+		t.Errorf("original amortization schedule mutated, payment = %.2f", got)
+	}
+	if *conf.Common.CashAccounts[0].Sweep.Above != 5000 {
+		t.Errorf("original cash sweep mutated")
+	}
+	if conf.Household.Members[0].Name != "Alex" {
+		t.Errorf("original household mutated")
+	}
+	if conf.Common.Loans[0].EarlyPayoffThreshold != 500 {
+		t.Errorf("original early payoff threshold mutated")
+	}
+	if conf.Common.Loans[0].Refinances[0].Term != 36 || conf.Common.Loans[0].Notes["2026-01"][0] != "refinanced" {
+		t.Errorf("original loan refinances or notes mutated")
+	}
+	if arm := conf.Common.Loans[0].AdjustableRate; arm.IndexSchedule[0].Rate != 4 || arm.IndexPath["2026-01"] != 4 {
+		t.Errorf("original adjustable rate mutated")
+	}
+	if *conf.Common.Investments[0].Returns.Mean != 6 {
+		t.Errorf("original return model mutated")
+	}
+	if conf.Common.Investments[0].ReturnPath["2025-01"] != 0.01 {
+		t.Errorf("original return path mutated")
+	}
+	if *conf.Scenarios[0].Events[0].Optimizer.Min != 10 {
+		t.Errorf("original optimizer bounds mutated")
+	}
+	if conf.Scenarios[0].Events[0].AmountSchedule[0].Amount != 75 {
+		t.Errorf("original amount schedule mutated")
+	}
+	if ss := conf.Scenarios[0].Events[1].SocialSecurity; *ss.COLA != 2.5 || ss.Spouse.ClaimingAge != 67 {
+		t.Errorf("original social security model mutated")
+	}
+	if trigger := conf.Scenarios[0].Triggers[0]; *trigger.When.Above != 5000 || trigger.Transfer.Amount != 100 {
+		t.Errorf("original trigger mutated")
+	}
+	if conf.Scenarios[0].Events[1].EndAnchor.Offset != 1 {
+		t.Errorf("original date anchor mutated")
+	}
+	if conf.Scenarios[0].Assets[0].PurchaseValue != 300000 {
+		t.Errorf("original asset mutated")
+	}
+	if conf.Scenarios[0].RevolvingDebts[0].Charges[0].Amount != 400 {
+		t.Errorf("original revolving debt charges mutated")
+	}
+	if plan := conf.Scenarios[0].DebtPayoff; plan.Order[0] != "Car" || plan.Report.Loans[0].Payoff != "2027-01" {
+		t.Errorf("original debt payoff plan mutated")
+	}
+	if conf.Scenarios[0].Remove.Loans[0] != "Car" {
+		t.Errorf("original scenario removals mutated")
+	}
+	if conf.Sweeps[0].Axes[0].Targets[0] != "loan:Car.interestRate" {
+		t.Errorf("original sweep mutated")
+	}
+	if conf.Scenarios[0].Name != "Base" {
+		t.Errorf("original scenario mutated")
+	}
+}
+
+func TestConfigurationCloneNil(t *testing.T) {
+	var conf *Configuration
+	if conf.Clone() != nil {
+		t.Fatal("expected nil clone for nil configuration")
+	}
+}
@@ -5,11 +5,13 @@ package config
 import (
 	"fmt"
 	"io"
+	"reflect"
 	"time"
 
 	"github.com/iwvelando/finance-forecast/pkg/configprocessor"
 	"github.com/iwvelando/finance-forecast/pkg/constants"
 	"github.com/iwvelando/finance-forecast/pkg/datetime"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
@@ -21,10 +23,22 @@ const DateTimeLayout = constants.DateTimeLayout
 type Configuration struct {
 	Common          Common
 	Scenarios       []Scenario
+	Household       *Household            `yaml:"household,omitempty"`
 	Logging         LoggingConfig         `yaml:"logging,omitempty"`
 	Output          OutputConfig          `yaml:"output,omitempty"`
 	Recommendations RecommendationsConfig `yaml:"recommendations,omitempty"`
+	MonteCarlo      MonteCarloConfig      `yaml:"monteCarlo,omitempty"`
+	ShortfallPolicy *ShortfallPolicy      `yaml:"shortfallPolicy,omitempty"`
+	Taxes           *TaxConfig            `yaml:"taxes,omitempty"`
+	Sweeps          []ParameterSweep      `yaml:"sweeps,omitempty"`
 	StartDate       string                `yaml:"startDate,omitempty"` // Optional simulation start date (YYYY-MM)
+	Inflation       float64               `yaml:"inflation,omitempty"` // Optional annual inflation rate (percent)
+}
+
+// MonteCarloConfig captures optional stochastic simulation settings.
+type MonteCarloConfig struct {
+	Iterations int   `yaml:"iterations,omitempty" mapstructure:"iterations"`
+	Seed       int64 `yaml:"seed,omitempty" mapstructure:"seed"`
 }
 
 // RecommendationsConfig captures optional recommendation settings.
@@ -46,32 +60,63 @@ type OutputConfig struct {
 
 // Common holds the shared parameters, events, and loans between all scenarios.
 type Common struct {
-	StartingValue float64      `yaml:"startingValue" mapstructure:"startingValue"`
-	DeathDate     string       `yaml:"deathDate,omitempty" mapstructure:"deathDate"`
-	Events        []Event      `yaml:"events" mapstructure:"events"`
-	Loans         []Loan       `yaml:"loans" mapstructure:"loans"`
-	Investments   []Investment `yaml:"investments" mapstructure:"investments"`
+	StartingValue  float64         `yaml:"startingValue" mapstructure:"startingValue"`
+	DeathDate      string          `yaml:"deathDate,omitempty" mapstructure:"deathDate"`
+	BirthDate      string          `yaml:"birthDate,omitempty" mapstructure:"birthDate"`
+	RMDStartAge    int             `yaml:"rmdStartAge,omitempty" mapstructure:"rmdStartAge"`
+	CashAccounts   []CashAccount   `yaml:"cashAccounts,omitempty" mapstructure:"cashAccounts"`
+	Events         []Event         `yaml:"events" mapstructure:"events"`
+	Loans          []Loan          `yaml:"loans" mapstructure:"loans"`
+	RevolvingDebts []RevolvingDebt `yaml:"revolvingDebts,omitempty" mapstructure:"revolvingDebts"`
+	Investments    []Investment    `yaml:"investments" mapstructure:"investments"`
+	Assets         []Asset         `yaml:"assets,omitempty" mapstructure:"assets"`
+	Triggers       []Trigger       `yaml:"triggers,omitempty" mapstructure:"triggers"`
+	DebtPayoff     *DebtPayoff     `yaml:"debtPayoff,omitempty" mapstructure:"debtPayoff"`
 }
 
 // Scenario holds all events and loans for a given scenario.
 type Scenario struct {
-	Name        string       `yaml:"name" mapstructure:"name"`
-	Active      bool         `yaml:"active" mapstructure:"active"`
-	Events      []Event      `yaml:"events" mapstructure:"events"`
-	Loans       []Loan       `yaml:"loans" mapstructure:"loans"`
-	Investments []Investment `yaml:"investments" mapstructure:"investments"`
+	Name           string            `yaml:"name" mapstructure:"name"`
+	Active         bool              `yaml:"active" mapstructure:"active"`
+	Extends        string            `yaml:"extends,omitempty" mapstructure:"extends"`
+	Remove         *ScenarioRemovals `yaml:"remove,omitempty" mapstructure:"remove"`
+	Events         []Event           `yaml:"events" mapstructure:"events"`
+	Loans          []Loan            `yaml:"loans" mapstructure:"loans"`
+	RevolvingDebts []RevolvingDebt   `yaml:"revolvingDebts,omitempty" mapstructure:"revolvingDebts"`
+	Investments    []Investment      `yaml:"investments" mapstructure:"investments"`
+	Assets         []Asset           `yaml:"assets,omitempty" mapstructure:"assets"`
+	Triggers       []Trigger         `yaml:"triggers,omitempty" mapstructure:"triggers"`
+	DebtPayoff     *DebtPayoff       `yaml:"debtPayoff,omitempty" mapstructure:"debtPayoff"`
+	Sweep          string            `yaml:"-" mapstructure:"-"` // name of the sweep that generated the scenario
 }
 
 // Event indicates a financial event.
 type Event struct {
-	Name       string           `yaml:"name" mapstructure:"name"`
-	Amount     float64          `yaml:"amount" mapstructure:"amount"`
-	Percentage float64          `yaml:"percentage,omitempty" mapstructure:"percentage,omitempty"`
-	StartDate  string           `yaml:"startDate,omitempty" mapstructure:"startDate,omitempty"`
-	EndDate    string           `yaml:"endDate,omitempty" mapstructure:"endDate,omitempty"`
-	Frequency  int              `yaml:"frequency" mapstructure:"frequency"`
-	DateList   []time.Time      `yaml:"-" mapstructure:"-"`
-	Optimizer  *OptimizerConfig `yaml:"optimize,omitempty" mapstructure:"optimize,omitempty"`
+	Name             string           `yaml:"name" mapstructure:"name"`
+	Amount           float64          `yaml:"amount" mapstructure:"amount"`
+	Percentage       float64          `yaml:"percentage,omitempty" mapstructure:"percentage,omitempty"`
+	PercentOf        string           `yaml:"percentOf,omitempty" mapstructure:"percentOf,omitempty"`
+	StartDate        string           `yaml:"startDate,omitempty" mapstructure:"startDate,omitempty"`
+	EndDate          string           `yaml:"endDate,omitempty" mapstructure:"endDate,omitempty"`
+	Frequency        int              `yaml:"frequency" mapstructure:"frequency"`
+	FrequencyUnit    string           `yaml:"frequencyUnit,omitempty" mapstructure:"frequencyUnit,omitempty"`
+	AnchorDate       string           `yaml:"anchorDate,omitempty" mapstructure:"anchorDate,omitempty"` // YYYY-MM-DD of one occurrence of a weekly event
+	GrowthRate       float64          `yaml:"growthRate,omitempty" mapstructure:"growthRate,omitempty"`
+	AnnualGrowthRate float64          `yaml:"annualGrowthRate,omitempty" mapstructure:"annualGrowthRate,omitempty"`
+	GrowthMonth      int              `yaml:"growthMonth,omitempty" mapstructure:"growthMonth,omitempty"`
+	AmountSchedule   []AmountStep     `yaml:"amountSchedule,omitempty" mapstructure:"amountSchedule,omitempty"`
+	IndexToInflation bool             `yaml:"indexToInflation,omitempty" mapstructure:"indexToInflation,omitempty"`
+	Account          string           `yaml:"account,omitempty" mapstructure:"account,omitempty"`
+	Taxable          string           `yaml:"taxable,omitempty" mapstructure:"taxable,omitempty"`
+	Member           string           `yaml:"member,omitempty" mapstructure:"member,omitempty"`
+	SocialSecurity   *SocialSecurity  `yaml:"socialSecurity,omitempty" mapstructure:"socialSecurity,omitempty"`
+	StartAge         int              `yaml:"startAge,omitempty" mapstructure:"startAge,omitempty"`
+	EndAge           int              `yaml:"endAge,omitempty" mapstructure:"endAge,omitempty"`
+	StartAnchor      *DateAnchor      `yaml:"startAnchor,omitempty" mapstructure:"startAnchor,omitempty"`
+	EndAnchor        *DateAnchor      `yaml:"endAnchor,omitempty" mapstructure:"endAnchor,omitempty"`
+	DateList         []time.Time      `yaml:"-" mapstructure:"-"`
+	AmountList       []float64        `yaml:"-" mapstructure:"-"` // per-date amounts when growth applies
+	Optimizer        *OptimizerConfig `yaml:"optimize,omitempty" mapstructure:"optimize,omitempty"`
 }
 
 // LoadConfiguration takes a file path as input and loads the YAML-formatted
@@ -87,10 +132,16 @@ func LoadConfiguration(configPath string) (*Configuration, error) {
 	}
 
 	var configuration Configuration
-	err := viper.Unmarshal(&configuration)
+	err := viper.Unmarshal(&configuration, decodeHook())
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode into struct, %s", err)
 	}
+	if err := configuration.ResolveScenarios(); err != nil {
+		return nil, err
+	}
+	if err := configuration.ExpandSweeps(); err != nil {
+		return nil, err
+	}
 
 	if !viper.IsSet("recommendations.emergencyFundMonths") {
 		configuration.Recommendations.EmergencyFundMonths = constants.DefaultEmergencyFundMonths
@@ -99,6 +150,21 @@ func LoadConfiguration(configPath string) (*Configuration, error) {
 	return &configuration, nil
 }
 
+// decodeHook keeps viper's default decode hooks and decodes YAML timestamps,
+// such as an unquoted anchorDate, back into YYYY-MM-DD strings.
+func decodeHook() viper.DecoderConfigOption {
+	return viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+			if date, ok := data.(time.Time); ok && to.Kind() == reflect.String {
+				return date.Format(datetime.DayLayout), nil
+			}
+			return data, nil
+		},
+	))
+}
+
 // LoadConfigurationFromReader loads the YAML-formatted configuration from an io.Reader.
 // This is useful for scenarios where the configuration is provided dynamically (e.g., via HTTP upload).
 func LoadConfigurationFromReader(reader io.Reader) (*Configuration, error) {
@@ -114,9 +180,15 @@ func LoadConfigurationFromReader(reader io.Reader) (*Configuration, error) {
 	}
 
 	var configuration Configuration
-	if err := v.Unmarshal(&configuration); err != nil {
+	if err := v.Unmarshal(&configuration, decodeHook()); err != nil {
 		return nil, fmt.Errorf("unable to decode into struct, %s", err)
 	}
+	if err := configuration.ResolveScenarios(); err != nil {
+		return nil, err
+	}
+	if err := configuration.ExpandSweeps(); err != nil {
+		return nil, err
+	}
 
 	if !v.IsSet("recommendations.emergencyFundMonths") {
 		configuration.Recommendations.EmergencyFundMonths = constants.DefaultEmergencyFundMonths
@@ -144,9 +216,16 @@ func (conf *Configuration) ParseDateLists() error {
 
 // ParseDateListsWithFixedTime parses all date lists in the configuration using a fixed time
 func (conf *Configuration) ParseDateListsWithFixedTime(fixedTime time.Time) error {
+	if err := conf.resolveDeathDate(); err != nil {
+		return err
+	}
+
 	// First handle the parsing for all Events in Scenarios.
 	for i, scenario := range conf.Scenarios {
 		for j := range scenario.Events {
+			if err := scenario.Events[j].requirePercentOf(); err != nil {
+				return err
+			}
 			err := conf.Scenarios[i].Events[j].FormDateListWithFixedTime(*conf, fixedTime)
 			if err != nil {
 				return err
@@ -161,16 +240,27 @@ func (conf *Configuration) ParseDateListsWithFixedTime(fixedTime time.Time) erro
 		// Check for extra principal payments within loans.
 		for j, loan := range scenario.Loans {
 			for k := range loan.ExtraPrincipalPayments {
+				if loan.ExtraPrincipalPayments[k].Anchored() || loan.ExtraPrincipalPayments[k].PercentageBased() {
+					return fmt.Errorf("loan %s: extra principal payments cannot use anchors or percentOf", loan.Name)
+				}
 				err := conf.Scenarios[i].Loans[j].ExtraPrincipalPayments[k].FormDateListWithFixedTime(*conf, fixedTime)
 				if err != nil {
 					return err
 				}
 			}
 		}
+		for j := range scenario.RevolvingDebts {
+			if err := conf.Scenarios[i].RevolvingDebts[j].formChargeDateLists(*conf, fixedTime); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Next handle the parsing for the Common Events.
 	for i := range conf.Common.Events {
+		if err := conf.Common.Events[i].requirePercentOf(); err != nil {
+			return err
+		}
 		err := conf.Common.Events[i].FormDateListWithFixedTime(*conf, fixedTime)
 		if err != nil {
 			return err
@@ -187,6 +277,9 @@ func (conf *Configuration) ParseDateListsWithFixedTime(fixedTime time.Time) erro
 	// Check for extra principal payments for common loans.
 	for i, loan := range conf.Common.Loans {
 		for j := range loan.ExtraPrincipalPayments {
+			if loan.ExtraPrincipalPayments[j].Anchored() || loan.ExtraPrincipalPayments[j].PercentageBased() {
+				return fmt.Errorf("loan %s: extra principal payments cannot use anchors or percentOf", loan.Name)
+			}
 			err := conf.Common.Loans[i].ExtraPrincipalPayments[j].FormDateListWithFixedTime(*conf, fixedTime)
 			if err != nil {
 				return err
@@ -194,6 +287,12 @@ func (conf *Configuration) ParseDateListsWithFixedTime(fixedTime time.Time) erro
 		}
 	}
 
+	for i := range conf.Common.RevolvingDebts {
+		if err := conf.Common.RevolvingDebts[i].formChargeDateLists(*conf, fixedTime); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -217,17 +316,57 @@ func (event *Event) FormDateList(conf Configuration) error {
 // FormDateListWithFixedTime handles the date to time.Time parsing for one given event
 // with injectable fixed time for testing.
 func (event *Event) FormDateListWithFixedTime(conf Configuration, fixedTime time.Time) error {
+	if event.SocialSecurity != nil {
+		return event.formSocialSecurityDateList(conf, fixedTime)
+	}
+
 	// Validate frequency to prevent infinite loops
 	if event.Frequency <= 0 {
 		return fmt.Errorf("event frequency must be greater than zero, got %d", event.Frequency)
 	}
 
+	if err := event.validateFrequencyUnit(); err != nil {
+		return err
+	}
+	if err := event.validatePercentOf(); err != nil {
+		return err
+	}
+	steps, err := event.validateGrowth()
+	if err != nil {
+		return err
+	}
+
+	// Anchored events are scheduled by the forecast once their milestones are known.
+	if event.Anchored() {
+		if err := event.validateAnchors(); err != nil {
+			return err
+		}
+		event.DateList = []time.Time{}
+		event.AmountList = nil
+		return nil
+	}
+
 	dateList := make([]time.Time, 1)
 	var startDateT time.Time
-	var err error
+
+	// startAge and endAge resolve against a household member's birth date.
+	if event.StartAge != 0 && event.StartDate != "" {
+		return fmt.Errorf("event %s: specify either startDate or startAge, not both", event.Name)
+	}
+	if event.EndAge != 0 && event.EndDate != "" {
+		return fmt.Errorf("event %s: specify either endDate or endAge, not both", event.Name)
+	}
+	if event.Member != "" && event.StartAge == 0 && event.EndAge == 0 {
+		return fmt.Errorf("event %s: member requires startAge or endAge", event.Name)
+	}
 
 	// Unspecified startDate goes to the fixed time.
-	if event.StartDate == "" {
+	if event.StartAge != 0 {
+		startDateT, err = conf.eventDateAtAge(*event, event.StartAge)
+		if err != nil {
+			return err
+		}
+	} else if event.StartDate == "" {
 		// Use datetime package for consistent date handling
 		startDateT = datetime.MustParseTime(DateTimeLayout, fixedTime.Format(DateTimeLayout))
 	} else {
@@ -237,22 +376,45 @@ func (event *Event) FormDateListWithFixedTime(conf Configuration, fixedTime time
 		}
 	}
 
-	// Unspecified endDate goes to the deathDate.
-	if event.EndDate == "" {
-		event.EndDate = conf.Common.DeathDate
+	// An endAge stops the event the month before the member reaches it, so
+	// one event can end and another start at the same age.
+	var endDateT time.Time
+	if event.EndAge != 0 {
+		endDateT, err = conf.eventDateAtAge(*event, event.EndAge)
+		if err != nil {
+			return err
+		}
+		endDateT = endDateT.AddDate(0, -1, 0)
+	} else {
+		// Unspecified endDate goes to the deathDate.
+		if event.EndDate == "" {
+			event.EndDate = conf.Common.DeathDate
+		}
+		endDateT, err = time.Parse(DateTimeLayout, event.EndDate)
+		if err != nil {
+			return err
+		}
 	}
-	endDateT, err := time.Parse(DateTimeLayout, event.EndDate)
-	if err != nil {
-		return err
+
+	// A member who is already past endAge never sees the event.
+	if event.EndAge != 0 && endDateT.Before(startDateT) {
+		event.DateList = []time.Time{}
+		event.AmountList = nil
+		return nil
 	}
 
 	// Identify all dates where an event takes place and aggregate them in dateList.
 	dateList[0] = startDateT
 
-	// Using datetime pattern to generate sequence of dates
+	// Using datetime pattern to generate sequence of dates; sub-monthly
+	// events visit every month and count their occurrences afterward.
+	step := event.Frequency
+	if event.SubMonthly() {
+		step = 1
+	}
 	for {
 		// Calculate next event date based on frequency
-		nextDate := dateList[len(dateList)-1].AddDate(0, event.Frequency, 0)
+		nextDate := dateList[len(dateList)-1].AddDate(0, step, 0)
 
 		if nextDate.Equal(endDateT) {
 			dateList = append(dateList, nextDate)
@@ -265,9 +427,23 @@ func (event *Event) FormDateListWithFixedTime(conf Configuration, fixedTime time
 	}
 
 	event.DateList = dateList
+
+	// Apply growth and the amountSchedule to each date.
+	event.formAmountList(steps, startDateT, conf.Inflation)
+	event.applyOccurrences()
+
 	return nil
 }
 
+// AmountAt returns the amount for the index-th occurrence of the event, falling
+// back to Amount when no growth schedule was computed.
+func (event Event) AmountAt(index int) float64 {
+	if index >= 0 && index < len(event.AmountList) {
+		return event.AmountList[index]
+	}
+	return event.Amount
+}
+
 // ValidateConfiguration performs general validation of the configuration and returns warnings
 func (c *Configuration) ValidateConfiguration() []string {
 	// Convert config structs to configprocessor format
@@ -298,9 +474,14 @@ func (c *Configuration) ValidateConfiguration() []string {
 		})
 	}
 
+	deathDate := c.Common.DeathDate
+	if deathDate == "" {
+		deathDate, _ = c.Household.DeathDate()
+	}
+
 	// Use the configprocessor for validation
 	processor := configprocessor.NewProcessor()
-	return processor.ValidateConfiguration(c.Common.DeathDate, commonEvents, scenarios)
+	return processor.ValidateConfiguration(deathDate, commonEvents, scenarios)
 }
 
 // EmergencyFundMonths returns the configured emergency fund duration, falling back to the default when unset.
@@ -588,6 +588,226 @@ func TestDateBeforeDate(t *testing.T) {
 	}
 }
 
+func TestEventFormDateListGrowth(t *testing.T) {
+	conf := Configuration{
+		Inflation: 3,
+		Common:    Common{DeathDate: "2030-12"},
+	}
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		event    Event
+		expected map[string]float64
+	}{
+		{
+			name:     "constant amount",
+			event:    Event{Amount: -100, StartDate: "2025-03", EndDate: "2027-03", Frequency: 12},
+			expected: nil,
+		},
+		{
+			name:  "indexed to inflation",
+			event: Event{Amount: -100, StartDate: "2025-03", EndDate: "2027-03", Frequency: 6, IndexToInflation: true},
+			expected: map[string]float64{
+				"2025-03": -100, "2025-09": -100, "2026-03": -103, "2026-09": -103, "2027-03": -106.09,
+			},
+		},
+		{
+			name:  "growth rate on top of inflation",
+			event: Event{Amount: 1000, StartDate: "2025-06", EndDate: "2026-06", Frequency: 12, GrowthRate: 2, IndexToInflation: true},
+			expected: map[string]float64{
+				"2025-06": 1000, "2026-06": 1050,
+			},
+		},
+		{
+			name:  "explicit growth only",
+			event: Event{Amount: 1000, StartDate: "2025-06", EndDate: "2026-06", Frequency: 12, GrowthRate: -10},
+			expected: map[string]float64{
+				"2025-06": 1000, "2026-06": 900,
+			},
+		},
+		{
+			name:  "annual raise in growth month",
+			event: Event{Amount: 1000, StartDate: "2025-01", EndDate: "2026-05", Frequency: 2, AnnualGrowthRate: 3, GrowthMonth: 3},
+			expected: map[string]float64{
+				"2025-01": 1000, "2025-03": 1030, "2025-05": 1030, "2025-07": 1030, "2025-09": 1030, "2025-11": 1030,
+				"2026-01": 1030, "2026-03": 1060.9, "2026-05": 1060.9,
+			},
+		},
+		{
+			name: "amount schedule",
+			event: Event{Amount: 1000, StartDate: "2025-01", EndDate: "2026-07", Frequency: 6, AmountSchedule: []AmountStep{
+				{Date: "2025-07", Amount: 2000},
+				{Date: "2026-06", Amount: 3000},
+			}},
+			expected: map[string]float64{
+				"2025-01": 1000, "2025-07": 2000, "2026-01": 2000, "2026-07": 3000,
+			},
+		},
+		{
+			name: "amount schedule grows from each step",
+			event: Event{Amount: 1000, StartDate: "2025-01", EndDate: "2027-01", Frequency: 6, AnnualGrowthRate: 10, AmountSchedule: []AmountStep{
+				{Date: "2025-07", Amount: 2000},
+			}},
+			expected: map[string]float64{
+				"2025-01": 1000, "2025-07": 2000, "2026-01": 2000, "2026-07": 2200, "2027-01": 2200,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.event.FormDateListWithFixedTime(conf, fixedTime); err != nil {
+				t.Fatalf("FormDateListWithFixedTime() error = %v", err)
+			}
+			if tt.expected == nil {
+				if tt.event.AmountList != nil {
+					t.Fatalf("expected no amount list, got %v", tt.event.AmountList)
+				}
+				return
+			}
+			if len(tt.event.AmountList) != len(tt.event.DateList) {
+				t.Fatalf("expected %d amounts, got %d", len(tt.event.DateList), len(tt.event.AmountList))
+			}
+			for i, date := range tt.event.DateList {
+				want := tt.expected[date.Format(DateTimeLayout)]
+				if got := tt.event.AmountAt(i); math.Abs(got-want) > 1e-9 {
+					t.Errorf("amount for %s = %.4f, want %.4f", date.Format(DateTimeLayout), got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEventFormDateListGrowthValidation(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-12"}}
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		event Event
+	}{
+		{"growthRate and annualGrowthRate", Event{Name: "Income", Amount: 1000, Frequency: 1, GrowthRate: 2, AnnualGrowthRate: 3}},
+		{"growthMonth out of range", Event{Name: "Income", Amount: 1000, Frequency: 1, AnnualGrowthRate: 3, GrowthMonth: 13}},
+		{"invalid step date", Event{Name: "Income", Amount: 1000, Frequency: 1, AmountSchedule: []AmountStep{{Date: "2026", Amount: 2000}}}},
+		{"unordered steps", Event{Name: "Income", Amount: 1000, Frequency: 1, AmountSchedule: []AmountStep{
+			{Date: "2027-01", Amount: 3000},
+			{Date: "2026-01", Amount: 2000},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.event.FormDateListWithFixedTime(conf, fixedTime); err == nil {
+				t.Error("FormDateListWithFixedTime() expected error but got none")
+			}
+		})
+	}
+}
+
+func TestLoadConfigurationAnnualGrowthRate(t *testing.T) {
+	yamlConfig := `
+common:
+  deathDate: 2030-01
+  events:
+    - name: Salary
+      amount: 6000
+      frequency: 1
+      annualGrowthRate: 3
+scenarios:
+  - name: Base
+    active: true
+`
+	conf, err := LoadConfigurationFromReader(bytes.NewReader([]byte(yamlConfig)))
+	if err != nil {
+		t.Fatalf("LoadConfigurationFromReader() error = %v", err)
+	}
+	if got := conf.Common.Events[0].EffectiveGrowthRate(0); got != 3 {
+		t.Errorf("EffectiveGrowthRate() = %.2f, want annualGrowthRate 3 to apply like growthRate", got)
+	}
+}
+
+func TestEventPercentOf(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-12"}}
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		event    Event
+		wantKind string
+		wantName string
+		wantErr  bool
+	}{
+		{"income", Event{Name: "Tithe", Percentage: -10, PercentOf: "Income", Frequency: 1}, PercentOfIncome, "", false},
+		{"event", Event{Name: "401k", Percentage: 10, PercentOf: "event: Salary", Frequency: 1}, PercentOfEvent, "Salary", false},
+		{"unknown source", Event{Name: "Tithe", Percentage: 10, PercentOf: "salary", Frequency: 1}, "", "", true},
+		{"unnamed event", Event{Name: "Tithe", Percentage: 10, PercentOf: "event:", Frequency: 1}, "", "", true},
+		{"itself", Event{Name: "Tithe", Percentage: 10, PercentOf: "event:Tithe", Frequency: 1}, "", "", true},
+		{"missing percentage", Event{Name: "Tithe", PercentOf: "income", Frequency: 1}, "", "", true},
+		{"with amount", Event{Name: "Tithe", Amount: -100, Percentage: 10, PercentOf: "income", Frequency: 1}, "", "", true},
+		{"with growth", Event{Name: "Tithe", Percentage: 10, PercentOf: "income", Frequency: 1, IndexToInflation: true}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.FormDateListWithFixedTime(conf, fixedTime)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("FormDateListWithFixedTime() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FormDateListWithFixedTime() error = %v", err)
+			}
+			kind, name, err := tt.event.PercentOfSource()
+			if err != nil || kind != tt.wantKind || name != tt.wantName {
+				t.Errorf("PercentOfSource() = %q, %q, %v, want %q, %q", kind, name, err, tt.wantKind, tt.wantName)
+			}
+		})
+	}
+
+	bare := Configuration{Common: Common{DeathDate: "2030-12", Events: []Event{{Name: "Tithe", Percentage: 10, Frequency: 1}}}}
+	if err := bare.ParseDateListsWithFixedTime(fixedTime); err == nil {
+		t.Error("expected an error for a percentage without percentOf")
+	}
+}
+
+func TestProcessLoansEscrowIndexedToInflation(t *testing.T) {
+	conf := &Configuration{
+		Inflation: 4,
+		Common: Common{
+			DeathDate: "2030-01",
+			Loans: []Loan{
+				{
+					Name:                   "Mortgage",
+					StartDate:              "2025-01",
+					Principal:              100000,
+					InterestRate:           5.0,
+					Term:                   120,
+					Escrow:                 300,
+					EscrowGrowthRate:       1,
+					EscrowIndexToInflation: true,
+				},
+			},
+		},
+	}
+
+	if err := conf.ProcessLoans(zap.NewNop()); err != nil {
+		t.Fatalf("ProcessLoans() error = %v", err)
+	}
+
+	loan := conf.Common.Loans[0]
+	if loan.EscrowAnnualGrowth != 5 {
+		t.Fatalf("EscrowAnnualGrowth = %.2f, want 5", loan.EscrowAnnualGrowth)
+	}
+	first := loan.AmortizationSchedule["2025-12"].Payment
+	second := loan.AmortizationSchedule["2026-01"].Payment
+	if math.Abs((second-first)-15) > 1e-6 {
+		t.Errorf("expected escrow to grow by 15.00 in the second loan year, got %.2f", second-first)
+	}
+}
+
 func TestProcessLoans(t *testing.T) {
 	logger := zap.NewNop()
 
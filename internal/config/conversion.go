@@ -15,12 +15,18 @@ func (loan *Loan) ToLoansConfig() *loans.LoanConfig {
 
 	loanConfig := &loans.LoanConfig{
 		Name:                    loan.Name,
+		Type:                    loan.Type,
 		StartDate:               loan.StartDate,
 		Principal:               loan.Principal,
 		InterestRate:            loan.InterestRate,
 		Term:                    loan.Term,
+		Payment:                 loan.Payment,
+		PaymentFrequency:        loan.PaymentFrequency,
+		InterestOnlyMonths:      loan.InterestOnlyMonths,
+		AmortizationTerm:        loan.AmortizationTerm,
 		DownPayment:             loan.DownPayment,
 		Escrow:                  loan.Escrow,
+		EscrowGrowthRate:        loan.EscrowAnnualGrowth,
 		MortgageInsurance:       loan.MortgageInsurance,
 		MortgageInsuranceCutoff: loan.MortgageInsuranceCutoff,
 		EarlyPayoffThreshold:    loan.EarlyPayoffThreshold,
@@ -28,6 +34,7 @@ func (loan *Loan) ToLoansConfig() *loans.LoanConfig {
 		SellProperty:            loan.SellProperty,
 		SellPrice:               loan.SellPrice,
 		SellCostsNet:            loan.SellCostsNet,
+		Adjustable:              loan.AdjustableRate.toLoans(),
 		AmortizationSchedule:    make(map[string]loans.Payment),
 	}
 
@@ -38,15 +45,20 @@ func (loan *Loan) ToLoansConfig() *loans.LoanConfig {
 			dateList = append(dateList, eventDate.Format(datetime.DateTimeLayout))
 		}
 		loanConfig.ExtraPrincipalPayments = append(loanConfig.ExtraPrincipalPayments, loans.Event{
-			Name:      event.Name,
-			Amount:    event.Amount,
-			StartDate: event.StartDate,
-			EndDate:   event.EndDate,
-			Frequency: event.Frequency,
-			DateList:  dateList,
+			Name:       event.Name,
+			Amount:     event.Amount,
+			StartDate:  event.StartDate,
+			EndDate:    event.EndDate,
+			Frequency:  event.Frequency,
+			DateList:   dateList,
+			AmountList: event.AmountList,
 		})
 	}
 
+	for _, refinance := range loan.Refinances {
+		loanConfig.Refinances = append(loanConfig.Refinances, loans.Refinance(refinance))
+	}
+
 	// Convert AmortizationSchedule if it exists
 	for date, payment := range loan.AmortizationSchedule {
 		loanConfig.AmortizationSchedule[date] = loans.Payment{
@@ -55,6 +67,7 @@ func (loan *Loan) ToLoansConfig() *loans.LoanConfig {
 			Interest:           payment.Interest,
 			RemainingPrincipal: payment.RemainingPrincipal,
 			RefundableEscrow:   payment.RefundableEscrow,
+			InterestRate:       payment.InterestRate,
 		}
 	}
 
@@ -69,6 +82,7 @@ func FromLoansPayment(payment loans.Payment) Payment {
 		Interest:           payment.Interest,
 		RemainingPrincipal: payment.RemainingPrincipal,
 		RefundableEscrow:   payment.RefundableEscrow,
+		InterestRate:       payment.InterestRate,
 	}
 }
 
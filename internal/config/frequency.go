@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+// Units an event's frequency may count. Months is the default.
+const (
+	FrequencyUnitMonths      = "months"
+	FrequencyUnitWeeks       = "weeks"
+	FrequencyUnitSemimonthly = "semimonthly"
+)
+
+// daysPerWeek converts a weekly frequency to its period in days.
+const daysPerWeek = 7
+
+// SubMonthly reports whether the event can occur more than once a month.
+// Such events keep one DateList entry per month they occur in, with
+// AmountList holding the month's total.
+func (event Event) SubMonthly() bool {
+	return event.FrequencyUnit == FrequencyUnitWeeks || event.FrequencyUnit == FrequencyUnitSemimonthly
+}
+
+// validateFrequencyUnit checks the frequency unit and the anchor date weekly
+// events count from.
+func (event Event) validateFrequencyUnit() error {
+	switch event.FrequencyUnit {
+	case "", FrequencyUnitMonths:
+		if event.AnchorDate != "" {
+			return fmt.Errorf("event %s: anchorDate only applies to a frequencyUnit of weeks", event.Name)
+		}
+		return nil
+	case FrequencyUnitWeeks:
+		if event.AnchorDate == "" {
+			return fmt.Errorf("event %s: a frequencyUnit of weeks requires an anchorDate", event.Name)
+		}
+		if _, err := time.Parse(datetime.DayLayout, event.AnchorDate); err != nil {
+			return fmt.Errorf("event %s: invalid anchorDate %q, expected YYYY-MM-DD", event.Name, event.AnchorDate)
+		}
+	case FrequencyUnitSemimonthly:
+		if event.Frequency != 1 {
+			return fmt.Errorf("event %s: semimonthly events occur twice a month and require a frequency of 1", event.Name)
+		}
+		if event.AnchorDate != "" {
+			return fmt.Errorf("event %s: anchorDate only applies to a frequencyUnit of weeks", event.Name)
+		}
+	default:
+		return fmt.Errorf("event %s: unknown frequencyUnit %q, expected months, weeks, or semimonthly", event.Name, event.FrequencyUnit)
+	}
+	if event.PercentageBased() {
+		return fmt.Errorf("event %s: percentOf amounts are monthly and cannot set a frequencyUnit of %s", event.Name, event.FrequencyUnit)
+	}
+	return nil
+}
+
+// occurrencesIn returns how many times a sub-monthly event occurs in month.
+func (event Event) occurrencesIn(month time.Time) int {
+	if event.FrequencyUnit == FrequencyUnitSemimonthly {
+		return 2
+	}
+	anchor, err := time.Parse(datetime.DayLayout, event.AnchorDate)
+	if err != nil {
+		return 0
+	}
+	return datetime.OccurrencesInMonth(anchor, event.Frequency*daysPerWeek, month)
+}
+
+// applyOccurrences scales a sub-monthly event's per-occurrence amounts by the
+// number of occurrences in each month, dropping the months it skips.
+func (event *Event) applyOccurrences() {
+	if !event.SubMonthly() {
+		return
+	}
+	dates := make([]time.Time, 0, len(event.DateList))
+	amounts := make([]float64, 0, len(event.DateList))
+	for i, date := range event.DateList {
+		count := event.occurrencesIn(date)
+		if count == 0 {
+			continue
+		}
+		dates = append(dates, date)
+		amounts = append(amounts, event.AmountAt(i)*float64(count))
+	}
+	event.DateList = dates
+	event.AmountList = amounts
+}
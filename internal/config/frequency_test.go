@@ -0,0 +1,117 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+func TestFormDateListSubMonthly(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-01"}}
+	fixedTime := datetime.MustParseTime(DateTimeLayout, "2025-01")
+
+	t.Run("biweekly paycheck", func(t *testing.T) {
+		// Fridays from 2025-01-03 fall three times in January and August.
+		event := Event{Name: "Paycheck", Amount: 1000, Frequency: 2, FrequencyUnit: FrequencyUnitWeeks, AnchorDate: "2025-01-03", StartDate: "2025-01", EndDate: "2025-12"}
+		if err := event.FormDateListWithFixedTime(conf, fixedTime); err != nil {
+			t.Fatalf("FormDateListWithFixedTime() error = %v", err)
+		}
+		if len(event.DateList) != 12 || len(event.AmountList) != 12 {
+			t.Fatalf("expected one entry per month, got %d dates and %d amounts", len(event.DateList), len(event.AmountList))
+		}
+		total := 0.0
+		for i, date := range event.DateList {
+			want := 2000.0
+			if date.Month() == time.January || date.Month() == time.August {
+				want = 3000
+			}
+			if event.AmountAt(i) != want {
+				t.Errorf("%s: amount %.2f, want %.2f", date.Format(DateTimeLayout), event.AmountAt(i), want)
+			}
+			total += event.AmountAt(i)
+		}
+		if total != 26000 {
+			t.Errorf("annual total = %.2f, want 26000.00", total)
+		}
+	})
+
+	t.Run("semimonthly with growth", func(t *testing.T) {
+		event := Event{Name: "Paycheck", Amount: 1000, Frequency: 1, FrequencyUnit: FrequencyUnitSemimonthly, AnnualGrowthRate: 10, StartDate: "2025-01", EndDate: "2026-01"}
+		if err := event.FormDateListWithFixedTime(conf, fixedTime); err != nil {
+			t.Fatalf("FormDateListWithFixedTime() error = %v", err)
+		}
+		if len(event.DateList) != 13 || event.AmountAt(0) != 2000 {
+			t.Fatalf("expected 13 months starting at 2000.00, got %d starting at %.2f", len(event.DateList), event.AmountAt(0))
+		}
+		if got := event.AmountAt(12); got < 2199.99 || got > 2200.01 {
+			t.Errorf("amount after a year of growth = %.2f, want 2200.00", got)
+		}
+	})
+
+	t.Run("every six weeks skips months", func(t *testing.T) {
+		event := Event{Name: "Service", Amount: -60, Frequency: 6, FrequencyUnit: FrequencyUnitWeeks, AnchorDate: "2025-01-01", StartDate: "2025-01", EndDate: "2025-12"}
+		if err := event.FormDateListWithFixedTime(conf, fixedTime); err != nil {
+			t.Fatalf("FormDateListWithFixedTime() error = %v", err)
+		}
+		// 2025-01-01 plus multiples of 42 days misses April, August, and
+		// November.
+		for _, date := range event.DateList {
+			if date.Month() == time.April || date.Month() == time.August || date.Month() == time.November {
+				t.Errorf("expected no occurrence in %s, got %v", date.Month(), event.DateList)
+			}
+		}
+		if len(event.DateList) != 9 || len(event.AmountList) != 9 {
+			t.Errorf("expected 9 months, got %d dates and %d amounts", len(event.DateList), len(event.AmountList))
+		}
+	})
+}
+
+func TestValidateFrequencyUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   Event
+		wantErr bool
+	}{
+		{name: "default months", event: Event{Frequency: 1}},
+		{name: "explicit months", event: Event{Frequency: 3, FrequencyUnit: "months"}},
+		{name: "weeks", event: Event{Frequency: 2, FrequencyUnit: "weeks", AnchorDate: "2025-01-03"}},
+		{name: "weeks without anchorDate", event: Event{Frequency: 2, FrequencyUnit: "weeks"}, wantErr: true},
+		{name: "weeks with a month anchorDate", event: Event{Frequency: 2, FrequencyUnit: "weeks", AnchorDate: "2025-01"}, wantErr: true},
+		{name: "semimonthly", event: Event{Frequency: 1, FrequencyUnit: "semimonthly"}},
+		{name: "semimonthly every other month", event: Event{Frequency: 2, FrequencyUnit: "semimonthly"}, wantErr: true},
+		{name: "anchorDate on a monthly event", event: Event{Frequency: 1, AnchorDate: "2025-01-03"}, wantErr: true},
+		{name: "percentOf", event: Event{Frequency: 1, FrequencyUnit: "semimonthly", PercentOf: "income", Percentage: 5}, wantErr: true},
+		{name: "unknown unit", event: Event{Frequency: 1, FrequencyUnit: "days"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.validateFrequencyUnit()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateFrequencyUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigurationAnchorDate(t *testing.T) {
+	// YAML reads an unquoted YYYY-MM-DD as a timestamp.
+	yamlConfig := `
+common:
+  deathDate: 2030-01
+  events:
+    - name: Paycheck
+      amount: 2500.00
+      frequency: 2
+      frequencyUnit: weeks
+      anchorDate: 2025-01-03
+`
+	conf, err := LoadConfigurationFromReader(strings.NewReader(yamlConfig))
+	if err != nil {
+		t.Fatalf("LoadConfigurationFromReader() error = %v", err)
+	}
+	if got := conf.Common.Events[0].AnchorDate; got != "2025-01-03" {
+		t.Errorf("anchorDate = %q, want 2025-01-03", got)
+	}
+}
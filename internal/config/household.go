@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+// Household lists the people a plan covers. The first member is the primary
+// member used when an age-based schedule does not name one.
+type Household struct {
+	Members []Member `yaml:"members" mapstructure:"members"`
+}
+
+// Member is a person in the household. LifeExpectancy is an age in years; when
+// common.deathDate is omitted the forecast runs until the last member reaches it.
+type Member struct {
+	Name           string `yaml:"name" mapstructure:"name"`
+	BirthDate      string `yaml:"birthDate" mapstructure:"birthDate"`
+	LifeExpectancy int    `yaml:"lifeExpectancy,omitempty" mapstructure:"lifeExpectancy"`
+}
+
+// Birth returns the member's parsed birth month.
+func (m Member) Birth() (time.Time, error) {
+	birth, err := time.Parse(DateTimeLayout, m.BirthDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("household member %s: invalid birthDate %q, expected YYYY-MM: %w", m.Name, m.BirthDate, err)
+	}
+	return birth, nil
+}
+
+// DateAtAge returns the month in which the member turns age.
+func (m Member) DateAtAge(age int) (time.Time, error) {
+	birth, err := m.Birth()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return birth.AddDate(age, 0, 0), nil
+}
+
+// AgeAt returns the member's age in whole years on date, or zero when the
+// birth date is invalid.
+func (m Member) AgeAt(date time.Time) int {
+	birth, err := m.Birth()
+	if err != nil {
+		return 0
+	}
+	return datetime.MonthsBetween(birth, date) / constants.MonthsPerYear
+}
+
+// Member returns the named household member, or the primary member when name
+// is empty.
+func (h *Household) Member(name string) (Member, error) {
+	if h == nil || len(h.Members) == 0 {
+		return Member{}, fmt.Errorf("age-based schedules require a household member")
+	}
+	if name == "" {
+		return h.Members[0], nil
+	}
+	for _, member := range h.Members {
+		if member.Name == name {
+			return member, nil
+		}
+	}
+	return Member{}, fmt.Errorf("unknown household member %q", name)
+}
+
+// DeathDate returns the month the last member reaches their life expectancy,
+// or an empty string when no member sets one.
+func (h *Household) DeathDate() (string, error) {
+	if h == nil {
+		return "", nil
+	}
+	var last time.Time
+	for _, member := range h.Members {
+		if member.LifeExpectancy == 0 {
+			continue
+		}
+		date, err := member.DateAtAge(member.LifeExpectancy)
+		if err != nil {
+			return "", err
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+	if last.IsZero() {
+		return "", nil
+	}
+	return last.Format(DateTimeLayout), nil
+}
+
+// Validate checks member names, birth dates, and life expectancies.
+func (h *Household) Validate() error {
+	if h == nil {
+		return nil
+	}
+	names := make(map[string]bool, len(h.Members))
+	for i, member := range h.Members {
+		if member.Name == "" {
+			return fmt.Errorf("household member %d: name cannot be empty", i)
+		}
+		if names[member.Name] {
+			return fmt.Errorf("household member %s: duplicate name", member.Name)
+		}
+		names[member.Name] = true
+		if _, err := member.Birth(); err != nil {
+			return err
+		}
+		if member.LifeExpectancy < 0 {
+			return fmt.Errorf("household member %s: lifeExpectancy cannot be negative", member.Name)
+		}
+	}
+	return nil
+}
+
+// resolveDeathDate fills in common.deathDate from the household's life
+// expectancies when it is not set explicitly.
+func (conf *Configuration) resolveDeathDate() error {
+	if err := conf.Household.Validate(); err != nil {
+		return err
+	}
+	if conf.Common.DeathDate != "" {
+		return nil
+	}
+	deathDate, err := conf.Household.DeathDate()
+	if err != nil {
+		return err
+	}
+	conf.Common.DeathDate = deathDate
+	return nil
+}
+
+// eventDateAtAge resolves an event's startAge or endAge against its member.
+func (conf Configuration) eventDateAtAge(event Event, age int) (time.Time, error) {
+	member, err := conf.Household.Member(event.Member)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("event %s: %w", event.Name, err)
+	}
+	return member.DateAtAge(age)
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+func TestHouseholdDeathDate(t *testing.T) {
+	conf := Configuration{
+		Household: &Household{Members: []Member{
+			{Name: "Alex", BirthDate: "1980-04", LifeExpectancy: 90},
+			{Name: "Sam", BirthDate: "1983-09", LifeExpectancy: 88},
+		}},
+		Scenarios: []Scenario{{Name: "Base", Active: true}},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+	if conf.Common.DeathDate != "2071-09" {
+		t.Errorf("DeathDate = %s, want 2071-09 from the longest-lived member", conf.Common.DeathDate)
+	}
+
+	conf.Common.DeathDate = "2060-01"
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+	if conf.Common.DeathDate != "2060-01" {
+		t.Errorf("explicit deathDate overridden: %s", conf.Common.DeathDate)
+	}
+}
+
+func TestEventAgeScheduling(t *testing.T) {
+	household := &Household{Members: []Member{
+		{Name: "Alex", BirthDate: "1980-04"},
+		{Name: "Sam", BirthDate: "1983-09"},
+	}}
+	fixedTime := datetime.MustParseTime(DateTimeLayout, "2025-01")
+
+	tests := []struct {
+		name      string
+		event     Event
+		wantFirst string
+		wantLast  string
+		wantCount int
+		wantErr   bool
+	}{
+		{"end at primary member's age", Event{Name: "Salary", Frequency: 1, EndAge: 62}, "2025-01", "2042-03", 207, false},
+		{"start at named member's age", Event{Name: "Pension", Frequency: 1, Member: "Sam", StartAge: 65}, "2048-09", "2060-01", 137, false},
+		{"age window", Event{Name: "Part time", Frequency: 12, StartAge: 62, EndAge: 65}, "2042-04", "2044-04", 3, false},
+		{"already past end age", Event{Name: "Old job", Frequency: 1, EndAge: 40}, "", "", 0, false},
+		{"both start date and age", Event{Name: "Bad", Frequency: 1, StartDate: "2030-01", StartAge: 50}, "", "", 0, true},
+		{"unknown member", Event{Name: "Bad", Frequency: 1, Member: "Pat", StartAge: 50}, "", "", 0, true},
+		{"member without age", Event{Name: "Bad", Frequency: 1, Member: "Sam"}, "", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := Configuration{Household: household, Common: Common{DeathDate: "2060-01"}}
+			event := tt.event
+			err := event.FormDateListWithFixedTime(conf, fixedTime)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormDateListWithFixedTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(event.DateList) != tt.wantCount {
+				t.Fatalf("len(DateList) = %d, want %d", len(event.DateList), tt.wantCount)
+			}
+			if tt.wantCount == 0 {
+				return
+			}
+			if got := event.DateList[0].Format(DateTimeLayout); got != tt.wantFirst {
+				t.Errorf("first date = %s, want %s", got, tt.wantFirst)
+			}
+			if got := event.DateList[len(event.DateList)-1].Format(DateTimeLayout); got != tt.wantLast {
+				t.Errorf("last date = %s, want %s", got, tt.wantLast)
+			}
+		})
+	}
+
+	if err := (&Event{Name: "No household", Frequency: 1, StartAge: 60}).FormDateListWithFixedTime(Configuration{Common: Common{DeathDate: "2060-01"}}, fixedTime); err == nil {
+		t.Error("expected error for startAge without a household")
+	}
+}
+
+func TestHouseholdValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []Member
+		wantErr bool
+	}{
+		{"valid", []Member{{Name: "Alex", BirthDate: "1980-04", LifeExpectancy: 90}}, false},
+		{"missing name", []Member{{BirthDate: "1980-04"}}, true},
+		{"duplicate name", []Member{{Name: "Alex", BirthDate: "1980-04"}, {Name: "Alex", BirthDate: "1981-04"}}, true},
+		{"bad birth date", []Member{{Name: "Alex", BirthDate: "April 1980"}}, true},
+		{"negative life expectancy", []Member{{Name: "Alex", BirthDate: "1980-04", LifeExpectancy: -1}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Household{Members: tt.members}).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemberAgeAt(t *testing.T) {
+	member := Member{Name: "Alex", BirthDate: "1980-04"}
+	tests := map[string]int{"2025-03": 44, "2025-04": 45, "2080-12": 100}
+	for date, want := range tests {
+		if got := member.AgeAt(datetime.MustParseTime(DateTimeLayout, date)); got != want {
+			t.Errorf("AgeAt(%s) = %d, want %d", date, got, want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScenarioRemovals names the items a scenario drops from the scenario it
+// extends.
+type ScenarioRemovals struct {
+	Events         []string `yaml:"events,omitempty" mapstructure:"events"`
+	Loans          []string `yaml:"loans,omitempty" mapstructure:"loans"`
+	Investments    []string `yaml:"investments,omitempty" mapstructure:"investments"`
+	RevolvingDebts []string `yaml:"revolvingDebts,omitempty" mapstructure:"revolvingDebts"`
+	Assets         []string `yaml:"assets,omitempty" mapstructure:"assets"`
+	Triggers       []string `yaml:"triggers,omitempty" mapstructure:"triggers"`
+}
+
+// Clone returns a deep copy of the removals.
+func (removals *ScenarioRemovals) Clone() *ScenarioRemovals {
+	if removals == nil {
+		return nil
+	}
+	return &ScenarioRemovals{
+		Events:         append([]string(nil), removals.Events...),
+		Loans:          append([]string(nil), removals.Loans...),
+		Investments:    append([]string(nil), removals.Investments...),
+		RevolvingDebts: append([]string(nil), removals.RevolvingDebts...),
+		Assets:         append([]string(nil), removals.Assets...),
+		Triggers:       append([]string(nil), removals.Triggers...),
+	}
+}
+
+// ResolveScenarios expands every scenario that extends another into the full
+// scenario: the parent's items with the scenario's own items replacing those
+// of the same name or added after them, less the items it removes. The
+// scenario's debtPayoff plan defaults to the parent's. Scenarios may extend
+// scenarios that extend others, in any order, but not in a cycle.
+func (conf *Configuration) ResolveScenarios() error {
+	if conf == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+	const (
+		unresolved = iota
+		resolving
+		resolved
+	)
+	state := make([]int, len(conf.Scenarios))
+
+	var resolve func(i int) error
+	resolve = func(i int) error {
+		scenario := conf.Scenarios[i]
+		switch {
+		case state[i] == resolved:
+			return nil
+		case state[i] == resolving:
+			return fmt.Errorf("scenario %s: extends forms a cycle", scenario.Name)
+		case scenario.Extends == "":
+			if scenario.Remove != nil {
+				return fmt.Errorf("scenario %s: remove requires extends", scenario.Name)
+			}
+			state[i] = resolved
+			return nil
+		}
+		state[i] = resolving
+		parent, err := conf.findScenario(scenario.Extends)
+		if err != nil {
+			return fmt.Errorf("scenario %s: %w", scenario.Name, err)
+		}
+		if err := resolve(parent); err != nil {
+			return err
+		}
+		merged, err := scenario.inherit(conf.Scenarios[parent])
+		if err != nil {
+			return fmt.Errorf("scenario %s: %w", scenario.Name, err)
+		}
+		conf.Scenarios[i] = merged
+		state[i] = resolved
+		return nil
+	}
+
+	for i := range conf.Scenarios {
+		if err := resolve(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compact returns a copy of the configuration with each scenario that extends
+// another reduced to what differs from its parent, the form ResolveScenarios
+// expands. Items changed since resolution, such as optimized amounts, are kept
+// as overrides. Scenarios generated by sweeps are dropped, as the sweeps
+// themselves are kept.
+func (conf *Configuration) Compact() *Configuration {
+	compact := conf.Clone()
+	if compact == nil {
+		return nil
+	}
+	compact.Scenarios = compact.Scenarios[:0]
+	for _, scenario := range conf.Scenarios {
+		switch {
+		case scenario.Sweep != "":
+			continue
+		case scenario.Extends == "":
+			compact.Scenarios = append(compact.Scenarios, scenario.Clone())
+			continue
+		}
+		parent, err := conf.findScenario(scenario.Extends)
+		if err != nil {
+			compact.Scenarios = append(compact.Scenarios, scenario.Clone())
+			continue
+		}
+		compact.Scenarios = append(compact.Scenarios, scenario.Clone().compactAgainst(conf.Scenarios[parent]))
+	}
+	return compact
+}
+
+// findScenario returns the index of the scenario with the given name.
+func (conf *Configuration) findScenario(name string) (int, error) {
+	found := -1
+	for i, scenario := range conf.Scenarios {
+		if scenario.Name != name {
+			continue
+		}
+		if found >= 0 {
+			return 0, fmt.Errorf("extends %q matches more than one scenario", name)
+		}
+		found = i
+	}
+	if found < 0 {
+		return 0, fmt.Errorf("extends unknown scenario %q", name)
+	}
+	return found, nil
+}
+
+// inherit returns the scenario merged over its resolved parent.
+func (scenario Scenario) inherit(parent Scenario) (Scenario, error) {
+	removals := scenario.Remove
+	if removals == nil {
+		removals = &ScenarioRemovals{}
+	}
+	merged := scenario
+	var err error
+	if merged.Events, err = mergeNamed("events", cloneEvents(parent.Events), scenario.Events, removals.Events, func(e Event) string { return e.Name }); err != nil {
+		return merged, err
+	}
+	if merged.Loans, err = mergeNamed("loans", cloneLoans(parent.Loans), scenario.Loans, removals.Loans, func(l Loan) string { return l.Name }); err != nil {
+		return merged, err
+	}
+	if merged.Investments, err = mergeNamed("investments", cloneInvestments(parent.Investments), scenario.Investments, removals.Investments, func(i Investment) string { return i.Name }); err != nil {
+		return merged, err
+	}
+	if merged.RevolvingDebts, err = mergeNamed("revolvingDebts", cloneRevolvingDebts(parent.RevolvingDebts), scenario.RevolvingDebts, removals.RevolvingDebts, func(d RevolvingDebt) string { return d.Name }); err != nil {
+		return merged, err
+	}
+	if merged.Assets, err = mergeNamed("assets", cloneAssets(parent.Assets), scenario.Assets, removals.Assets, func(a Asset) string { return a.Name }); err != nil {
+		return merged, err
+	}
+	if merged.Triggers, err = mergeNamed("triggers", cloneTriggers(parent.Triggers), scenario.Triggers, removals.Triggers, func(t Trigger) string { return t.Name }); err != nil {
+		return merged, err
+	}
+	if merged.DebtPayoff == nil {
+		merged.DebtPayoff = parent.DebtPayoff.Clone()
+	}
+	return merged, nil
+}
+
+// mergeNamed drops the removed names from inherited, then replaces inherited
+// items with own items of the same name and appends the rest.
+func mergeNamed[T any](kind string, inherited, own []T, remove []string, name func(T) string) ([]T, error) {
+	for _, removed := range remove {
+		found := false
+		for _, item := range inherited {
+			found = found || (removed != "" && name(item) == removed)
+		}
+		if !found {
+			return nil, fmt.Errorf("remove.%s names %q, which the extended scenario does not have", kind, removed)
+		}
+	}
+
+	merged := make([]T, 0, len(inherited)+len(own))
+	for _, item := range inherited {
+		kept := true
+		for _, removed := range remove {
+			kept = kept && name(item) != removed
+		}
+		if kept {
+			merged = append(merged, item)
+		}
+	}
+	for _, item := range own {
+		replaced := false
+		for i := range merged {
+			if name(item) != "" && name(merged[i]) == name(item) {
+				merged[i] = item
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, item)
+		}
+	}
+	return merged, nil
+}
+
+// compactAgainst reduces a resolved scenario to its differences from parent.
+func (scenario Scenario) compactAgainst(parent Scenario) Scenario {
+	removals := &ScenarioRemovals{}
+	scenario.Events, removals.Events = compactNamed(parent.Events, scenario.Events, func(e Event) string { return e.Name }, sameYAML[Event])
+	scenario.Loans, removals.Loans = compactNamed(parent.Loans, scenario.Loans, func(l Loan) string { return l.Name }, sameLoan)
+	scenario.Investments, removals.Investments = compactNamed(parent.Investments, scenario.Investments, func(i Investment) string { return i.Name }, sameYAML[Investment])
+	scenario.RevolvingDebts, removals.RevolvingDebts = compactNamed(parent.RevolvingDebts, scenario.RevolvingDebts, func(d RevolvingDebt) string { return d.Name }, sameYAML[RevolvingDebt])
+	scenario.Assets, removals.Assets = compactNamed(parent.Assets, scenario.Assets, func(a Asset) string { return a.Name }, sameYAML[Asset])
+	scenario.Triggers, removals.Triggers = compactNamed(parent.Triggers, scenario.Triggers, func(t Trigger) string { return t.Name }, sameYAML[Trigger])
+	if sameYAML(scenario.DebtPayoff, parent.DebtPayoff) {
+		scenario.DebtPayoff = nil
+	}
+
+	scenario.Remove = nil
+	if len(removals.Events)+len(removals.Loans)+len(removals.Investments)+
+		len(removals.RevolvingDebts)+len(removals.Assets)+len(removals.Triggers) > 0 {
+		scenario.Remove = removals
+	}
+	return scenario
+}
+
+// compactNamed returns the items of expanded that are new or differ from
+// inherited, and the names of inherited items expanded no longer has.
+func compactNamed[T any](inherited, expanded []T, name func(T) string, same func(a, b T) bool) ([]T, []string) {
+	var own []T
+	for _, item := range expanded {
+		unchanged := false
+		for _, parentItem := range inherited {
+			if name(item) != "" && name(parentItem) == name(item) {
+				unchanged = same(item, parentItem)
+				break
+			}
+		}
+		if !unchanged {
+			own = append(own, item)
+		}
+	}
+
+	var removed []string
+	for _, parentItem := range inherited {
+		kept := false
+		for _, item := range expanded {
+			kept = kept || name(item) == name(parentItem)
+		}
+		if !kept {
+			removed = append(removed, name(parentItem))
+		}
+	}
+	return own, removed
+}
+
+// sameYAML reports whether a and b write the same configuration.
+func sameYAML[T any](a, b T) bool {
+	x, err := yaml.Marshal(a)
+	if err != nil {
+		return false
+	}
+	y, err := yaml.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(x, y)
+}
+
+// sameLoan compares loans by configuration, ignoring their computed
+// amortization schedules, which a scenario's debtPayoff plan may change.
+func sameLoan(a, b Loan) bool {
+	a.AmortizationSchedule, b.AmortizationSchedule = nil, nil
+	return sameYAML(a, b)
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func inheritanceTestConfig() Configuration {
+	return Configuration{
+		Scenarios: []Scenario{
+			{
+				Name:    "Early retirement",
+				Active:  true,
+				Extends: "Move",
+				Remove:  &ScenarioRemovals{Events: []string{"Salary"}},
+			},
+			{
+				Name:   "Base",
+				Active: true,
+				Events: []Event{
+					{Name: "Salary", Amount: 6000, Frequency: 1},
+					{Name: "Rent", Amount: -2000, Frequency: 1},
+				},
+				Loans:       []Loan{{Name: "Car", Principal: 20000, InterestRate: 5, Term: 60}},
+				Investments: []Investment{{Name: "Brokerage", StartingValue: 10000}},
+				DebtPayoff:  &DebtPayoff{Strategy: "avalanche", Budget: 100},
+			},
+			{
+				Name:    "Move",
+				Active:  true,
+				Extends: "Base",
+				Events: []Event{
+					{Name: "Rent", Amount: -2500, Frequency: 1},
+					{Name: "Moving costs", Amount: -5000, Frequency: 1, StartDate: "2026-06", EndDate: "2026-06"},
+				},
+				Remove: &ScenarioRemovals{Loans: []string{"Car"}},
+			},
+		},
+	}
+}
+
+func eventNames(events []Event) []string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.Name
+	}
+	return names
+}
+
+func TestResolveScenarios(t *testing.T) {
+	conf := inheritanceTestConfig()
+	if err := conf.ResolveScenarios(); err != nil {
+		t.Fatalf("ResolveScenarios() error = %v", err)
+	}
+
+	move := conf.Scenarios[2]
+	if got := strings.Join(eventNames(move.Events), ", "); got != "Salary, Rent, Moving costs" {
+		t.Errorf("Move events = %s, want Salary, Rent, Moving costs", got)
+	}
+	if move.Events[1].Amount != -2500 {
+		t.Errorf("Move rent = %.2f, want the override -2500.00", move.Events[1].Amount)
+	}
+	if move.Loans == nil || len(move.Loans) != 0 {
+		t.Errorf("Move should remove the car loan, leaving an empty list, got %#v", move.Loans)
+	}
+	if len(move.Investments) != 1 || move.DebtPayoff == nil || move.DebtPayoff.Strategy != "avalanche" {
+		t.Errorf("Move should inherit investments and the debt payoff plan, got %v and %+v", move.Investments, move.DebtPayoff)
+	}
+
+	// A scenario may extend one declared after it that itself extends another.
+	retirement := conf.Scenarios[0]
+	if got := strings.Join(eventNames(retirement.Events), ", "); got != "Rent, Moving costs" {
+		t.Errorf("Early retirement events = %s, want Rent, Moving costs", got)
+	}
+	if !retirement.Active || retirement.Name != "Early retirement" {
+		t.Errorf("Early retirement should keep its own name and active flag, got %s, %v", retirement.Name, retirement.Active)
+	}
+
+	// Inherited items are copies.
+	move.Events[0].Amount = 7000
+	if conf.Scenarios[1].Events[0].Amount != 6000 {
+		t.Error("changing an inherited event should not change the parent scenario")
+	}
+
+	// Resolving again leaves the expanded scenarios as they are.
+	before := conf.Scenarios[0].Events
+	if err := conf.ResolveScenarios(); err != nil {
+		t.Fatalf("second ResolveScenarios() error = %v", err)
+	}
+	if got := strings.Join(eventNames(conf.Scenarios[0].Events), ", "); got != strings.Join(eventNames(before), ", ") {
+		t.Errorf("second resolution changed events to %s", got)
+	}
+}
+
+func TestResolveScenariosErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		scenarios []Scenario
+		wantErr   string
+	}{
+		{
+			name:      "unknown parent",
+			scenarios: []Scenario{{Name: "Child", Extends: "Missing"}},
+			wantErr:   "unknown scenario",
+		},
+		{
+			name:      "cycle",
+			scenarios: []Scenario{{Name: "A", Extends: "B"}, {Name: "B", Extends: "A"}},
+			wantErr:   "cycle",
+		},
+		{
+			name:      "extends itself",
+			scenarios: []Scenario{{Name: "A", Extends: "A"}},
+			wantErr:   "cycle",
+		},
+		{
+			name:      "ambiguous parent",
+			scenarios: []Scenario{{Name: "Base"}, {Name: "Base"}, {Name: "Child", Extends: "Base"}},
+			wantErr:   "more than one scenario",
+		},
+		{
+			name:      "remove unknown item",
+			scenarios: []Scenario{{Name: "Base"}, {Name: "Child", Extends: "Base", Remove: &ScenarioRemovals{Events: []string{"Salary"}}}},
+			wantErr:   "remove.events",
+		},
+		{
+			name:      "remove without extends",
+			scenarios: []Scenario{{Name: "Base", Remove: &ScenarioRemovals{Events: []string{"Salary"}}}},
+			wantErr:   "remove requires extends",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := Configuration{Scenarios: tt.scenarios}
+			err := conf.ResolveScenarios()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ResolveScenarios() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompactScenarios(t *testing.T) {
+	conf := inheritanceTestConfig()
+	if err := conf.ResolveScenarios(); err != nil {
+		t.Fatalf("ResolveScenarios() error = %v", err)
+	}
+	// An optimizer changing an inherited event makes it an override.
+	conf.Scenarios[2].Events[0].Amount = 6500
+
+	compact := conf.Compact()
+	move := compact.Scenarios[2]
+	if got := strings.Join(eventNames(move.Events), ", "); got != "Salary, Rent, Moving costs" {
+		t.Errorf("compact Move events = %s, want Salary, Rent, Moving costs", got)
+	}
+	if move.Loans != nil || move.Investments != nil || move.DebtPayoff != nil {
+		t.Errorf("compact Move should leave inherited items out, got %v, %v, %+v", move.Loans, move.Investments, move.DebtPayoff)
+	}
+	if move.Remove == nil || strings.Join(move.Remove.Loans, ",") != "Car" {
+		t.Errorf("compact Move should remove the car loan, got %+v", move.Remove)
+	}
+	retirement := compact.Scenarios[0]
+	if len(retirement.Events) != 0 || retirement.Remove == nil || strings.Join(retirement.Remove.Events, ",") != "Salary" {
+		t.Errorf("compact Early retirement = %v removing %+v, want no events removing Salary", eventNames(retirement.Events), retirement.Remove)
+	}
+	if len(conf.Scenarios[2].Loans) != 0 || len(conf.Scenarios[2].Investments) != 1 {
+		t.Error("Compact should not change the resolved configuration")
+	}
+
+	// The compact form resolves back to the same scenarios.
+	if err := compact.ResolveScenarios(); err != nil {
+		t.Fatalf("ResolveScenarios() of the compact form error = %v", err)
+	}
+	for i := range conf.Scenarios {
+		if !sameYAML(compact.Scenarios[i], conf.Scenarios[i]) {
+			t.Errorf("scenario %s does not round trip through Compact", conf.Scenarios[i].Name)
+		}
+	}
+}
+
+func TestLoadConfigurationResolvesScenarios(t *testing.T) {
+	yamlConfig := `
+common:
+  deathDate: 2030-01
+scenarios:
+  - name: Base
+    active: true
+    events:
+      - name: Salary
+        amount: 6000
+        frequency: 1
+  - name: Raise
+    active: true
+    extends: Base
+    events:
+      - name: Salary
+        amount: 6500
+        frequency: 1
+`
+	conf, err := LoadConfigurationFromReader(strings.NewReader(yamlConfig))
+	if err != nil {
+		t.Fatalf("LoadConfigurationFromReader() error = %v", err)
+	}
+	if raise := conf.Scenarios[1]; len(raise.Events) != 1 || raise.Events[0].Amount != 6500 {
+		t.Errorf("Raise events = %+v, want the single overridden salary", raise.Events)
+	}
+
+	_, err = LoadConfigurationFromReader(strings.NewReader(`
+scenarios:
+  - name: Orphan
+    extends: Missing
+`))
+	if err == nil {
+		t.Error("expected an error for a scenario extending an unknown scenario")
+	}
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/finance"
+	"github.com/iwvelando/finance-forecast/pkg/returns"
 )
 
 // Investment describes an investment account with contributions and withdrawals.
@@ -12,9 +15,51 @@ type Investment struct {
 	AnnualReturnRate      float64 `yaml:"annualReturnRate,omitempty"`
 	TaxRate               float64 `yaml:"taxRate,omitempty"`
 	WithdrawalTaxRate     float64 `yaml:"withdrawalTaxRate,omitempty"`
+	AccountType           string  `yaml:"accountType,omitempty"`
 	ContributionsFromCash bool    `yaml:"contributionsFromCash,omitempty"`
 	Contributions         []Event `yaml:"contributions,omitempty"`
 	Withdrawals           []Event `yaml:"withdrawals,omitempty"`
+	// Returns optionally describes how monthly returns are sampled in stochastic modes.
+	Returns *ReturnModel `yaml:"returns,omitempty"`
+	// ReturnPath holds sampled monthly returns keyed by date; when empty the fixed
+	// AnnualReturnRate is used.
+	ReturnPath map[string]float64 `yaml:"-" mapstructure:"-"`
+}
+
+// ReturnModel configures the distribution used to sample an investment's monthly
+// returns. Mean and Volatility are annual percentages; Mean defaults to the
+// investment's annualReturnRate when omitted. Series instead names an embedded
+// historical return series replayed by backtests.
+type ReturnModel struct {
+	Series       string   `yaml:"series,omitempty" mapstructure:"series"`
+	Distribution string   `yaml:"distribution,omitempty" mapstructure:"distribution"`
+	Mean         *float64 `yaml:"mean,omitempty" mapstructure:"mean"`
+	Volatility   float64  `yaml:"volatility,omitempty" mapstructure:"volatility"`
+	Seed         *int64   `yaml:"seed,omitempty" mapstructure:"seed"`
+}
+
+// Stochastic reports whether the investment declares a sampled return model.
+func (investment Investment) Stochastic() bool {
+	return investment.Returns != nil && investment.Returns.Volatility > 0
+}
+
+// Historical reports whether the investment references a historical return series.
+func (investment Investment) Historical() bool {
+	return investment.Returns != nil && investment.Returns.Series != ""
+}
+
+// ToReturnsModel converts the investment's return configuration to a returns.Model.
+func (investment Investment) ToReturnsModel() returns.Model {
+	model := returns.Model{Mean: investment.AnnualReturnRate}
+	if investment.Returns == nil {
+		return model
+	}
+	model.Distribution = investment.Returns.Distribution
+	model.Volatility = investment.Returns.Volatility
+	if investment.Returns.Mean != nil {
+		model.Mean = *investment.Returns.Mean
+	}
+	return model
 }
 
 // FormDateListsWithFixedTime parses contribution and withdrawal date lists using the provided fixed time.
@@ -28,8 +73,8 @@ func (investment *Investment) FormDateListsWithFixedTime(conf Configuration, fix
 			investment.Contributions[i].Frequency = 1
 		}
 
-		if investment.Contributions[i].Percentage != 0 {
-			return fmt.Errorf("investment %s contribution %d: percentage is not supported for contributions", investment.Name, i)
+		if err := investment.Contributions[i].requirePercentOf(); err != nil {
+			return fmt.Errorf("investment %s contribution %d: %w", investment.Name, i, err)
 		}
 
 		if err := investment.Contributions[i].FormDateListWithFixedTime(conf, fixedTime); err != nil {
@@ -44,6 +89,10 @@ func (investment *Investment) FormDateListsWithFixedTime(conf Configuration, fix
 			investment.Withdrawals[i].Frequency = 1
 		}
 
+		if investment.Withdrawals[i].PercentageBased() {
+			return fmt.Errorf("investment %s withdrawal %d: percentOf is not supported for withdrawals; percentage is of the investment balance", investment.Name, i)
+		}
+
 		amount := investment.Withdrawals[i].Amount
 		percent := investment.Withdrawals[i].Percentage
 		if amount != 0 && percent != 0 {
@@ -67,5 +116,127 @@ func (investment *Investment) FormDateListsWithFixedTime(conf Configuration, fix
 		return fmt.Errorf("investment %s: withdrawals cannot mix amount and percentage entries", investment.Name)
 	}
 
+	if investment.Returns != nil {
+		if err := investment.ToReturnsModel().Validate(); err != nil {
+			return fmt.Errorf("investment %s returns: %w", investment.Name, err)
+		}
+		if investment.Historical() {
+			if investment.Returns.Volatility != 0 {
+				return fmt.Errorf("investment %s returns: series cannot be combined with volatility", investment.Name)
+			}
+			if _, err := returns.LoadSeries(investment.Returns.Series); err != nil {
+				return fmt.Errorf("investment %s returns: %w", investment.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateAccountTypes checks each investment's accountType against the tax
+// rates it declares, and the birthDate and rmdStartAge used for required
+// minimum distributions.
+func (conf *Configuration) ValidateAccountTypes() error {
+	if conf == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+
+	if conf.Common.BirthDate != "" {
+		if _, err := time.Parse(DateTimeLayout, conf.Common.BirthDate); err != nil {
+			return fmt.Errorf("invalid birthDate %q, expected YYYY-MM: %w", conf.Common.BirthDate, err)
+		}
+	}
+	if conf.Common.RMDStartAge != 0 && (conf.Common.RMDStartAge < 72 || conf.Common.RMDStartAge > 120) {
+		return fmt.Errorf("rmdStartAge %d must be between 72 and 120", conf.Common.RMDStartAge)
+	}
+
+	checkInvestments := func(scope string, investments []Investment) error {
+		for _, investment := range investments {
+			if err := finance.ValidateAccountType(investment.AccountType); err != nil {
+				return fmt.Errorf("%s investment %s: %w", scope, investment.Name, err)
+			}
+			accountType := finance.CanonicalAccountType(investment.AccountType)
+			if investment.TaxRate != 0 && !finance.TaxesGrowth(accountType) {
+				return fmt.Errorf("%s investment %s: taxRate does not apply to %s accounts", scope, investment.Name, accountType)
+			}
+			if investment.WithdrawalTaxRate != 0 && (accountType == finance.AccountTypeRoth || accountType == finance.AccountTypeHSA) {
+				return fmt.Errorf("%s investment %s: withdrawalTaxRate does not apply to %s accounts", scope, investment.Name, accountType)
+			}
+		}
+		return nil
+	}
+
+	if err := checkInvestments("common", conf.Common.Investments); err != nil {
+		return err
+	}
+	for _, scenario := range conf.Scenarios {
+		if err := checkInvestments(fmt.Sprintf("scenario %s", scenario.Name), scenario.Investments); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RMDBirthDate returns the birth date that required minimum distributions are
+// based on: common.birthDate, or the primary household member's birth date.
+func (conf Configuration) RMDBirthDate() string {
+	if conf.Common.BirthDate != "" {
+		return conf.Common.BirthDate
+	}
+	if conf.Household != nil && len(conf.Household.Members) > 0 {
+		return conf.Household.Members[0].BirthDate
+	}
+	return ""
+}
+
+// RMDStartAgeOrDefault returns the configured age at which required minimum
+// distributions begin, or the default when unset.
+func (c Common) RMDStartAgeOrDefault() int {
+	if c.RMDStartAge != 0 {
+		return c.RMDStartAge
+	}
+	return finance.DefaultRMDStartAge
+}
+
+// ShortfallPolicy sells investments automatically when cash would otherwise go
+// negative. Order lists investment names in the sequence they are drawn down.
+type ShortfallPolicy struct {
+	Order []string `yaml:"order" mapstructure:"order"`
+}
+
+// ValidateShortfallPolicy checks that every investment named by the shortfall
+// policy exists in the common investments or at least one scenario.
+func (conf *Configuration) ValidateShortfallPolicy() error {
+	if conf == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+	policy := conf.ShortfallPolicy
+	if policy == nil {
+		return nil
+	}
+	if len(policy.Order) == 0 {
+		return fmt.Errorf("shortfallPolicy: order must list at least one investment")
+	}
+
+	known := make(map[string]bool)
+	for _, investment := range conf.Common.Investments {
+		known[investment.Name] = true
+	}
+	for _, scenario := range conf.Scenarios {
+		for _, investment := range scenario.Investments {
+			known[investment.Name] = true
+		}
+	}
+
+	seen := make(map[string]bool, len(policy.Order))
+	for _, name := range policy.Order {
+		if !known[name] {
+			return fmt.Errorf("shortfallPolicy: unknown investment %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("shortfallPolicy: investment %q listed more than once", name)
+		}
+		seen[name] = true
+	}
 	return nil
 }
@@ -108,3 +108,91 @@ func TestParseDateListsWithInvestments(t *testing.T) {
 		t.Fatalf("expected contribution dates for scenario investment")
 	}
 }
+
+func TestInvestmentReturnsValidation(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2026-12"}}
+	fixedTime := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		returns *ReturnModel
+		wantErr bool
+	}{
+		{name: "sampled", returns: &ReturnModel{Volatility: 15}},
+		{name: "historical series", returns: &ReturnModel{Series: "us-stocks"}},
+		{name: "unknown distribution", returns: &ReturnModel{Distribution: "uniform", Volatility: 10}, wantErr: true},
+		{name: "negative volatility", returns: &ReturnModel{Volatility: -1}, wantErr: true},
+		{name: "unknown series", returns: &ReturnModel{Series: "gold"}, wantErr: true},
+		{name: "series with volatility", returns: &ReturnModel{Series: "us-bonds", Volatility: 5}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			investment := Investment{Name: "Fund", AnnualReturnRate: 6, Returns: tt.returns}
+			err := investment.FormDateListsWithFixedTime(conf, fixedTime)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormDateListsWithFixedTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateShortfallPolicy(t *testing.T) {
+	base := Configuration{
+		Common:    Common{Investments: []Investment{{Name: "Brokerage"}}},
+		Scenarios: []Scenario{{Name: "Retire", Investments: []Investment{{Name: "Roth IRA"}}}},
+	}
+
+	tests := []struct {
+		name    string
+		policy  *ShortfallPolicy
+		wantErr bool
+	}{
+		{"no policy", nil, false},
+		{"common and scenario investments", &ShortfallPolicy{Order: []string{"Brokerage", "Roth IRA"}}, false},
+		{"empty order", &ShortfallPolicy{}, true},
+		{"unknown investment", &ShortfallPolicy{Order: []string{"Pension"}}, true},
+		{"duplicate investment", &ShortfallPolicy{Order: []string{"Brokerage", "Brokerage"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := base
+			conf.ShortfallPolicy = tt.policy
+			err := conf.ValidateShortfallPolicy()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateShortfallPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAccountTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		common      Common
+		investments []Investment
+		wantErr     bool
+	}{
+		{"default taxable", Common{}, []Investment{{Name: "Brokerage", TaxRate: 15, WithdrawalTaxRate: 15}}, false},
+		{"traditional with rmd settings", Common{BirthDate: "1960-05", RMDStartAge: 75}, []Investment{{Name: "IRA", AccountType: "traditional", WithdrawalTaxRate: 22}}, false},
+		{"unknown account type", Common{}, []Investment{{Name: "Annuity", AccountType: "annuity"}}, true},
+		{"growth tax on traditional", Common{}, []Investment{{Name: "IRA", AccountType: "traditional", TaxRate: 15}}, true},
+		{"withdrawal tax on roth", Common{}, []Investment{{Name: "Roth", AccountType: "roth", WithdrawalTaxRate: 15}}, true},
+		{"bad birth date", Common{BirthDate: "May 1960"}, nil, true},
+		{"rmd age too low", Common{RMDStartAge: 60}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := Configuration{
+				Common:    tt.common,
+				Scenarios: []Scenario{{Name: "Base", Investments: tt.investments}},
+			}
+			err := conf.ValidateAccountTypes()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateAccountTypes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
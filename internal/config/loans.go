@@ -4,30 +4,45 @@ package config
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/iwvelando/finance-forecast/pkg/datetime"
 	"github.com/iwvelando/finance-forecast/pkg/loans"
+	"github.com/iwvelando/finance-forecast/pkg/mathutil"
 	"go.uber.org/zap"
 )
 
 // Loan indicates a loan and its parameters.
 type Loan struct {
-	Name                    string             `yaml:"name,omitempty" mapstructure:"name"`
-	StartDate               string             `yaml:"startDate,omitempty" mapstructure:"startDate"`
-	Principal               float64            `yaml:"principal" mapstructure:"principal"`
-	InterestRate            float64            `yaml:"interestRate" mapstructure:"interestRate"`
-	Term                    int                `yaml:"term" mapstructure:"term"`
-	DownPayment             float64            `yaml:"downPayment,omitempty" mapstructure:"downPayment"`
-	Escrow                  float64            `yaml:"escrow,omitempty" mapstructure:"escrow"`
-	MortgageInsurance       float64            `yaml:"mortgageInsurance,omitempty" mapstructure:"mortgageInsurance"`
-	MortgageInsuranceCutoff float64            `yaml:"mortgageInsuranceCutoff,omitempty" mapstructure:"mortgageInsuranceCutoff"`
-	EarlyPayoffThreshold    float64            `yaml:"earlyPayoffThreshold,omitempty" mapstructure:"earlyPayoffThreshold"`
-	EarlyPayoffDate         string             `yaml:"earlyPayoffDate,omitempty" mapstructure:"earlyPayoffDate"`
-	SellProperty            bool               `yaml:"sellProperty,omitempty" mapstructure:"sellProperty"`
-	SellPrice               float64            `yaml:"sellPrice,omitempty" mapstructure:"sellPrice"`
-	SellCostsNet            float64            `yaml:"sellCostsNet,omitempty" mapstructure:"sellCostsNet"`
-	ExtraPrincipalPayments  []Event            `yaml:"extraPrincipalPayments,omitempty" mapstructure:"extraPrincipalPayments"`
-	AmortizationSchedule    map[string]Payment `yaml:"amortizationSchedule,omitempty" mapstructure:"amortizationSchedule"`
+	Name                    string              `yaml:"name,omitempty" mapstructure:"name"`
+	Type                    string              `yaml:"type,omitempty" mapstructure:"type"` // amortizing (default), interestOnly, balloon, or simpleInterest
+	StartDate               string              `yaml:"startDate,omitempty" mapstructure:"startDate"`
+	Principal               float64             `yaml:"principal" mapstructure:"principal"`
+	InterestRate            float64             `yaml:"interestRate" mapstructure:"interestRate"`
+	Term                    int                 `yaml:"term" mapstructure:"term"`
+	Payment                 float64             `yaml:"payment,omitempty" mapstructure:"payment"`                   // instead of term; principal and interest only
+	PaymentFrequency        string              `yaml:"paymentFrequency,omitempty" mapstructure:"paymentFrequency"` // monthly (default) or biweekly
+	InterestOnlyMonths      int                 `yaml:"interestOnlyMonths,omitempty" mapstructure:"interestOnlyMonths"`
+	AmortizationTerm        int                 `yaml:"amortizationTerm,omitempty" mapstructure:"amortizationTerm"`
+	DownPayment             float64             `yaml:"downPayment,omitempty" mapstructure:"downPayment"`
+	Escrow                  float64             `yaml:"escrow,omitempty" mapstructure:"escrow"`
+	EscrowGrowthRate        float64             `yaml:"escrowGrowthRate,omitempty" mapstructure:"escrowGrowthRate"`
+	EscrowIndexToInflation  bool                `yaml:"escrowIndexToInflation,omitempty" mapstructure:"escrowIndexToInflation"`
+	EscrowAnnualGrowth      float64             `yaml:"-" mapstructure:"-"` // resolved from escrowGrowthRate and inflation
+	MortgageInsurance       float64             `yaml:"mortgageInsurance,omitempty" mapstructure:"mortgageInsurance"`
+	MortgageInsuranceCutoff float64             `yaml:"mortgageInsuranceCutoff,omitempty" mapstructure:"mortgageInsuranceCutoff"`
+	EarlyPayoffThreshold    float64             `yaml:"earlyPayoffThreshold,omitempty" mapstructure:"earlyPayoffThreshold"`
+	EarlyPayoffDate         string              `yaml:"earlyPayoffDate,omitempty" mapstructure:"earlyPayoffDate"`
+	SellProperty            bool                `yaml:"sellProperty,omitempty" mapstructure:"sellProperty"`
+	SellPrice               float64             `yaml:"sellPrice,omitempty" mapstructure:"sellPrice"`
+	SellCostsNet            float64             `yaml:"sellCostsNet,omitempty" mapstructure:"sellCostsNet"`
+	Asset                   string              `yaml:"asset,omitempty" mapstructure:"asset"`
+	Account                 string              `yaml:"account,omitempty" mapstructure:"account"`
+	ExtraPrincipalPayments  []Event             `yaml:"extraPrincipalPayments,omitempty" mapstructure:"extraPrincipalPayments"`
+	Refinances              []Refinance         `yaml:"refinance,omitempty" mapstructure:"refinance"`
+	AdjustableRate          *AdjustableRate     `yaml:"adjustableRate,omitempty" mapstructure:"adjustableRate"`
+	AmortizationSchedule    map[string]Payment  `yaml:"amortizationSchedule,omitempty" mapstructure:"amortizationSchedule"`
+	Notes                   map[string][]string `yaml:"-" mapstructure:"-"` // schedule notes by month, such as refinances
 }
 
 // Payment holds the values for a given payment.
@@ -37,6 +52,7 @@ type Payment struct {
 	Interest           float64
 	RemainingPrincipal float64
 	RefundableEscrow   float64
+	InterestRate       float64
 }
 
 // ProcessLoans iterates through all loans and produces the amortization
@@ -52,6 +68,10 @@ func (conf *Configuration) ProcessLoans(logger *zap.Logger) error {
 	// First handle the processing for all Loans in Scenarios.
 	for i, scenario := range conf.Scenarios {
 		for j := range scenario.Loans {
+			if err := conf.Scenarios[i].Loans[j].linkAsset(scenario.Assets, conf.Common.Assets); err != nil {
+				return fmt.Errorf("scenario %s: %w", scenario.Name, err)
+			}
+
 			// Set default sell price if not specified
 			if conf.Scenarios[i].Loans[j].SellPrice == 0 {
 				conf.Scenarios[i].Loans[j].SellPrice = conf.Scenarios[i].Loans[j].Principal
@@ -67,6 +87,10 @@ func (conf *Configuration) ProcessLoans(logger *zap.Logger) error {
 
 	// Next handle the processing for the Common Loans.
 	for i := range conf.Common.Loans {
+		if err := conf.Common.Loans[i].linkAsset(conf.Common.Assets); err != nil {
+			return err
+		}
+
 		// Set default sell price if not specified
 		if conf.Common.Loans[i].SellPrice == 0 {
 			conf.Common.Loans[i].SellPrice = conf.Common.Loans[i].Principal
@@ -79,7 +103,7 @@ func (conf *Configuration) ProcessLoans(logger *zap.Logger) error {
 		}
 	}
 
-	return nil
+	return conf.ApplyDebtPayoffPlans(logger)
 }
 
 // GetAmortizationSchedule computes the amortization schedule for a given Loan.
@@ -93,6 +117,25 @@ func (loan *Loan) GetAmortizationSchedule(logger *zap.Logger, conf Configuration
 	if loan.Name == "" {
 		return fmt.Errorf("loan name cannot be empty")
 	}
+	if err := loan.validateType(); err != nil {
+		return err
+	}
+	if err := loan.validatePayment(); err != nil {
+		return err
+	}
+	if err := loan.validateRefinances(); err != nil {
+		return err
+	}
+	if loan.AdjustableRate != nil {
+		if err := loan.AdjustableRate.validate(loan.Name); err != nil {
+			return err
+		}
+	}
+
+	loan.EscrowAnnualGrowth = loan.EscrowGrowthRate
+	if loan.EscrowIndexToInflation {
+		loan.EscrowAnnualGrowth += conf.Inflation
+	}
 
 	// Convert config.Loan to loans.LoanConfig using helper
 	loanConfig := loan.ToLoansConfig()
@@ -112,10 +155,46 @@ func (loan *Loan) GetAmortizationSchedule(logger *zap.Logger, conf Configuration
 	for date, payment := range schedule {
 		loan.AmortizationSchedule[date] = FromLoansPayment(payment)
 	}
+	loan.Notes = loanConfig.Notes
 
 	return nil
 }
 
+// PaymentDates returns the months in the loan's amortization schedule in order.
+func (loan Loan) PaymentDates() []string {
+	dates := make([]string, 0, len(loan.AmortizationSchedule))
+	for date := range loan.AmortizationSchedule {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// PayoffDate returns the month of the payment that clears the loan's
+// principal, ignoring any escrow-only payments that follow it. It returns
+// false when the loan is not paid off within the schedule.
+func (loan Loan) PayoffDate() (string, bool) {
+	for _, date := range loan.PaymentDates() {
+		if mathutil.Round(loan.AmortizationSchedule[date].RemainingPrincipal) == 0 {
+			return date, true
+		}
+	}
+	return "", false
+}
+
+// BalanceAt returns the principal remaining after the loan's latest payment
+// on or before date, or zero before the first payment.
+func (loan Loan) BalanceAt(date string) float64 {
+	balance := 0.0
+	for _, paymentDate := range loan.PaymentDates() {
+		if paymentDate > date {
+			break
+		}
+		balance = loan.AmortizationSchedule[paymentDate].RemainingPrincipal
+	}
+	return balance
+}
+
 // ExtraPrincipal returns an extra principal payment, if present, or 0
 func (loan *Loan) ExtraPrincipal(logger *zap.Logger, date string) (float64, error) {
 	if logger == nil {
@@ -129,12 +208,13 @@ func (loan *Loan) ExtraPrincipal(logger *zap.Logger, date string) (float64, erro
 			dateList = append(dateList, eventDate.Format(datetime.DateTimeLayout))
 		}
 		loanEvents = append(loanEvents, loans.Event{
-			Name:      event.Name,
-			Amount:    event.Amount,
-			StartDate: event.StartDate,
-			EndDate:   event.EndDate,
-			Frequency: event.Frequency,
-			DateList:  dateList,
+			Name:       event.Name,
+			Amount:     event.Amount,
+			StartDate:  event.StartDate,
+			EndDate:    event.EndDate,
+			Frequency:  event.Frequency,
+			DateList:   dateList,
+			AmountList: event.AmountList,
 		})
 	}
 
@@ -178,7 +258,79 @@ func (loan *Loan) CheckEarlyPayoffThreshold(currentMonth, deathDate string, bala
 
 		// Synchronize the schedule using helper
 		loan.SyncScheduleWithLoansConfig(loanConfig)
+
+		// Refinances that would have followed the payoff no longer happen.
+		for date := range loan.Notes {
+			if date > currentMonth {
+				delete(loan.Notes, date)
+			}
+		}
 	}
 
 	return note, nil
 }
+
+// validateType checks the loan type, payment frequency, and the settings that
+// go with them.
+func (loan Loan) validateType() error {
+	switch loan.Type {
+	case "", loans.TypeAmortizing, loans.TypeSimpleInterest:
+	case loans.TypeInterestOnly:
+		if loan.InterestOnlyMonths <= 0 || loan.InterestOnlyMonths >= loan.Term {
+			return fmt.Errorf("loan %s: interestOnlyMonths must be greater than zero and less than the term", loan.Name)
+		}
+	case loans.TypeBalloon:
+		if loan.AmortizationTerm <= loan.Term {
+			return fmt.Errorf("loan %s: a balloon loan's amortizationTerm must be longer than its term", loan.Name)
+		}
+	default:
+		return fmt.Errorf("loan %s: unknown type %q, expected amortizing, interestOnly, balloon, or simpleInterest", loan.Name, loan.Type)
+	}
+	if loan.InterestOnlyMonths != 0 && loan.Type != loans.TypeInterestOnly {
+		return fmt.Errorf("loan %s: interestOnlyMonths only applies to interestOnly loans", loan.Name)
+	}
+	if loan.AmortizationTerm != 0 && loan.Type != loans.TypeBalloon {
+		return fmt.Errorf("loan %s: amortizationTerm only applies to balloon loans", loan.Name)
+	}
+	switch loan.PaymentFrequency {
+	case "", loans.PaymentMonthly:
+	case loans.PaymentBiweekly:
+		if loan.Type == loans.TypeInterestOnly || loan.Type == loans.TypeBalloon {
+			return fmt.Errorf("loan %s: biweekly payments cannot be used with %s loans", loan.Name, loan.Type)
+		}
+	default:
+		return fmt.Errorf("loan %s: unknown paymentFrequency %q, expected monthly or biweekly", loan.Name, loan.PaymentFrequency)
+	}
+	return nil
+}
+
+// validatePayment checks a loan given by its payment instead of its term.
+func (loan Loan) validatePayment() error {
+	if loan.Payment == 0 {
+		return nil
+	}
+	if loan.Payment < 0 {
+		return fmt.Errorf("loan %s: payment cannot be negative", loan.Name)
+	}
+	if loan.Term != 0 {
+		return fmt.Errorf("loan %s: specify either term or payment, not both", loan.Name)
+	}
+	if loan.Type == loans.TypeInterestOnly || loan.Type == loans.TypeBalloon {
+		return fmt.Errorf("loan %s: payment cannot be used with %s loans", loan.Name, loan.Type)
+	}
+	if _, err := loans.TermForPayment(loan.Principal-loan.DownPayment, loan.InterestRate, loan.Payment); err != nil {
+		return fmt.Errorf("loan %s: %w", loan.Name, err)
+	}
+	return nil
+}
+
+// term returns the loan term in months, derived from the payment when the
+// loan is given by its payment.
+func (loan Loan) term() int {
+	if loan.Payment > 0 {
+		if term, err := loans.TermForPayment(loan.Principal-loan.DownPayment, loan.InterestRate, loan.Payment); err == nil {
+			return term
+		}
+	}
+	return loan.Term
+}
@@ -139,6 +139,57 @@ func TestLoanWithEscrow(t *testing.T) {
 	}
 }
 
+func TestLoanPayoffDate(t *testing.T) {
+	config := Configuration{Common: Common{DeathDate: "2030-01"}}
+
+	tests := []struct {
+		name     string
+		loan     Loan
+		want     string
+		wantPaid bool
+	}{
+		{"matures with escrow", Loan{Name: "Car", StartDate: "2025-01", Principal: 12000, InterestRate: 4, Term: 24, Escrow: 100}, "2026-12", true},
+		{"early payoff date", Loan{Name: "House", StartDate: "2025-01", Principal: 100000, InterestRate: 5, Term: 360, Escrow: 500, EarlyPayoffDate: "2026-06"}, "2026-06", true},
+		{"outlives the forecast", Loan{Name: "House", StartDate: "2025-01", Principal: 100000, InterestRate: 5, Term: 360}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loan := tt.loan
+			if err := loan.GetAmortizationSchedule(zap.NewNop(), config); err != nil {
+				t.Fatalf("GetAmortizationSchedule() error = %v", err)
+			}
+			got, paid := loan.PayoffDate()
+			if got != tt.want || paid != tt.wantPaid {
+				t.Errorf("PayoffDate() = %q, %v, want %q, %v", got, paid, tt.want, tt.wantPaid)
+			}
+		})
+	}
+}
+
+func TestLoanBalanceAt(t *testing.T) {
+	loan := Loan{Name: "Car", StartDate: "2025-01", Principal: 600, Term: 6}
+	if err := loan.GetAmortizationSchedule(zap.NewNop(), Configuration{Common: Common{DeathDate: "2026-01"}}); err != nil {
+		t.Fatalf("GetAmortizationSchedule() error = %v", err)
+	}
+
+	tests := []struct {
+		date string
+		want float64
+	}{
+		{"2024-12", 0},
+		{"2025-01", 500},
+		{"2025-03", 300},
+		{"2025-06", 0},
+		{"2025-09", 0},
+	}
+	for _, tt := range tests {
+		if got := loan.BalanceAt(tt.date); got != tt.want {
+			t.Errorf("BalanceAt(%s) = %.2f, want %.2f", tt.date, got, tt.want)
+		}
+	}
+}
+
 func TestLoanWithMortgageInsurance(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 
@@ -453,3 +504,76 @@ func TestProcessLoansNilSafety(t *testing.T) {
 		t.Errorf("Expected no error for nil logger after fix, got: %v", err)
 	}
 }
+
+func TestLoanTypeValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		loan    Loan
+		wantErr bool
+	}{
+		{name: "default", loan: Loan{Term: 12}},
+		{name: "simple interest", loan: Loan{Type: "simpleInterest", Term: 12}},
+		{name: "interest only", loan: Loan{Type: "interestOnly", Term: 12, InterestOnlyMonths: 6}},
+		{name: "interest only for the whole term", loan: Loan{Type: "interestOnly", Term: 12, InterestOnlyMonths: 12}, wantErr: true},
+		{name: "interest only without months", loan: Loan{Type: "interestOnly", Term: 12}, wantErr: true},
+		{name: "balloon", loan: Loan{Type: "balloon", Term: 12, AmortizationTerm: 60}},
+		{name: "balloon without longer amortization", loan: Loan{Type: "balloon", Term: 12, AmortizationTerm: 12}, wantErr: true},
+		{name: "amortizationTerm on another type", loan: Loan{Term: 12, AmortizationTerm: 60}, wantErr: true},
+		{name: "interestOnlyMonths on another type", loan: Loan{Type: "balloon", Term: 12, AmortizationTerm: 60, InterestOnlyMonths: 6}, wantErr: true},
+		{name: "unknown type", loan: Loan{Type: "payday", Term: 12}, wantErr: true},
+		{name: "biweekly", loan: Loan{Term: 12, PaymentFrequency: "biweekly"}},
+		{name: "biweekly simple interest", loan: Loan{Type: "simpleInterest", Term: 12, PaymentFrequency: "biweekly"}},
+		{name: "biweekly interest only", loan: Loan{Type: "interestOnly", Term: 12, InterestOnlyMonths: 6, PaymentFrequency: "biweekly"}, wantErr: true},
+		{name: "unknown payment frequency", loan: Loan{Term: 12, PaymentFrequency: "weekly"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.loan.validateType()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanPaymentValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		loan    Loan
+		wantErr bool
+	}{
+		{name: "term only", loan: Loan{Principal: 10000, InterestRate: 6, Term: 36}},
+		{name: "payment only", loan: Loan{Principal: 10000, InterestRate: 6, Payment: 500}},
+		{name: "simple interest payment", loan: Loan{Type: "simpleInterest", Principal: 10000, InterestRate: 6, Payment: 500}},
+		{name: "term and payment", loan: Loan{Principal: 10000, InterestRate: 6, Term: 36, Payment: 500}, wantErr: true},
+		{name: "negative payment", loan: Loan{Principal: 10000, InterestRate: 6, Payment: -500}, wantErr: true},
+		{name: "payment below interest", loan: Loan{Principal: 10000, InterestRate: 6, Payment: 40}, wantErr: true},
+		{name: "payment on a balloon loan", loan: Loan{Type: "balloon", Principal: 10000, InterestRate: 6, Payment: 500, AmortizationTerm: 60}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.loan.validatePayment()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePayment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanDefinedByPayment(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-01"}}
+	loan := Loan{
+		Name:         "Car",
+		StartDate:    "2025-01",
+		Principal:    10000,
+		InterestRate: 6,
+		Payment:      500,
+		Refinances:   []Refinance{{Date: "2026-01", InterestRate: 3, Term: 12}},
+	}
+	if err := loan.GetAmortizationSchedule(zap.NewNop(), conf); err != nil {
+		t.Fatalf("GetAmortizationSchedule() error = %v", err)
+	}
+	if got, ok := loan.PayoffDate(); !ok || got != "2026-12" {
+		t.Errorf("PayoffDate() = %s, %v, want 2026-12 after the refinance", got, ok)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/socialsecurity"
 )
 
 const (
@@ -11,12 +13,15 @@ const (
 	OptimizerFieldFrequency = "frequency"
 	OptimizerFieldStartDate = "startDate"
 	OptimizerFieldEndDate   = "endDate"
+	// OptimizerFieldClaimingAge searches a socialSecurity event's claiming age.
+	OptimizerFieldClaimingAge = "claimingAge"
 
 	OptimizerKindCashFloor       = "cash_floor"
 	OptimizerTargetEmergencyFund = "emergencyFund"
 
 	defaultToleranceAmount   = 0.01
 	defaultToleranceDiscrete = 1
+	defaultToleranceAge      = 1.0 / 12.0
 	defaultMaxIterations     = 50
 )
 
@@ -48,6 +53,8 @@ func CanonicalOptimizerField(value string) string {
 		return OptimizerFieldStartDate
 	case "enddate", "end_date", "end-date":
 		return OptimizerFieldEndDate
+	case "claimingage", "claiming_age", "claiming-age":
+		return OptimizerFieldClaimingAge
 	default:
 		return strings.ToLower(trimmed)
 	}
@@ -82,6 +89,10 @@ func (o *OptimizerConfig) Normalize() {
 		if o.Tolerance <= 0 {
 			o.Tolerance = defaultToleranceDiscrete
 		}
+	case OptimizerFieldClaimingAge:
+		if o.Tolerance <= 0 {
+			o.Tolerance = defaultToleranceAge
+		}
 	default:
 		if o.Tolerance <= 0 {
 			o.Tolerance = defaultToleranceAmount
@@ -101,7 +112,7 @@ func (o *OptimizerConfig) Validate() error {
 	o.Normalize()
 
 	switch o.Field {
-	case OptimizerFieldAmount, OptimizerFieldFrequency, OptimizerFieldStartDate, OptimizerFieldEndDate:
+	case OptimizerFieldAmount, OptimizerFieldFrequency, OptimizerFieldStartDate, OptimizerFieldEndDate, OptimizerFieldClaimingAge:
 		// supported fields
 	default:
 		return fmt.Errorf("optimizer field %q is not supported", o.Field)
@@ -134,6 +145,16 @@ func (o *OptimizerConfig) Validate() error {
 		if *o.Min >= *o.Max {
 			return fmt.Errorf("optimizer frequency minimum %.0f must be less than maximum %.0f", *o.Min, *o.Max)
 		}
+	case OptimizerFieldClaimingAge:
+		if o.Min == nil || o.Max == nil {
+			return fmt.Errorf("optimizer requires minimum and maximum claiming ages")
+		}
+		if *o.Min < socialsecurity.MinClaimingAge || *o.Max > socialsecurity.MaxClaimingAge {
+			return fmt.Errorf("optimizer claiming ages must be between %d and %d", socialsecurity.MinClaimingAge, socialsecurity.MaxClaimingAge)
+		}
+		if *o.Min >= *o.Max {
+			return fmt.Errorf("optimizer claiming age minimum %.2f must be less than maximum %.2f", *o.Min, *o.Max)
+		}
 	case OptimizerFieldStartDate, OptimizerFieldEndDate:
 		if strings.TrimSpace(o.MinDate) == "" {
 			return fmt.Errorf("optimizer %s requires a minimum date", o.Field)
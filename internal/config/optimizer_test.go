@@ -13,6 +13,7 @@ func TestCanonicalOptimizerField(t *testing.T) {
 		{name: "frequency", input: "FREQUENCY", expected: OptimizerFieldFrequency},
 		{name: "start date variations", input: "start_date", expected: OptimizerFieldStartDate},
 		{name: "end date variations", input: "END-DATE", expected: OptimizerFieldEndDate},
+		{name: "claiming age variations", input: "claiming_age", expected: OptimizerFieldClaimingAge},
 		{name: "unknown lowered", input: "Custom", expected: "custom"},
 	}
 
@@ -69,6 +70,34 @@ func TestOptimizerConfigNormalizeCanonicalField(t *testing.T) {
 	}
 }
 
+func TestOptimizerConfigValidateClaimingAge(t *testing.T) {
+	testCases := []struct {
+		name    string
+		min     *float64
+		max     *float64
+		wantErr bool
+	}{
+		{name: "full claiming window", min: floatPtr(62), max: floatPtr(70)},
+		{name: "missing bound", min: floatPtr(62), wantErr: true},
+		{name: "below earliest claiming age", min: floatPtr(60), max: floatPtr(70), wantErr: true},
+		{name: "above latest claiming age", min: floatPtr(62), max: floatPtr(72), wantErr: true},
+		{name: "inverted bounds", min: floatPtr(68), max: floatPtr(65), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &OptimizerConfig{Field: "claimingAge", Min: tc.min, Max: tc.max}
+			err := cfg.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err == nil && cfg.Tolerance != defaultToleranceAge {
+				t.Fatalf("expected claiming age tolerance %.4f, got %.4f", defaultToleranceAge, cfg.Tolerance)
+			}
+		})
+	}
+}
+
 func floatPtr(value float64) *float64 {
 	return &value
 }
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+	"github.com/iwvelando/finance-forecast/pkg/loans"
+	"go.uber.org/zap"
+)
+
+// DebtPayoff spends an extra Budget each month on the loans in its scope,
+// ordered by Strategy: avalanche (highest rate first), snowball (smallest
+// balance first), or custom (Order, with unlisted loans last). A paid-off
+// loan's regular payment rolls into the budget for the next. The plan starts
+// with StartDate, by default the first forecast month.
+type DebtPayoff struct {
+	Strategy  string   `yaml:"strategy" mapstructure:"strategy"`
+	Budget    float64  `yaml:"budget" mapstructure:"budget"`
+	StartDate string   `yaml:"startDate,omitempty" mapstructure:"startDate"`
+	Order     []string `yaml:"order,omitempty" mapstructure:"order"`
+
+	// Report is the plan's outcome and Comparison the outcome of each
+	// strategy with the same budget, filled in by ProcessLoans.
+	Report     *loans.PayoffReport  `yaml:"-" mapstructure:"-"`
+	Comparison []loans.PayoffReport `yaml:"-" mapstructure:"-"`
+}
+
+// Clone returns a deep copy of the plan.
+func (plan *DebtPayoff) Clone() *DebtPayoff {
+	if plan == nil {
+		return nil
+	}
+	clone := *plan
+	clone.Order = append([]string(nil), plan.Order...)
+	if plan.Report != nil {
+		report := clonePayoffReport(*plan.Report)
+		clone.Report = &report
+	}
+	if plan.Comparison != nil {
+		clone.Comparison = make([]loans.PayoffReport, len(plan.Comparison))
+		for i, report := range plan.Comparison {
+			clone.Comparison[i] = clonePayoffReport(report)
+		}
+	}
+	return &clone
+}
+
+func clonePayoffReport(report loans.PayoffReport) loans.PayoffReport {
+	report.Loans = append([]loans.PayoffOutcome(nil), report.Loans...)
+	return report
+}
+
+// validate checks the strategy, budget, start date, and custom order against
+// the loans the plan covers.
+func (plan *DebtPayoff) validate(loanList []Loan) error {
+	switch plan.Strategy {
+	case loans.StrategyAvalanche, loans.StrategySnowball:
+	case loans.StrategyCustom:
+		if len(plan.Order) == 0 {
+			return fmt.Errorf("debtPayoff: custom strategy requires order")
+		}
+	default:
+		return fmt.Errorf("debtPayoff: unknown strategy %q, expected avalanche, snowball, or custom", plan.Strategy)
+	}
+	if plan.Budget <= 0 {
+		return fmt.Errorf("debtPayoff: budget must be greater than zero")
+	}
+	if plan.StartDate != "" {
+		if _, err := time.Parse(DateTimeLayout, plan.StartDate); err != nil {
+			return fmt.Errorf("debtPayoff: invalid startDate %q, expected YYYY-MM", plan.StartDate)
+		}
+	}
+	listed := make(map[string]bool, len(plan.Order))
+	for _, name := range plan.Order {
+		if listed[name] {
+			return fmt.Errorf("debtPayoff: loan %s is listed more than once in order", name)
+		}
+		listed[name] = true
+		found := false
+		for _, loan := range loanList {
+			found = found || loan.Name == name
+		}
+		if !found {
+			return fmt.Errorf("debtPayoff: order names unknown loan %q", name)
+		}
+	}
+	return nil
+}
+
+// startDate returns the first month of the plan.
+func (plan *DebtPayoff) startDate(conf Configuration) (string, error) {
+	if plan.StartDate != "" {
+		return plan.StartDate, nil
+	}
+	start := time.Now().Format(DateTimeLayout)
+	if conf.StartDate != "" {
+		start = conf.StartDate
+	}
+	return datetime.OffsetDate(start, DateTimeLayout, 1)
+}
+
+// apply plans the payoff of loanList, replacing their amortization schedules
+// and notes with the planned ones and recording the plan's report and the
+// comparison across strategies. The loans' schedules must already have been
+// generated.
+func (plan *DebtPayoff) apply(logger *zap.Logger, loanList []Loan, conf Configuration) error {
+	if err := plan.validate(loanList); err != nil {
+		return err
+	}
+	plan.Report = nil
+	plan.Comparison = nil
+	if len(loanList) == 0 {
+		return nil
+	}
+	start, err := plan.startDate(conf)
+	if err != nil {
+		return err
+	}
+
+	generator := loans.NewAmortizationScheduleGenerator(logger)
+	strategies := []string{loans.StrategyAvalanche, loans.StrategySnowball}
+	if len(plan.Order) > 0 {
+		strategies = append(strategies, loans.StrategyCustom)
+	}
+	for _, strategy := range strategies {
+		configs := make([]*loans.LoanConfig, len(loanList))
+		for i := range loanList {
+			configs[i] = loanList[i].ToLoansConfig()
+		}
+		report, err := generator.PlanPayoff(loans.PayoffPlan{
+			Strategy:  strategy,
+			Budget:    plan.Budget,
+			StartDate: start,
+			Order:     plan.Order,
+		}, configs, conf.Common.DeathDate)
+		if err != nil {
+			return fmt.Errorf("debtPayoff: %w", err)
+		}
+		plan.Comparison = append(plan.Comparison, report)
+		if strategy != plan.Strategy {
+			continue
+		}
+		plan.Report = &report
+		for i := range loanList {
+			loanList[i].UpdateFromLoansConfig(configs[i])
+			loanList[i].Notes = configs[i].Notes
+		}
+	}
+	return nil
+}
+
+// ApplyDebtPayoffPlans applies the common and scenario debtPayoff plans to the
+// loans in their scope.
+func (conf *Configuration) ApplyDebtPayoffPlans(logger *zap.Logger) error {
+	if conf == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+	if plan := conf.Common.DebtPayoff; plan != nil {
+		if err := plan.apply(logger, conf.Common.Loans, *conf); err != nil {
+			return fmt.Errorf("common %w", err)
+		}
+	}
+	for i := range conf.Scenarios {
+		if plan := conf.Scenarios[i].DebtPayoff; plan != nil {
+			if err := plan.apply(logger, conf.Scenarios[i].Loans, *conf); err != nil {
+				return fmt.Errorf("scenario %s %w", conf.Scenarios[i].Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestDebtPayoffValidate(t *testing.T) {
+	loanList := []Loan{{Name: "Car"}, {Name: "Student loan"}}
+
+	tests := []struct {
+		name    string
+		plan    DebtPayoff
+		wantErr bool
+	}{
+		{name: "avalanche", plan: DebtPayoff{Strategy: "avalanche", Budget: 200}},
+		{name: "custom", plan: DebtPayoff{Strategy: "custom", Budget: 200, Order: []string{"Student loan"}}},
+		{name: "unknown strategy", plan: DebtPayoff{Strategy: "fastest", Budget: 200}, wantErr: true},
+		{name: "missing budget", plan: DebtPayoff{Strategy: "snowball"}, wantErr: true},
+		{name: "invalid start date", plan: DebtPayoff{Strategy: "snowball", Budget: 200, StartDate: "2025"}, wantErr: true},
+		{name: "custom without order", plan: DebtPayoff{Strategy: "custom", Budget: 200}, wantErr: true},
+		{name: "unknown loan in order", plan: DebtPayoff{Strategy: "custom", Budget: 200, Order: []string{"Boat"}}, wantErr: true},
+		{name: "duplicate loan in order", plan: DebtPayoff{Strategy: "custom", Budget: 200, Order: []string{"Car", "Car"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.plan.validate(loanList)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProcessLoansDebtPayoff(t *testing.T) {
+	conf := Configuration{
+		StartDate: "2025-01",
+		Common: Common{
+			DeathDate: "2030-01",
+			Loans:     []Loan{{Name: "Mortgage", StartDate: "2025-01", Principal: 12000, InterestRate: 6, Term: 120}},
+		},
+		Scenarios: []Scenario{{
+			Name: "Payoff",
+			Loans: []Loan{
+				{Name: "Car", StartDate: "2025-01", Principal: 1200, Term: 12},
+				{Name: "Card loan", StartDate: "2025-01", Principal: 2400, InterestRate: 12, Term: 24},
+			},
+			DebtPayoff: &DebtPayoff{Strategy: "snowball", Budget: 100},
+		}},
+	}
+	if err := conf.ProcessLoans(zap.NewNop()); err != nil {
+		t.Fatalf("ProcessLoans() error = %v", err)
+	}
+
+	plan := conf.Scenarios[0].DebtPayoff
+	if plan.Report == nil || plan.Report.Strategy != "snowball" {
+		t.Fatalf("expected a snowball report, got %+v", plan.Report)
+	}
+	if len(plan.Comparison) != 2 || plan.Comparison[0].Strategy != "avalanche" || plan.Comparison[1].Strategy != "snowball" {
+		t.Errorf("expected avalanche and snowball comparisons, got %+v", plan.Comparison)
+	}
+
+	// The plan starts with the first forecast month, 2025-02.
+	car := conf.Scenarios[0].Loans[0]
+	if got, ok := car.PayoffDate(); !ok || got != "2025-07" {
+		t.Errorf("car PayoffDate() = %s, %v, want 2025-07", got, ok)
+	}
+	if got := car.AmortizationSchedule["2025-02"].Payment; got != 200 {
+		t.Errorf("car payment in 2025-02 = %.2f, want 200.00", got)
+	}
+	if len(car.Notes["2025-07"]) != 1 {
+		t.Errorf("expected a payoff note for the car, got %v", car.Notes)
+	}
+
+	// Common loans are outside the scenario plan.
+	if got, ok := conf.Common.Loans[0].PayoffDate(); ok {
+		t.Errorf("common mortgage paid off %s; the scenario plan should not touch it", got)
+	}
+}
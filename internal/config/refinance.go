@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+// Refinance replaces a loan's interest rate and term from Date. The remaining
+// principal plus CashOut is re-amortized over Term months, and ClosingCosts
+// are paid with that month's payment while CashOut is received in cash.
+type Refinance struct {
+	Date         string  `yaml:"date" mapstructure:"date"`
+	InterestRate float64 `yaml:"interestRate" mapstructure:"interestRate"`
+	Term         int     `yaml:"term" mapstructure:"term"`
+	ClosingCosts float64 `yaml:"closingCosts,omitempty" mapstructure:"closingCosts"`
+	CashOut      float64 `yaml:"cashOut,omitempty" mapstructure:"cashOut"`
+}
+
+// validateRefinances checks that each refinance falls within the term in
+// effect at its date, after the loan's first payment, and in date order.
+func (loan Loan) validateRefinances() error {
+	if len(loan.Refinances) == 0 {
+		return nil
+	}
+	lastPayment, err := datetime.OffsetDate(loan.StartDate, DateTimeLayout, loan.term()-1)
+	if err != nil {
+		return fmt.Errorf("loan %s: %w", loan.Name, err)
+	}
+	previous := loan.StartDate
+	for _, refinance := range loan.Refinances {
+		if _, err := time.Parse(DateTimeLayout, refinance.Date); err != nil {
+			return fmt.Errorf("loan %s: refinance date %q: %w", loan.Name, refinance.Date, err)
+		}
+		if refinance.Date <= previous {
+			return fmt.Errorf("loan %s: refinance %s must come after the loan start and any earlier refinance", loan.Name, refinance.Date)
+		}
+		if refinance.Date > lastPayment {
+			return fmt.Errorf("loan %s: refinance %s comes after the final payment in %s", loan.Name, refinance.Date, lastPayment)
+		}
+		if refinance.Term <= 0 {
+			return fmt.Errorf("loan %s: refinance %s term must be greater than zero", loan.Name, refinance.Date)
+		}
+		if refinance.InterestRate < 0 || refinance.ClosingCosts < 0 || refinance.CashOut < 0 {
+			return fmt.Errorf("loan %s: refinance %s interestRate, closingCosts, and cashOut cannot be negative", loan.Name, refinance.Date)
+		}
+		lastPayment, err = datetime.OffsetDate(refinance.Date, DateTimeLayout, refinance.Term-1)
+		if err != nil {
+			return fmt.Errorf("loan %s: %w", loan.Name, err)
+		}
+		previous = refinance.Date
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestLoanRefinanceValidation(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-01"}}
+	tests := []struct {
+		name       string
+		refinances []Refinance
+		wantErr    string
+	}{
+		{"valid", []Refinance{{Date: "2025-06", InterestRate: 4, Term: 24}, {Date: "2026-06", InterestRate: 3, Term: 36}}, ""},
+		{"bad date", []Refinance{{Date: "June", Term: 24}}, "refinance date"},
+		{"at start", []Refinance{{Date: "2025-01", Term: 24}}, "must come after"},
+		{"out of order", []Refinance{{Date: "2025-06", Term: 24}, {Date: "2025-03", Term: 24}}, "must come after"},
+		{"after final payment", []Refinance{{Date: "2027-01", Term: 24}}, "after the final payment"},
+		{"within extended term", []Refinance{{Date: "2026-06", Term: 24}, {Date: "2028-01", Term: 12}}, ""},
+		{"zero term", []Refinance{{Date: "2025-06"}}, "term must be greater than zero"},
+		{"negative costs", []Refinance{{Date: "2025-06", Term: 24, ClosingCosts: -1}}, "cannot be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loan := &Loan{Name: "Mortgage", StartDate: "2025-01", Principal: 24000, InterestRate: 5, Term: 24, Refinances: tt.refinances}
+			err := loan.GetAmortizationSchedule(zap.NewNop(), conf)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("GetAmortizationSchedule() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("GetAmortizationSchedule() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanRefinanceNotes(t *testing.T) {
+	conf := Configuration{Common: Common{DeathDate: "2030-01"}}
+	loan := &Loan{
+		Name:                 "Mortgage",
+		StartDate:            "2025-01",
+		Principal:            12000,
+		Term:                 12,
+		EarlyPayoffThreshold: 1000,
+		Refinances:           []Refinance{{Date: "2025-06", Term: 24, ClosingCosts: 200}},
+	}
+	if err := loan.GetAmortizationSchedule(zap.NewNop(), conf); err != nil {
+		t.Fatalf("GetAmortizationSchedule() error = %v", err)
+	}
+	if len(loan.Notes["2025-06"]) != 1 {
+		t.Fatalf("expected a refinance note in 2025-06, got %v", loan.Notes)
+	}
+
+	note, err := loan.CheckEarlyPayoffThreshold("2025-03", conf.Common.DeathDate, 50000)
+	if err != nil {
+		t.Fatalf("CheckEarlyPayoffThreshold() error = %v", err)
+	}
+	if note == "" {
+		t.Fatal("expected the threshold to pay off the loan")
+	}
+	if _, ok := loan.Notes["2025-06"]; ok {
+		t.Error("expected the refinance note to be dropped after the payoff")
+	}
+}
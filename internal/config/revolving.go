@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RevolvingDebt is a credit card style balance. Each month the carried balance
+// accrues interest at APR/12, Charges (new purchases) are added, and the
+// minimum payment is made: MinimumPercent of the balance, but at least
+// MinimumPayment. With PayInFull the payment instead covers as much of the
+// balance as the cash on hand allows. Payments come from Account.
+type RevolvingDebt struct {
+	Name           string  `yaml:"name" mapstructure:"name"`
+	Balance        float64 `yaml:"balance" mapstructure:"balance"`
+	APR            float64 `yaml:"apr" mapstructure:"apr"`
+	MinimumPercent float64 `yaml:"minimumPercent,omitempty" mapstructure:"minimumPercent"`
+	MinimumPayment float64 `yaml:"minimumPayment,omitempty" mapstructure:"minimumPayment"`
+	Charges        []Event `yaml:"charges,omitempty" mapstructure:"charges"`
+	PayInFull      bool    `yaml:"payInFull,omitempty" mapstructure:"payInFull"`
+	Account        string  `yaml:"account,omitempty" mapstructure:"account"`
+}
+
+// Validate checks the debt's rates, minimum payment, and charges.
+func (debt RevolvingDebt) Validate() error {
+	if debt.Name == "" {
+		return fmt.Errorf("revolving debt name cannot be empty")
+	}
+	if debt.Balance < 0 || debt.APR < 0 || debt.MinimumPercent < 0 || debt.MinimumPayment < 0 {
+		return fmt.Errorf("revolving debt %s: balance, apr, minimumPercent, and minimumPayment cannot be negative", debt.Name)
+	}
+	if debt.MinimumPercent > 100 {
+		return fmt.Errorf("revolving debt %s: minimumPercent cannot exceed 100", debt.Name)
+	}
+	if !debt.PayInFull && debt.MinimumPercent == 0 && debt.MinimumPayment == 0 {
+		return fmt.Errorf("revolving debt %s: set minimumPercent, minimumPayment, or payInFull", debt.Name)
+	}
+	for _, charge := range debt.Charges {
+		if charge.Amount < 0 {
+			return fmt.Errorf("revolving debt %s: charge %s amount cannot be negative", debt.Name, charge.Name)
+		}
+		if charge.Anchored() || charge.PercentageBased() {
+			return fmt.Errorf("revolving debt %s: charges cannot use anchors or percentOf", debt.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateRevolvingDebts checks the common and scenario revolving debts.
+// Names must be unique within each scope.
+func (conf *Configuration) ValidateRevolvingDebts() error {
+	if conf == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+
+	check := func(scope string, debts []RevolvingDebt) error {
+		names := make(map[string]bool, len(debts))
+		for _, debt := range debts {
+			if err := debt.Validate(); err != nil {
+				return fmt.Errorf("%s %w", scope, err)
+			}
+			if names[debt.Name] {
+				return fmt.Errorf("%s revolving debt %s: duplicate name", scope, debt.Name)
+			}
+			names[debt.Name] = true
+		}
+		return nil
+	}
+
+	if err := check("common", conf.Common.RevolvingDebts); err != nil {
+		return err
+	}
+	for _, scenario := range conf.Scenarios {
+		if err := check(fmt.Sprintf("scenario %s", scenario.Name), scenario.RevolvingDebts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formChargeDateLists parses the dates of the debt's charges.
+func (debt *RevolvingDebt) formChargeDateLists(conf Configuration, fixedTime time.Time) error {
+	for i := range debt.Charges {
+		if debt.Charges[i].Anchored() || debt.Charges[i].PercentageBased() {
+			return fmt.Errorf("revolving debt %s: charges cannot use anchors or percentOf", debt.Name)
+		}
+		if err := debt.Charges[i].FormDateListWithFixedTime(conf, fixedTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+func TestValidateRevolvingDebts(t *testing.T) {
+	card := RevolvingDebt{Name: "Card", Balance: 2000, APR: 24, MinimumPercent: 2, MinimumPayment: 25}
+
+	tests := []struct {
+		name    string
+		conf    Configuration
+		wantErr bool
+	}{
+		{
+			name: "valid common and scenario debts",
+			conf: Configuration{
+				Common:    Common{RevolvingDebts: []RevolvingDebt{card}},
+				Scenarios: []Scenario{{Name: "Base", RevolvingDebts: []RevolvingDebt{card, {Name: "Store card", PayInFull: true}}}},
+			},
+		},
+		{
+			name:    "missing name",
+			conf:    Configuration{Common: Common{RevolvingDebts: []RevolvingDebt{{Balance: 100, MinimumPayment: 25}}}},
+			wantErr: true,
+		},
+		{
+			name:    "negative apr",
+			conf:    Configuration{Common: Common{RevolvingDebts: []RevolvingDebt{{Name: "Card", APR: -1, MinimumPayment: 25}}}},
+			wantErr: true,
+		},
+		{
+			name:    "minimum percent above 100",
+			conf:    Configuration{Common: Common{RevolvingDebts: []RevolvingDebt{{Name: "Card", MinimumPercent: 101}}}},
+			wantErr: true,
+		},
+		{
+			name:    "no minimum and no pay in full",
+			conf:    Configuration{Common: Common{RevolvingDebts: []RevolvingDebt{{Name: "Card", Balance: 100}}}},
+			wantErr: true,
+		},
+		{
+			name:    "negative charge",
+			conf:    Configuration{Common: Common{RevolvingDebts: []RevolvingDebt{{Name: "Card", PayInFull: true, Charges: []Event{{Name: "Refund", Amount: -10}}}}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name",
+			conf:    Configuration{Scenarios: []Scenario{{Name: "Base", RevolvingDebts: []RevolvingDebt{card, card}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.conf.ValidateRevolvingDebts()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRevolvingDebts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseDateListsRevolvingCharges(t *testing.T) {
+	conf := Configuration{
+		Common: Common{
+			DeathDate: "2025-06",
+			RevolvingDebts: []RevolvingDebt{{
+				Name:      "Card",
+				PayInFull: true,
+				Charges:   []Event{{Name: "Groceries", Amount: 400, Frequency: 1, StartDate: "2025-03"}},
+			}},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+	if got := len(conf.Common.RevolvingDebts[0].Charges[0].DateList); got != 4 {
+		t.Errorf("charge date list has %d dates, want 4", got)
+	}
+
+	conf.Common.RevolvingDebts[0].Charges[0].PercentOf = "Salary"
+	conf.Common.RevolvingDebts[0].Charges[0].Percentage = 5
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(DateTimeLayout, "2025-01")); err == nil {
+		t.Error("expected an error for a percentOf charge")
+	}
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+	"github.com/iwvelando/finance-forecast/pkg/mathutil"
+	"github.com/iwvelando/finance-forecast/pkg/socialsecurity"
+)
+
+// SocialSecurity models a household's Social Security benefits on an event.
+// The worker is the event's member (or the primary household member). PIA is
+// the monthly benefit at full retirement age in today's dollars and
+// ClaimingAge is in years, e.g. 62.5 for 62 and 6 months.
+type SocialSecurity struct {
+	PIA               float64         `yaml:"pia" mapstructure:"pia"`
+	ClaimingAge       float64         `yaml:"claimingAge" mapstructure:"claimingAge"`
+	FullRetirementAge float64         `yaml:"fullRetirementAge,omitempty" mapstructure:"fullRetirementAge"` // defaults from the birth year
+	COLA              *float64        `yaml:"cola,omitempty" mapstructure:"cola"`                           // defaults to the inflation rate
+	Spouse            *SpouseBenefits `yaml:"spouse,omitempty" mapstructure:"spouse"`
+}
+
+// SpouseBenefits describes the worker's spouse, who receives the larger of
+// their own benefit and the spousal or survivor benefit on the worker's record.
+type SpouseBenefits struct {
+	Member            string  `yaml:"member" mapstructure:"member"`
+	PIA               float64 `yaml:"pia,omitempty" mapstructure:"pia"`
+	ClaimingAge       float64 `yaml:"claimingAge" mapstructure:"claimingAge"`
+	FullRetirementAge float64 `yaml:"fullRetirementAge,omitempty" mapstructure:"fullRetirementAge"`
+}
+
+// Clone returns a deep copy of the benefit model.
+func (s *SocialSecurity) Clone() *SocialSecurity {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	clone.COLA = cloneFloatPtr(s.COLA)
+	if s.Spouse != nil {
+		spouse := *s.Spouse
+		clone.Spouse = &spouse
+	}
+	return &clone
+}
+
+// ageToMonths converts an age in years to whole months.
+func ageToMonths(age float64) int {
+	return int(math.Round(age * constants.MonthsPerYear))
+}
+
+// beneficiary resolves a household member into a socialsecurity.Person.
+func beneficiary(conf Configuration, memberName string, pia, claimingAge, fullRetirementAge float64) (socialsecurity.Person, error) {
+	member, err := conf.Household.Member(memberName)
+	if err != nil {
+		return socialsecurity.Person{}, err
+	}
+	birth, err := member.Birth()
+	if err != nil {
+		return socialsecurity.Person{}, err
+	}
+	person := socialsecurity.Person{
+		Birth:       birth,
+		PIA:         pia,
+		ClaimMonths: ageToMonths(claimingAge),
+		FRAMonths:   socialsecurity.FullRetirementAgeMonths(birth.Year()),
+	}
+	if fullRetirementAge != 0 {
+		person.FRAMonths = ageToMonths(fullRetirementAge)
+	}
+	if member.LifeExpectancy != 0 {
+		person.Death = birth.AddDate(member.LifeExpectancy, 0, 0)
+	}
+	if err := person.Validate(); err != nil {
+		return socialsecurity.Person{}, fmt.Errorf("member %s: %w", member.Name, err)
+	}
+	return person, nil
+}
+
+// formSocialSecurityDateList fills the event's dates and amounts with the
+// monthly household benefit from the first claim until the deathDate. Amounts
+// rise by the COLA every January after the simulation start.
+func (event *Event) formSocialSecurityDateList(conf Configuration, fixedTime time.Time) error {
+	ss := event.SocialSecurity
+	if event.Amount != 0 || event.StartDate != "" || event.EndDate != "" || event.StartAge != 0 || event.EndAge != 0 || event.Anchored() || event.PercentageBased() {
+		return fmt.Errorf("event %s: socialSecurity cannot be combined with amount, dates, or ages", event.Name)
+	}
+	if event.GrowthRate != 0 || event.AnnualGrowthRate != 0 || event.GrowthMonth != 0 || event.IndexToInflation {
+		return fmt.Errorf("event %s: socialSecurity uses cola instead of growthRate, annualGrowthRate, or indexToInflation", event.Name)
+	}
+	if len(event.AmountSchedule) > 0 {
+		return fmt.Errorf("event %s: socialSecurity cannot be combined with amountSchedule", event.Name)
+	}
+
+	if ss.PIA <= 0 {
+		return fmt.Errorf("event %s: socialSecurity pia must be greater than zero", event.Name)
+	}
+
+	worker, err := beneficiary(conf, event.Member, ss.PIA, ss.ClaimingAge, ss.FullRetirementAge)
+	if err != nil {
+		return fmt.Errorf("event %s: %w", event.Name, err)
+	}
+	var spouse *socialsecurity.Person
+	start := worker.ClaimDate()
+	if ss.Spouse != nil {
+		if workerMember, _ := conf.Household.Member(event.Member); ss.Spouse.Member == "" || ss.Spouse.Member == workerMember.Name {
+			return fmt.Errorf("event %s: spouse must name another household member", event.Name)
+		}
+		person, err := beneficiary(conf, ss.Spouse.Member, ss.Spouse.PIA, ss.Spouse.ClaimingAge, ss.Spouse.FullRetirementAge)
+		if err != nil {
+			return fmt.Errorf("event %s spouse: %w", event.Name, err)
+		}
+		spouse = &person
+		if claim := person.ClaimDate(); claim.Before(start) {
+			start = claim
+		}
+	}
+
+	cola := conf.Inflation
+	if ss.COLA != nil {
+		cola = *ss.COLA
+	}
+
+	startOfSimulation := datetime.MustParseTime(DateTimeLayout, fixedTime.Format(DateTimeLayout))
+	if start.Before(startOfSimulation) {
+		start = startOfSimulation
+	}
+	end, err := time.Parse(DateTimeLayout, conf.Common.DeathDate)
+	if err != nil {
+		return err
+	}
+
+	event.Frequency = 1
+	event.DateList = []time.Time{}
+	event.AmountList = []float64{}
+	for date := start; !date.After(end); date = date.AddDate(0, 1, 0) {
+		benefit := socialsecurity.HouseholdBenefit(worker, spouse, date)
+		if benefit <= 0 {
+			continue
+		}
+		years := date.Year() - startOfSimulation.Year()
+		event.DateList = append(event.DateList, date)
+		event.AmountList = append(event.AmountList, mathutil.CompoundGrowth(benefit, cola, years))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"math"
+	"testing"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+func TestSocialSecurityDateList(t *testing.T) {
+	household := &Household{Members: []Member{
+		{Name: "Alex", BirthDate: "1960-06"},
+		{Name: "Sam", BirthDate: "1962-01"},
+	}}
+	fixedTime := datetime.MustParseTime(DateTimeLayout, "2025-01")
+	cola := 2.0
+
+	tests := []struct {
+		name        string
+		benefits    SocialSecurity
+		wantFirst   string
+		wantCount   int
+		wantAmounts map[string]float64
+	}{
+		{
+			name:        "worker claims at full retirement age",
+			benefits:    SocialSecurity{PIA: 2000, ClaimingAge: 67, COLA: &cola},
+			wantFirst:   "2027-06",
+			wantCount:   43,
+			wantAmounts: map[string]float64{"2027-06": 2080.80, "2030-12": 2000 * math.Pow(1.02, 5)},
+		},
+		{
+			name:        "worker delays to 70",
+			benefits:    SocialSecurity{PIA: 2000, ClaimingAge: 70, COLA: floatPtr(0)},
+			wantFirst:   "2030-06",
+			wantCount:   7,
+			wantAmounts: map[string]float64{"2030-06": 2480},
+		},
+		{
+			name: "spouse claims early and tops up once the worker claims",
+			benefits: SocialSecurity{PIA: 2000, ClaimingAge: 67, COLA: &cola,
+				Spouse: &SpouseBenefits{Member: "Sam", PIA: 600, ClaimingAge: 62}},
+			wantFirst:   "2025-01",
+			wantCount:   72,
+			wantAmounts: map[string]float64{"2025-01": 420, "2027-05": 420 * 1.0404, "2027-06": 2650 * 1.0404},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := Configuration{Household: household, Common: Common{DeathDate: "2030-12"}}
+			benefits := tt.benefits
+			event := Event{Name: "Social Security", SocialSecurity: &benefits}
+			if err := event.FormDateListWithFixedTime(conf, fixedTime); err != nil {
+				t.Fatalf("FormDateListWithFixedTime() error = %v", err)
+			}
+			if len(event.DateList) != tt.wantCount {
+				t.Fatalf("len(DateList) = %d, want %d", len(event.DateList), tt.wantCount)
+			}
+			if got := event.DateList[0].Format(DateTimeLayout); got != tt.wantFirst {
+				t.Errorf("first date = %s, want %s", got, tt.wantFirst)
+			}
+			for i, date := range event.DateList {
+				want, ok := tt.wantAmounts[date.Format(DateTimeLayout)]
+				if ok && math.Abs(event.AmountList[i]-want) > 0.01 {
+					t.Errorf("amount on %s = %.2f, want %.2f", date.Format(DateTimeLayout), event.AmountList[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestSocialSecurityDateListErrors(t *testing.T) {
+	household := &Household{Members: []Member{
+		{Name: "Alex", BirthDate: "1960-06"},
+		{Name: "Sam", BirthDate: "1962-01"},
+	}}
+	fixedTime := datetime.MustParseTime(DateTimeLayout, "2025-01")
+
+	tests := []struct {
+		name      string
+		event     Event
+		household *Household
+	}{
+		{"combined with amount", Event{Name: "Bad", Amount: 100, SocialSecurity: &SocialSecurity{PIA: 2000, ClaimingAge: 67}}, household},
+		{"combined with growth rate", Event{Name: "Bad", GrowthRate: 2, SocialSecurity: &SocialSecurity{PIA: 2000, ClaimingAge: 67}}, household},
+		{"missing pia", Event{Name: "Bad", SocialSecurity: &SocialSecurity{ClaimingAge: 67}}, household},
+		{"claiming too early", Event{Name: "Bad", SocialSecurity: &SocialSecurity{PIA: 2000, ClaimingAge: 61}}, household},
+		{"spouse is the worker", Event{Name: "Bad", SocialSecurity: &SocialSecurity{PIA: 2000, ClaimingAge: 67, Spouse: &SpouseBenefits{Member: "Alex", ClaimingAge: 67}}}, household},
+		{"unknown spouse", Event{Name: "Bad", SocialSecurity: &SocialSecurity{PIA: 2000, ClaimingAge: 67, Spouse: &SpouseBenefits{Member: "Pat", ClaimingAge: 67}}}, household},
+		{"no household", Event{Name: "Bad", SocialSecurity: &SocialSecurity{PIA: 2000, ClaimingAge: 67}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := Configuration{Household: tt.household, Common: Common{DeathDate: "2030-12"}}
+			event := tt.event
+			if err := event.FormDateListWithFixedTime(conf, fixedTime); err == nil {
+				t.Fatalf("FormDateListWithFixedTime() expected an error")
+			}
+		})
+	}
+}
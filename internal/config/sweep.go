@@ -0,0 +1,305 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxSweepAxes is the number of parameters a sweep may vary, the rows and
+// columns of its grid.
+const maxSweepAxes = 2
+
+// maxSweepCells bounds the scenarios a single sweep may generate.
+const maxSweepCells = 1000
+
+// sweepValuePrecision is the number of decimal places sweep values are rounded
+// to, so that stepping by fractions such as 0.1 lands on the values written.
+const sweepValuePrecision = 1e6
+
+// ParameterSweep generates a grid of scenarios from a base scenario, one for
+// every combination of the values of its axes.
+type ParameterSweep struct {
+	Name     string      `yaml:"name" mapstructure:"name"`
+	Scenario string      `yaml:"scenario" mapstructure:"scenario"` // base scenario
+	Axes     []SweepAxis `yaml:"axes" mapstructure:"axes"`
+}
+
+// SweepAxis sets every target parameter to each value from From to To, in
+// steps of Step. A target names a field of an item in the base scenario as
+// kind:item.field, such as loan:House.principal.
+type SweepAxis struct {
+	Name    string   `yaml:"name,omitempty" mapstructure:"name"`
+	Targets []string `yaml:"targets" mapstructure:"targets"`
+	From    float64  `yaml:"from" mapstructure:"from"`
+	To      float64  `yaml:"to" mapstructure:"to"`
+	Step    float64  `yaml:"step" mapstructure:"step"`
+}
+
+// Clone returns a deep copy of the sweep.
+func (sweep ParameterSweep) Clone() ParameterSweep {
+	clone := sweep
+	if sweep.Axes != nil {
+		clone.Axes = make([]SweepAxis, len(sweep.Axes))
+		for i, axis := range sweep.Axes {
+			clone.Axes[i] = axis
+			clone.Axes[i].Targets = append([]string(nil), axis.Targets...)
+		}
+	}
+	return clone
+}
+
+// Label returns the axis name, or its first target when it has none.
+func (axis SweepAxis) Label() string {
+	if axis.Name != "" || len(axis.Targets) == 0 {
+		return axis.Name
+	}
+	return axis.Targets[0]
+}
+
+// Values returns the values the axis steps through, From and every Step
+// after it up to and including To.
+func (axis SweepAxis) Values() []float64 {
+	if axis.Step <= 0 || axis.To < axis.From {
+		return nil
+	}
+	count := int(math.Floor((axis.To-axis.From)/axis.Step+1e-9)) + 1
+	values := make([]float64, count)
+	for i := range values {
+		values[i] = math.Round((axis.From+float64(i)*axis.Step)*sweepValuePrecision) / sweepValuePrecision
+	}
+	return values
+}
+
+// Cells returns the axis values of every scenario in the grid, each row of
+// the first axis in turn across the values of the second.
+func (sweep ParameterSweep) Cells() [][]float64 {
+	cells := [][]float64{nil}
+	for _, axis := range sweep.Axes {
+		var next [][]float64
+		for _, cell := range cells {
+			for _, value := range axis.Values() {
+				next = append(next, append(append([]float64(nil), cell...), value))
+			}
+		}
+		cells = next
+	}
+	return cells
+}
+
+// CellName returns the name of the scenario generated for the axis values.
+func (sweep ParameterSweep) CellName(values []float64) string {
+	parts := make([]string, 0, len(values))
+	for i, value := range values {
+		if i < len(sweep.Axes) {
+			parts = append(parts, sweep.Axes[i].Label()+" "+strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+	return fmt.Sprintf("%s [%s]", sweep.Name, strings.Join(parts, ", "))
+}
+
+// ExpandSweeps appends an active scenario for every cell of every sweep: a
+// copy of the sweep's base scenario with the cell's values applied. Scenarios
+// generated by an earlier expansion are replaced, so expanding is repeatable.
+func (conf *Configuration) ExpandSweeps() error {
+	if conf == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+
+	scenarios := make([]Scenario, 0, len(conf.Scenarios))
+	for _, scenario := range conf.Scenarios {
+		if scenario.Sweep == "" {
+			scenarios = append(scenarios, scenario)
+		}
+	}
+	names := make(map[string]bool, len(scenarios))
+	for _, scenario := range scenarios {
+		names[scenario.Name] = true
+	}
+
+	sweepNames := make(map[string]bool, len(conf.Sweeps))
+	generated := scenarios
+	for _, sweep := range conf.Sweeps {
+		if sweep.Name == "" {
+			return fmt.Errorf("sweep name is required")
+		}
+		if sweepNames[sweep.Name] {
+			return fmt.Errorf("sweep %s: name is used by more than one sweep", sweep.Name)
+		}
+		sweepNames[sweep.Name] = true
+
+		base, err := sweep.baseScenario(scenarios)
+		if err != nil {
+			return fmt.Errorf("sweep %s: %w", sweep.Name, err)
+		}
+		if err := sweep.validate(base); err != nil {
+			return fmt.Errorf("sweep %s: %w", sweep.Name, err)
+		}
+
+		for _, values := range sweep.Cells() {
+			scenario := base.Clone()
+			scenario.Name = sweep.CellName(values)
+			scenario.Active = true
+			scenario.Extends = ""
+			scenario.Remove = nil
+			scenario.Sweep = sweep.Name
+			if names[scenario.Name] {
+				return fmt.Errorf("sweep %s: generated scenario %q is already defined", sweep.Name, scenario.Name)
+			}
+			names[scenario.Name] = true
+			for i, axis := range sweep.Axes {
+				for _, target := range axis.Targets {
+					if err := scenario.applySweepTarget(target, values[i]); err != nil {
+						return fmt.Errorf("sweep %s: %w", sweep.Name, err)
+					}
+				}
+			}
+			generated = append(generated, scenario)
+		}
+	}
+	conf.Scenarios = generated
+	return nil
+}
+
+// baseScenario returns the scenario the sweep varies.
+func (sweep ParameterSweep) baseScenario(scenarios []Scenario) (Scenario, error) {
+	if sweep.Scenario == "" {
+		return Scenario{}, fmt.Errorf("scenario is required")
+	}
+	found := -1
+	for i, scenario := range scenarios {
+		if scenario.Name != sweep.Scenario {
+			continue
+		}
+		if found >= 0 {
+			return Scenario{}, fmt.Errorf("scenario %q matches more than one scenario", sweep.Scenario)
+		}
+		found = i
+	}
+	if found < 0 {
+		return Scenario{}, fmt.Errorf("unknown scenario %q", sweep.Scenario)
+	}
+	return scenarios[found], nil
+}
+
+// validate checks the sweep's axes against its base scenario.
+func (sweep ParameterSweep) validate(base Scenario) error {
+	if len(sweep.Axes) == 0 || len(sweep.Axes) > maxSweepAxes {
+		return fmt.Errorf("must have 1 or %d axes, got %d", maxSweepAxes, len(sweep.Axes))
+	}
+	cells := 1
+	for i, axis := range sweep.Axes {
+		if len(axis.Targets) == 0 {
+			return fmt.Errorf("axis %d: at least one target is required", i+1)
+		}
+		if axis.Step <= 0 {
+			return fmt.Errorf("axis %d: step must be positive", i+1)
+		}
+		if axis.To < axis.From {
+			return fmt.Errorf("axis %d: to %.2f is before from %.2f", i+1, axis.To, axis.From)
+		}
+		values := axis.Values()
+		cells *= len(values)
+		if cells > maxSweepCells {
+			return fmt.Errorf("generates more than %d scenarios", maxSweepCells)
+		}
+		for _, target := range axis.Targets {
+			// Apply every value to a scratch copy so that unknown targets and
+			// values a field cannot hold are reported before expanding.
+			scratch := base.Clone()
+			for _, value := range values {
+				if err := scratch.applySweepTarget(target, value); err != nil {
+					return fmt.Errorf("axis %d: %w", i+1, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// applySweepTarget sets the scenario parameter named by target to value.
+func (scenario *Scenario) applySweepTarget(target string, value float64) error {
+	kind, rest, ok := strings.Cut(target, ":")
+	dot := strings.LastIndex(rest, ".")
+	if !ok || dot <= 0 || dot == len(rest)-1 {
+		return fmt.Errorf("target %q must be of the form kind:name.field", target)
+	}
+	name, field := rest[:dot], rest[dot+1:]
+
+	var set func(float64) error
+	switch kind {
+	case "event":
+		for i := range scenario.Events {
+			if scenario.Events[i].Name != name {
+				continue
+			}
+			event := &scenario.Events[i]
+			switch field {
+			case "amount":
+				set = func(v float64) error { event.Amount = v; return nil }
+			}
+			break
+		}
+	case "loan":
+		for i := range scenario.Loans {
+			if scenario.Loans[i].Name != name {
+				continue
+			}
+			loan := &scenario.Loans[i]
+			switch field {
+			case "principal":
+				set = func(v float64) error { loan.Principal = v; return nil }
+			case "interestRate":
+				set = func(v float64) error { loan.InterestRate = v; return nil }
+			case "downPayment":
+				set = func(v float64) error { loan.DownPayment = v; return nil }
+			case "payment":
+				set = func(v float64) error { loan.Payment = v; return nil }
+			case "term":
+				set = func(v float64) error {
+					if v != math.Trunc(v) {
+						return fmt.Errorf("target %q takes whole months, got %v", target, v)
+					}
+					loan.Term = int(v)
+					return nil
+				}
+			}
+			break
+		}
+	case "investment":
+		for i := range scenario.Investments {
+			if scenario.Investments[i].Name != name {
+				continue
+			}
+			investment := &scenario.Investments[i]
+			switch field {
+			case "startingValue":
+				set = func(v float64) error { investment.StartingValue = v; return nil }
+			case "annualReturnRate":
+				set = func(v float64) error { investment.AnnualReturnRate = v; return nil }
+			}
+			break
+		}
+	case "asset":
+		for i := range scenario.Assets {
+			if scenario.Assets[i].Name != name {
+				continue
+			}
+			asset := &scenario.Assets[i]
+			switch field {
+			case "purchaseValue":
+				set = func(v float64) error { asset.PurchaseValue = v; return nil }
+			case "appreciationRate":
+				set = func(v float64) error { asset.AppreciationRate = v; return nil }
+			}
+			break
+		}
+	default:
+		return fmt.Errorf("target %q: unknown kind %q, must be event, loan, investment or asset", target, kind)
+	}
+	if set == nil {
+		return fmt.Errorf("target %q: scenario %s has no %s %q with a sweepable field %q", target, scenario.Name, kind, name, field)
+	}
+	return set(value)
+}
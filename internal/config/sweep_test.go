@@ -0,0 +1,212 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func sweepTestConfig() Configuration {
+	return Configuration{
+		Scenarios: []Scenario{
+			{
+				Name:   "Buy",
+				Active: true,
+				Events: []Event{{Name: "Salary", Amount: 6000, Frequency: 1}},
+				Loans:  []Loan{{Name: "House", Principal: 350000, DownPayment: 70000, InterestRate: 6, Term: 360}},
+				Assets: []Asset{{Name: "Home", PurchaseDate: "2026-01", PurchaseValue: 350000}},
+			},
+		},
+		Sweeps: []ParameterSweep{
+			{
+				Name:     "Price by rate",
+				Scenario: "Buy",
+				Axes: []SweepAxis{
+					{Name: "price", Targets: []string{"loan:House.principal", "asset:Home.purchaseValue"}, From: 300000, To: 400000, Step: 50000},
+					{Name: "rate", Targets: []string{"loan:House.interestRate"}, From: 5, To: 6, Step: 0.5},
+				},
+			},
+		},
+	}
+}
+
+func TestSweepAxisValues(t *testing.T) {
+	tests := []struct {
+		name string
+		axis SweepAxis
+		want []float64
+	}{
+		{"whole steps", SweepAxis{From: 300000, To: 500000, Step: 100000}, []float64{300000, 400000, 500000}},
+		{"fractional steps", SweepAxis{From: 5, To: 5.3, Step: 0.1}, []float64{5, 5.1, 5.2, 5.3}},
+		{"to between steps", SweepAxis{From: 1, To: 2.5, Step: 1}, []float64{1, 2}},
+		{"single value", SweepAxis{From: 7, To: 7, Step: 1}, []float64{7}},
+		{"no step", SweepAxis{From: 1, To: 2}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.axis.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandSweeps(t *testing.T) {
+	conf := sweepTestConfig()
+	if err := conf.ExpandSweeps(); err != nil {
+		t.Fatalf("ExpandSweeps() error = %v", err)
+	}
+	if len(conf.Scenarios) != 1+3*3 {
+		t.Fatalf("got %d scenarios, want the base and 9 generated", len(conf.Scenarios))
+	}
+
+	cell := conf.Scenarios[6]
+	if cell.Name != "Price by rate [price 350000, rate 6]" {
+		t.Errorf("cell name = %q", cell.Name)
+	}
+	if !cell.Active || cell.Sweep != "Price by rate" {
+		t.Errorf("cell Active = %v, Sweep = %q, want an active scenario of the sweep", cell.Active, cell.Sweep)
+	}
+	if cell.Loans[0].Principal != 350000 || cell.Loans[0].InterestRate != 6 || cell.Assets[0].PurchaseValue != 350000 {
+		t.Errorf("cell loan = %+v, asset = %+v, want price 350000 at 6%%", cell.Loans[0], cell.Assets[0])
+	}
+	if last := conf.Scenarios[9]; last.Loans[0].Principal != 400000 || last.Loans[0].InterestRate != 6 {
+		t.Errorf("last cell loan = %+v, want price 400000 at 6%%", last.Loans[0])
+	}
+	if base := conf.Scenarios[0]; base.Loans[0].InterestRate != 6 || base.Sweep != "" {
+		t.Errorf("base scenario changed: %+v", base)
+	}
+
+	if err := conf.ExpandSweeps(); err != nil {
+		t.Fatalf("second ExpandSweeps() error = %v", err)
+	}
+	if len(conf.Scenarios) != 10 {
+		t.Errorf("re-expanding left %d scenarios, want 10", len(conf.Scenarios))
+	}
+
+	compact := conf.Compact()
+	if len(compact.Scenarios) != 1 || len(compact.Sweeps) != 1 {
+		t.Errorf("Compact() kept %d scenarios and %d sweeps, want the base and the sweep", len(compact.Scenarios), len(compact.Sweeps))
+	}
+}
+
+func TestExpandSweepsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(conf *Configuration)
+		wantErr string
+	}{
+		{
+			name:    "unknown base scenario",
+			modify:  func(conf *Configuration) { conf.Sweeps[0].Scenario = "Rent" },
+			wantErr: `unknown scenario "Rent"`,
+		},
+		{
+			name:    "no axes",
+			modify:  func(conf *Configuration) { conf.Sweeps[0].Axes = nil },
+			wantErr: "must have 1 or 2 axes",
+		},
+		{
+			name: "three axes",
+			modify: func(conf *Configuration) {
+				conf.Sweeps[0].Axes = append(conf.Sweeps[0].Axes, SweepAxis{Targets: []string{"event:Salary.amount"}, From: 1, To: 2, Step: 1})
+			},
+			wantErr: "must have 1 or 2 axes",
+		},
+		{
+			name:    "non-positive step",
+			modify:  func(conf *Configuration) { conf.Sweeps[0].Axes[1].Step = 0 },
+			wantErr: "axis 2: step must be positive",
+		},
+		{
+			name:    "descending range",
+			modify:  func(conf *Configuration) { conf.Sweeps[0].Axes[0].To = 100000 },
+			wantErr: "axis 1: to 100000.00 is before from 300000.00",
+		},
+		{
+			name:    "unknown item",
+			modify:  func(conf *Configuration) { conf.Sweeps[0].Axes[1].Targets = []string{"loan:Car.interestRate"} },
+			wantErr: `scenario Buy has no loan "Car"`,
+		},
+		{
+			name:    "unknown field",
+			modify:  func(conf *Configuration) { conf.Sweeps[0].Axes[1].Targets = []string{"loan:House.escrow"} },
+			wantErr: `sweepable field "escrow"`,
+		},
+		{
+			name:    "malformed target",
+			modify:  func(conf *Configuration) { conf.Sweeps[0].Axes[1].Targets = []string{"House.interestRate"} },
+			wantErr: "must be of the form kind:name.field",
+		},
+		{
+			name:    "fractional term",
+			modify:  func(conf *Configuration) { conf.Sweeps[0].Axes[1].Targets = []string{"loan:House.term"} },
+			wantErr: "takes whole months",
+		},
+		{
+			name:    "too many cells",
+			modify:  func(conf *Configuration) { conf.Sweeps[0].Axes[0].Step = 1 },
+			wantErr: "generates more than 1000 scenarios",
+		},
+		{
+			name: "duplicate sweep name",
+			modify: func(conf *Configuration) {
+				conf.Sweeps = append(conf.Sweeps, conf.Sweeps[0].Clone())
+			},
+			wantErr: "name is used by more than one sweep",
+		},
+		{
+			name: "generated name already defined",
+			modify: func(conf *Configuration) {
+				conf.Scenarios = append(conf.Scenarios, Scenario{Name: "Price by rate [price 300000, rate 5]"})
+			},
+			wantErr: "is already defined",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := sweepTestConfig()
+			tt.modify(&conf)
+			err := conf.ExpandSweeps()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ExpandSweeps() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigurationExpandsSweeps(t *testing.T) {
+	yamlConfig := `
+common:
+  deathDate: 2030-01
+scenarios:
+  - name: Base
+    active: true
+    events:
+      - name: Salary
+        amount: 6000
+        frequency: 1
+  - name: Frugal
+    active: true
+    extends: Base
+sweeps:
+  - name: Salary
+    scenario: Frugal
+    axes:
+      - targets: [event:Salary.amount]
+        from: 5000
+        to: 7000
+        step: 1000
+`
+	conf, err := LoadConfigurationFromReader(strings.NewReader(yamlConfig))
+	if err != nil {
+		t.Fatalf("LoadConfigurationFromReader() error = %v", err)
+	}
+	if len(conf.Scenarios) != 5 {
+		t.Fatalf("got %d scenarios, want 2 configured and 3 generated", len(conf.Scenarios))
+	}
+	cell := conf.Scenarios[3]
+	if cell.Name != "Salary [event:Salary.amount 6000]" || cell.Events[0].Amount != 6000 {
+		t.Errorf("cell = %s with salary %.2f, want the 6000 salary cell", cell.Name, cell.Events[0].Amount)
+	}
+}
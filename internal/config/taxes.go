@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/iwvelando/finance-forecast/pkg/tax"
+)
+
+// TaxableGross marks an event amount as pre-tax income that the tax engine
+// taxes at marginal rates. Negative taxable events reduce taxable income.
+const TaxableGross = "gross"
+
+// TaxConfig configures the progressive income tax engine. Brackets and
+// StandardDeduction override the built-in federal schedule for FilingStatus.
+type TaxConfig struct {
+	FilingStatus      string       `yaml:"filingStatus,omitempty" mapstructure:"filingStatus"`
+	StateRate         float64      `yaml:"stateRate,omitempty" mapstructure:"stateRate"`
+	StandardDeduction *float64     `yaml:"standardDeduction,omitempty" mapstructure:"standardDeduction"`
+	Brackets          []TaxBracket `yaml:"brackets,omitempty" mapstructure:"brackets"`
+	Settlement        string       `yaml:"settlement,omitempty" mapstructure:"settlement"` // monthly (default) or annual
+}
+
+// TaxBracket is a marginal rate (percent) applied above Threshold.
+type TaxBracket struct {
+	Threshold float64 `yaml:"threshold" mapstructure:"threshold"`
+	Rate      float64 `yaml:"rate" mapstructure:"rate"`
+}
+
+// Schedule resolves the federal schedule, applying any overrides.
+func (taxes TaxConfig) Schedule() (tax.Schedule, error) {
+	schedule, err := tax.FederalSchedule(taxes.FilingStatus)
+	if err != nil {
+		return tax.Schedule{}, err
+	}
+	if len(taxes.Brackets) > 0 {
+		schedule.Brackets = make([]tax.Bracket, len(taxes.Brackets))
+		for i, bracket := range taxes.Brackets {
+			schedule.Brackets[i] = tax.Bracket{Threshold: bracket.Threshold, Rate: bracket.Rate}
+		}
+	}
+	if taxes.StandardDeduction != nil {
+		schedule.StandardDeduction = *taxes.StandardDeduction
+	}
+	return schedule, nil
+}
+
+// NewEngine builds a tax engine from the configuration.
+func (taxes TaxConfig) NewEngine() (*tax.Engine, error) {
+	schedule, err := taxes.Schedule()
+	if err != nil {
+		return nil, fmt.Errorf("taxes: %w", err)
+	}
+	engine, err := tax.NewEngine(schedule, taxes.StateRate, taxes.Settlement)
+	if err != nil {
+		return nil, fmt.Errorf("taxes: %w", err)
+	}
+	return engine, nil
+}
+
+// ValidateTaxes checks the taxes block and the taxable flag on events.
+func (conf *Configuration) ValidateTaxes() error {
+	if conf == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+	if conf.Taxes != nil {
+		if _, err := conf.Taxes.NewEngine(); err != nil {
+			return err
+		}
+	}
+
+	checkEvents := func(scope string, events []Event) error {
+		for _, event := range events {
+			switch event.Taxable {
+			case "":
+			case TaxableGross:
+				if conf.Taxes == nil {
+					return fmt.Errorf("%s event %s: taxable requires a taxes block", scope, event.Name)
+				}
+			default:
+				return fmt.Errorf("%s event %s: taxable %q is not supported", scope, event.Name, event.Taxable)
+			}
+		}
+		return nil
+	}
+
+	if err := checkEvents("common", conf.Common.Events); err != nil {
+		return err
+	}
+	for _, scenario := range conf.Scenarios {
+		if err := checkEvents(fmt.Sprintf("scenario %s", scenario.Name), scenario.Events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
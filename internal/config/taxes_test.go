@@ -0,0 +1,86 @@
+package config
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTaxConfigSchedule(t *testing.T) {
+	deduction := 1000.0
+	taxes := TaxConfig{
+		FilingStatus:      "married-joint",
+		StandardDeduction: &deduction,
+	}
+
+	schedule, err := taxes.Schedule()
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	if schedule.StandardDeduction != 1000 {
+		t.Errorf("StandardDeduction = %.2f, want override 1000", schedule.StandardDeduction)
+	}
+	if len(schedule.Brackets) != 7 || schedule.Brackets[1].Threshold != 23200 {
+		t.Errorf("expected built-in married-joint brackets, got %+v", schedule.Brackets)
+	}
+
+	taxes.Brackets = []TaxBracket{{Threshold: 0, Rate: 15}}
+	schedule, err = taxes.Schedule()
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	if got := schedule.Tax(11000); math.Abs(got-1500) > 1e-9 {
+		t.Errorf("Tax() with bracket override = %.2f, want 1500", got)
+	}
+}
+
+func TestValidateTaxes(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    Configuration
+		wantErr bool
+	}{
+		{
+			name: "no taxes",
+			conf: Configuration{Common: Common{Events: []Event{{Name: "Salary"}}}},
+		},
+		{
+			name: "gross event with taxes",
+			conf: Configuration{
+				Taxes:     &TaxConfig{FilingStatus: "single", Settlement: "annual"},
+				Scenarios: []Scenario{{Name: "Base", Events: []Event{{Name: "Salary", Taxable: TaxableGross}}}},
+			},
+		},
+		{
+			name:    "gross event without taxes",
+			conf:    Configuration{Common: Common{Events: []Event{{Name: "Salary", Taxable: TaxableGross}}}},
+			wantErr: true,
+		},
+		{
+			name: "unsupported taxable value",
+			conf: Configuration{
+				Taxes:  &TaxConfig{},
+				Common: Common{Events: []Event{{Name: "Salary", Taxable: "net"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown filing status",
+			conf:    Configuration{Taxes: &TaxConfig{FilingStatus: "widowed"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown settlement",
+			conf:    Configuration{Taxes: &TaxConfig{Settlement: "quarterly"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.conf.ValidateTaxes()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateTaxes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
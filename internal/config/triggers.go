@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Balances a trigger condition can watch.
+const (
+	TriggerBalanceLiquid     = "liquid"
+	TriggerBalanceTotal      = "total"
+	TriggerBalanceInvestment = "investment"
+	TriggerBalanceLoan       = "loan"
+)
+
+// Trigger reacts to the simulated balances at the end of each month. When its
+// condition holds it transfers money between cash and an investment, stops an
+// event, or both. A trigger fires once unless Repeat is set, in which case it
+// fires every month the condition holds.
+type Trigger struct {
+	Name      string           `yaml:"name" mapstructure:"name"`
+	When      TriggerCondition `yaml:"when" mapstructure:"when"`
+	Transfer  *TriggerTransfer `yaml:"transfer,omitempty" mapstructure:"transfer"`
+	StopEvent string           `yaml:"stopEvent,omitempty" mapstructure:"stopEvent"`
+	Repeat    bool             `yaml:"repeat,omitempty" mapstructure:"repeat"`
+}
+
+// TriggerCondition compares a balance against a threshold. Balance is liquid,
+// total, investment:<name>, or loan:<name>; exactly one of Above and Below is set.
+type TriggerCondition struct {
+	Balance string   `yaml:"balance" mapstructure:"balance"`
+	Above   *float64 `yaml:"above,omitempty" mapstructure:"above"`
+	Below   *float64 `yaml:"below,omitempty" mapstructure:"below"`
+}
+
+// TriggerTransfer moves Amount from cash into the investment named by To, or
+// sells it from the investment named by From into cash.
+type TriggerTransfer struct {
+	From   string  `yaml:"from,omitempty" mapstructure:"from"`
+	To     string  `yaml:"to,omitempty" mapstructure:"to"`
+	Amount float64 `yaml:"amount" mapstructure:"amount"`
+}
+
+// Source returns the kind of balance watched and, for investments and loans,
+// its name.
+func (c TriggerCondition) Source() (string, string, error) {
+	value := strings.TrimSpace(c.Balance)
+	kind, name, found := strings.Cut(value, ":")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	name = strings.TrimSpace(name)
+	switch kind {
+	case TriggerBalanceLiquid, TriggerBalanceTotal:
+		if found {
+			return "", "", fmt.Errorf("balance %q does not take a name", c.Balance)
+		}
+		return kind, "", nil
+	case TriggerBalanceInvestment, TriggerBalanceLoan:
+		if name == "" {
+			return "", "", fmt.Errorf("balance %q must name the %s", c.Balance, kind)
+		}
+		return kind, name, nil
+	default:
+		return "", "", fmt.Errorf("balance %q must be liquid, total, investment:<name>, or loan:<name>", c.Balance)
+	}
+}
+
+// Met reports whether value satisfies the condition.
+func (c TriggerCondition) Met(value float64) bool {
+	if c.Above != nil {
+		return value > *c.Above
+	}
+	return c.Below != nil && value < *c.Below
+}
+
+// String describes the condition for forecast notes.
+func (c TriggerCondition) String() string {
+	if c.Above != nil {
+		return fmt.Sprintf("%s above %.2f", c.Balance, *c.Above)
+	}
+	if c.Below != nil {
+		return fmt.Sprintf("%s below %.2f", c.Balance, *c.Below)
+	}
+	return c.Balance
+}
+
+// Validate checks the trigger's condition and actions.
+func (t Trigger) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("trigger name cannot be empty")
+	}
+	if _, _, err := t.When.Source(); err != nil {
+		return fmt.Errorf("trigger %s: %w", t.Name, err)
+	}
+	if (t.When.Above == nil) == (t.When.Below == nil) {
+		return fmt.Errorf("trigger %s: when requires exactly one of above or below", t.Name)
+	}
+	if t.Transfer == nil && t.StopEvent == "" {
+		return fmt.Errorf("trigger %s: requires a transfer or stopEvent action", t.Name)
+	}
+	if t.Transfer != nil {
+		if (t.Transfer.From == "") == (t.Transfer.To == "") {
+			return fmt.Errorf("trigger %s: transfer requires exactly one of from or to", t.Name)
+		}
+		if t.Transfer.Amount <= 0 {
+			return fmt.Errorf("trigger %s: transfer amount must be greater than zero", t.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateTriggers checks the common and scenario triggers. Names they refer
+// to are resolved per scenario when the forecast runs.
+func (conf *Configuration) ValidateTriggers() error {
+	for _, trigger := range conf.Common.Triggers {
+		if err := trigger.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, scenario := range conf.Scenarios {
+		for _, trigger := range scenario.Triggers {
+			if err := trigger.Validate(); err != nil {
+				return fmt.Errorf("scenario %s: %w", scenario.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import "testing"
+
+func TestTriggerValidate(t *testing.T) {
+	threshold := 1000.0
+	transfer := &TriggerTransfer{To: "Brokerage", Amount: 500}
+
+	tests := []struct {
+		name    string
+		trigger Trigger
+		wantErr bool
+	}{
+		{"liquid transfer", Trigger{Name: "Invest", When: TriggerCondition{Balance: "liquid", Above: &threshold}, Transfer: transfer}, false},
+		{"loan stop event", Trigger{Name: "Stop", When: TriggerCondition{Balance: "loan:Mortgage", Below: &threshold}, StopEvent: "Extra payment"}, false},
+		{"investment balance", Trigger{Name: "Sell", When: TriggerCondition{Balance: "Investment:Brokerage", Above: &threshold}, Transfer: &TriggerTransfer{From: "Brokerage", Amount: 100}}, false},
+		{"missing name", Trigger{When: TriggerCondition{Balance: "liquid", Above: &threshold}, Transfer: transfer}, true},
+		{"unknown balance", Trigger{Name: "Bad", When: TriggerCondition{Balance: "cash", Above: &threshold}, Transfer: transfer}, true},
+		{"unnamed investment", Trigger{Name: "Bad", When: TriggerCondition{Balance: "investment", Above: &threshold}, Transfer: transfer}, true},
+		{"named total", Trigger{Name: "Bad", When: TriggerCondition{Balance: "total:Brokerage", Above: &threshold}, Transfer: transfer}, true},
+		{"no threshold", Trigger{Name: "Bad", When: TriggerCondition{Balance: "liquid"}, Transfer: transfer}, true},
+		{"both thresholds", Trigger{Name: "Bad", When: TriggerCondition{Balance: "liquid", Above: &threshold, Below: &threshold}, Transfer: transfer}, true},
+		{"no action", Trigger{Name: "Bad", When: TriggerCondition{Balance: "liquid", Above: &threshold}}, true},
+		{"transfer both ways", Trigger{Name: "Bad", When: TriggerCondition{Balance: "liquid", Above: &threshold}, Transfer: &TriggerTransfer{From: "A", To: "B", Amount: 1}}, true},
+		{"zero transfer", Trigger{Name: "Bad", When: TriggerCondition{Balance: "liquid", Above: &threshold}, Transfer: &TriggerTransfer{To: "A"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.trigger.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTriggerConditionMet(t *testing.T) {
+	threshold := 1000.0
+	above := TriggerCondition{Balance: "liquid", Above: &threshold}
+	below := TriggerCondition{Balance: "liquid", Below: &threshold}
+
+	if above.Met(1000) || !above.Met(1000.01) {
+		t.Error("above should require a value strictly greater than the threshold")
+	}
+	if below.Met(1000) || !below.Met(999.99) {
+		t.Error("below should require a value strictly less than the threshold")
+	}
+}
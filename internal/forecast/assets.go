@@ -0,0 +1,74 @@
+package forecast
+
+import (
+	"fmt"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+)
+
+// heldAsset tracks one asset through a scenario until it is sold.
+type heldAsset struct {
+	config.Asset
+	scope string
+	sold  bool
+}
+
+// buildAssets lists the scenario's assets followed by the common ones.
+func buildAssets(scenario, common []config.Asset) []*heldAsset {
+	var assets []*heldAsset
+	for _, asset := range scenario {
+		assets = append(assets, &heldAsset{Asset: asset, scope: "scenario"})
+	}
+	for _, asset := range common {
+		assets = append(assets, &heldAsset{Asset: asset, scope: "common"})
+	}
+	return assets
+}
+
+// value returns the asset's value in date, or zero before it is bought or
+// once it has been sold.
+func (a *heldAsset) value(date string) float64 {
+	if a.sold {
+		return 0
+	}
+	return a.ValueAt(date)
+}
+
+// totalAssetValue sums the value of the assets held in date.
+func totalAssetValue(assets []*heldAsset, date string) float64 {
+	total := 0.0
+	for _, asset := range assets {
+		total += asset.value(date)
+	}
+	return total
+}
+
+// linkedAsset returns the asset a loan is linked to. Scenario loans prefer a
+// scenario asset over a common one; common loans only see common assets.
+func linkedAsset(assets []*heldAsset, loan config.Loan, scope string) *heldAsset {
+	if loan.Asset == "" {
+		return nil
+	}
+	for _, wantScope := range []string{scope, "common"} {
+		for _, asset := range assets {
+			if asset.scope == wantScope && asset.Name == loan.Asset {
+				return asset
+			}
+		}
+	}
+	return nil
+}
+
+// sellDueAssets sells the assets whose saleDate is date, crediting the value
+// less sale costs to each asset's cash account.
+func sellDueAssets(assets []*heldAsset, date string, accountFlows map[string]float64, notes map[string][]string) {
+	for _, asset := range assets {
+		if asset.sold || asset.SaleDate != date {
+			continue
+		}
+		value := asset.value(date)
+		asset.sold = true
+		accountFlows[asset.Account] += value - asset.SaleCosts
+		notes[date] = append(notes[date], fmt.Sprintf("%s asset %s: sold for %.2f with %.2f sale costs", asset.scope, asset.Name, value, asset.SaleCosts))
+	}
+}
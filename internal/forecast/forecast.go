@@ -12,23 +12,95 @@ import (
 	"github.com/iwvelando/finance-forecast/pkg/adapters"
 	"github.com/iwvelando/finance-forecast/pkg/datetime"
 	"github.com/iwvelando/finance-forecast/pkg/finance"
+	"github.com/iwvelando/finance-forecast/pkg/loans"
+	"github.com/iwvelando/finance-forecast/pkg/mathutil"
 	"github.com/iwvelando/finance-forecast/pkg/optimization"
+	"github.com/iwvelando/finance-forecast/pkg/tax"
 	"go.uber.org/zap"
 )
 
 // Forecast holds all information related to a specific forecast.
 type Forecast struct {
-	Name    string
-	Data    map[string]float64
-	Liquid  map[string]float64
+	Name   string
+	Data   map[string]float64
+	Liquid map[string]float64
+	// RealData and RealLiquid hold the same series deflated to start-date
+	// dollars; they are nil when no inflation rate is configured.
+	RealData   map[string]float64
+	RealLiquid map[string]float64
+	// Liabilities holds the principal outstanding on the scenario's loans and
+	// NetWorth the total less those liabilities. Both are nil when the
+	// scenario has no loans or revolving debts; RealNetWorth is also nil
+	// without inflation.
+	Liabilities  map[string]float64
+	NetWorth     map[string]float64
+	RealNetWorth map[string]float64
+	// Accounts holds per-account cash balances when cashAccounts are configured.
+	Accounts []AccountSeries
+	// Ages holds each household member's age in whole years per date.
+	Ages    []MemberAges
 	Notes   map[string][]string
 	Metrics ForecastMetrics
 }
 
+// AccountSeries holds the month-end balances of one cash account.
+type AccountSeries struct {
+	Name     string
+	Balances map[string]float64
+}
+
+// MemberAges holds one household member's age on each forecast date.
+type MemberAges struct {
+	Name string
+	Ages map[string]int
+}
+
 // ForecastMetrics aggregates supplementary scenario insights.
 type ForecastMetrics struct {
 	EmergencyFund *EmergencyFundRecommendation
 	Optimizations []optimization.Summary
+	Taxes         *TaxSummary
+	DebtPayoff    []DebtPayoffSummary
+}
+
+// DebtPayoffSummary reports a debtPayoff plan covering the scenario or common
+// loans, along with how each strategy would do with the same budget.
+type DebtPayoffSummary struct {
+	Scope      string
+	Budget     float64
+	Report     loans.PayoffReport
+	Comparison []loans.PayoffReport
+}
+
+// TaxSummary totals the income tax paid over the forecast when a taxes block
+// is configured.
+type TaxSummary struct {
+	Federal float64
+	State   float64
+	Years   []TaxYear
+}
+
+// TaxYear is the income tax paid during one calendar year.
+type TaxYear struct {
+	Year    int
+	Federal float64
+	State   float64
+}
+
+// Total returns the combined federal and state tax paid.
+func (t *TaxSummary) Total() float64 {
+	return t.Federal + t.State
+}
+
+func (t *TaxSummary) add(year int, paid tax.Liability) {
+	t.Federal += paid.Federal
+	t.State += paid.State
+	if n := len(t.Years); n == 0 || t.Years[n-1].Year != year {
+		t.Years = append(t.Years, TaxYear{Year: year})
+	}
+	last := &t.Years[len(t.Years)-1]
+	last.Federal += paid.Federal
+	last.State += paid.State
 }
 
 // EmergencyFundRecommendation summarizes the emergency fund target for a scenario.
@@ -64,6 +136,39 @@ func GetForecastWithFixedTime(logger *zap.Logger, conf config.Configuration, fix
 		logger = zap.NewNop()
 	}
 
+	if err := conf.ValidateCashAccounts(); err != nil {
+		return nil, err
+	}
+	if err := conf.ValidateShortfallPolicy(); err != nil {
+		return nil, err
+	}
+	if err := conf.ValidateTaxes(); err != nil {
+		return nil, err
+	}
+	if err := conf.ValidateAccountTypes(); err != nil {
+		return nil, err
+	}
+	if err := conf.Household.Validate(); err != nil {
+		return nil, err
+	}
+	if err := conf.ValidateTriggers(); err != nil {
+		return nil, err
+	}
+	if err := conf.ValidateAssets(); err != nil {
+		return nil, err
+	}
+	if err := conf.ValidateRevolvingDebts(); err != nil {
+		return nil, err
+	}
+	var birthDate time.Time
+	if rmdBirthDate := conf.RMDBirthDate(); rmdBirthDate != "" {
+		var err error
+		birthDate, err = time.Parse(config.DateTimeLayout, rmdBirthDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var results []Forecast
 	startDate := fixedTime.Format(config.DateTimeLayout)
 	emergencyFundMonths := conf.EmergencyFundMonths()
@@ -81,26 +186,113 @@ func GetForecastWithFixedTime(logger *zap.Logger, conf config.Configuration, fix
 		result.Data = make(map[string]float64)
 		result.Liquid = make(map[string]float64)
 		result.Notes = make(map[string][]string)
+		if conf.Inflation != 0 {
+			result.RealData = make(map[string]float64)
+			result.RealLiquid = make(map[string]float64)
+		}
+		for _, entry := range []struct {
+			scope string
+			plan  *config.DebtPayoff
+		}{{"scenario", scenario.DebtPayoff}, {"common", conf.Common.DebtPayoff}} {
+			if entry.plan == nil || entry.plan.Report == nil {
+				continue
+			}
+			result.Metrics.DebtPayoff = append(result.Metrics.DebtPayoff, DebtPayoffSummary{
+				Scope:      entry.scope,
+				Budget:     entry.plan.Budget,
+				Report:     *entry.plan.Report,
+				Comparison: entry.plan.Comparison,
+			})
+		}
 		previousDate := startDate
 		// Create a forecast engine to process monthly changes
 		forecastEngine := finance.NewForecastEngine(logger)
 
-		scenarioEvents := adapters.EventsToFinanceEvents(scenario.Events)
-		commonEvents := adapters.EventsToFinanceEvents(conf.Common.Events)
-		scenarioLoans := adapters.LoansToFinanceLoans(scenario.Loans)
-		commonLoans := adapters.LoansToFinanceLoans(conf.Common.Loans)
-		scenarioInvestments := adapters.InvestmentsToFinanceInvestments(scenario.Investments)
-		commonInvestments := adapters.InvestmentsToFinanceInvestments(conf.Common.Investments)
+		schedule, err := newEventSchedule(conf, scenario, fixedTime)
+		if err != nil {
+			return results, err
+		}
+		scenarioEvents := groupEventsByAccount(schedule.scenarioEvents)
+		commonEvents := groupEventsByAccount(schedule.commonEvents)
+		scenarioLoans := groupLoansByAccount(scenario.Loans)
+		commonLoans := groupLoansByAccount(conf.Common.Loans)
+		scenarioInvestments := adapters.InvestmentsToFinanceInvestments(schedule.scenarioInvestments)
+		commonInvestments := adapters.InvestmentsToFinanceInvestments(schedule.commonInvestments)
 
 		scenarioInvestmentStates := initializeInvestmentStates(scenarioInvestments)
 		commonInvestmentStates := initializeInvestmentStates(commonInvestments)
+		shortfallOrder := buildShortfallSources(conf.ShortfallPolicy, scenarioInvestments, scenarioInvestmentStates, commonInvestments, commonInvestmentStates)
+		findInvestment := func(name string) (investmentSource, bool) {
+			return findInvestmentSource(name, scenarioInvestments, scenarioInvestmentStates, commonInvestments, commonInvestmentStates)
+		}
+		triggers, err := buildTriggers(scenario, conf.Common.Triggers, findInvestment, schedule)
+		if err != nil {
+			return results, err
+		}
+		var distributionAccounts []*rmdAccount
+		if !birthDate.IsZero() {
+			distributionAccounts = buildRMDAccounts(scenarioInvestments, scenarioInvestmentStates, commonInvestments, commonInvestmentStates)
+		}
+
+		ledger, err := finance.NewCashLedger(conf.Common.StartingValue, adapters.CashAccountsToFinanceConfigs(conf.Common.CashAccounts))
+		if err != nil {
+			return results, err
+		}
+		if conf.Household != nil {
+			for _, member := range conf.Household.Members {
+				result.Ages = append(result.Ages, MemberAges{Name: member.Name, Ages: make(map[string]int)})
+			}
+		}
+		if len(conf.Common.CashAccounts) > 0 {
+			for _, name := range ledger.Names() {
+				result.Accounts = append(result.Accounts, AccountSeries{Name: name, Balances: make(map[string]float64)})
+			}
+		}
+		var taxEngine *tax.Engine
+		var taxableEvents []finance.EventWithDates
+		if conf.Taxes != nil {
+			taxEngine, err = conf.Taxes.NewEngine()
+			if err != nil {
+				return results, err
+			}
+			taxableEvents = adapters.EventsToFinanceEvents(filterTaxableEvents(schedule.scenarioEvents, schedule.commonEvents))
+			result.Metrics.Taxes = &TaxSummary{}
+		}
+
+		// Rescheduled and stopped events, and the percentage-based
+		// contributions that follow them, take effect once regrouped. States
+		// are keyed by name, so they carry over to the rebuilt investments.
+		regroupEvents := func() {
+			scenarioEvents = groupEventsByAccount(schedule.scenarioEvents)
+			commonEvents = groupEventsByAccount(schedule.commonEvents)
+			scenarioInvestments = adapters.InvestmentsToFinanceInvestments(schedule.scenarioInvestments)
+			commonInvestments = adapters.InvestmentsToFinanceInvestments(schedule.commonInvestments)
+			if taxEngine != nil {
+				taxableEvents = adapters.EventsToFinanceEvents(filterTaxableEvents(schedule.scenarioEvents, schedule.commonEvents))
+			}
+		}
 
-		cashBalance := conf.Common.StartingValue
+		cashBalance := ledger.Total()
 		scenarioInvestmentTotal := sumInvestmentStartingValues(scenarioInvestments)
 		commonInvestmentTotal := sumInvestmentStartingValues(commonInvestments)
 		initialInvestmentBalance := scenarioInvestmentTotal + commonInvestmentTotal
+		assets := buildAssets(scenario.Assets, conf.Common.Assets)
+		assetTotal := totalAssetValue(assets, startDate)
+		revolvingDebts := buildRevolvingDebts(scenario.RevolvingDebts, conf.Common.RevolvingDebts)
+		debts := trackLiabilities(startDate, revolvingDebts, conf.Scenarios[i].Loans, conf.Common.Loans)
+		if !debts.empty() {
+			result.Liabilities = make(map[string]float64)
+			result.NetWorth = make(map[string]float64)
+			if conf.Inflation != 0 {
+				result.RealNetWorth = make(map[string]float64)
+			}
+		}
 		result.Liquid[startDate] = cashBalance
-		result.Data[startDate] = cashBalance + initialInvestmentBalance
+		result.Data[startDate] = cashBalance + initialInvestmentBalance + assetTotal
+		result.recordLiabilities(startDate, debts.total())
+		result.recordReal(startDate, conf.Inflation, 0)
+		result.recordAccounts(startDate, ledger)
+		result.recordAges(fixedTime, conf.Household)
 
 		monthsObserved := 0
 		totalMonthlyExpenses := 0.0
@@ -109,19 +301,42 @@ func GetForecastWithFixedTime(logger *zap.Logger, conf config.Configuration, fix
 			if err != nil {
 				return results, err
 			}
+			dateT, err := time.Parse(config.DateTimeLayout, date)
+			if err != nil {
+				return results, err
+			}
+
+			// Reschedule anchored events whose milestones have moved.
+			rescheduled, anchorErr := schedule.resolve()
+			if anchorErr != nil {
+				return results, anchorErr
+			}
+			if rescheduled {
+				regroupEvents()
+			}
+
+			// Cash accounts earn interest on the prior month-end balance.
+			interest := ledger.AccrueInterest()
+			accountFlows := make(map[string]float64)
 
 			// Process scenario events
-			scenarioChanges, scenarioErr := forecastEngine.ProcessMonthlyChanges(date, scenarioEvents, nil, config.DateTimeLayout)
+			scenarioChanges, scenarioErr := processAccountEvents(forecastEngine, date, scenarioEvents, accountFlows)
 			if scenarioErr != nil {
 				return results, scenarioErr
 			}
 
 			// Process common events
-			commonChanges, commonErr := forecastEngine.ProcessMonthlyChanges(date, commonEvents, nil, config.DateTimeLayout)
+			commonChanges, commonErr := processAccountEvents(forecastEngine, date, commonEvents, accountFlows)
 			if commonErr != nil {
 				return results, commonErr
 			}
 
+			if dateT.Month() == time.January || monthsObserved == 0 {
+				for _, account := range distributionAccounts {
+					account.startYear()
+				}
+			}
+
 			// Process investments
 			scenarioInvestmentChange, scenarioInvestmentDetails, scenarioInvestErr := forecastEngine.ProcessInvestments(date, scenarioInvestments, config.DateTimeLayout, scenarioInvestmentStates)
 			if scenarioInvestErr != nil {
@@ -133,6 +348,37 @@ func GetForecastWithFixedTime(logger *zap.Logger, conf config.Configuration, fix
 				return results, commonInvestErr
 			}
 
+			// Traditional accounts pay out any required minimum distribution
+			// not already covered by the year's withdrawals in December.
+			for _, change := range scenarioInvestmentDetails {
+				countWithdrawal(distributionAccounts, "scenario", change)
+			}
+			for _, change := range commonInvestmentDetails {
+				countWithdrawal(distributionAccounts, "common", change)
+			}
+			var distributions []finance.InvestmentChange
+			for _, account := range distributionAccounts {
+				if dateT.Month() != time.December {
+					continue
+				}
+				age := dateT.Year() - birthDate.Year()
+				if age < conf.Common.RMDStartAgeOrDefault() {
+					continue
+				}
+				due := finance.RequiredMinimumDistribution(account.yearStartValue, age) - account.withdrawn
+				change := forecastEngine.DrawDistribution(account.investment, account.state, due)
+				if change.Withdrawal == 0 {
+					continue
+				}
+				if account.scope == "scenario" {
+					scenarioInvestmentChange -= change.Withdrawal
+				} else {
+					commonInvestmentChange -= change.Withdrawal
+				}
+				distributions = append(distributions, change)
+				result.Notes[date] = append(result.Notes[date], withdrawalNote(account.scope, "required minimum distribution", change))
+			}
+
 			scenarioContributionOffset := sumIncomeReducingContributions(scenarioInvestmentDetails)
 			commonContributionOffset := sumIncomeReducingContributions(commonInvestmentDetails)
 			scenarioWithdrawalCash := sumWithdrawals(scenarioInvestmentDetails)
@@ -141,61 +387,177 @@ func GetForecastWithFixedTime(logger *zap.Logger, conf config.Configuration, fix
 			addInvestmentNotes(result.Notes, date, "scenario", scenarioInvestmentDetails)
 			addInvestmentNotes(result.Notes, date, "common", commonInvestmentDetails)
 
-			// Check for early payoff thresholds
-			projectedBalance := result.Data[previousDate] + scenarioChanges + commonChanges - scenarioContributionOffset - commonContributionOffset + scenarioInvestmentChange + commonInvestmentChange
+			// Check for early payoff thresholds against cash and investments.
+			projectedBalance := result.Data[previousDate] - assetTotal + interest + scenarioChanges + commonChanges - scenarioContributionOffset - commonContributionOffset + scenarioInvestmentChange + commonInvestmentChange
 
-			for j := range conf.Scenarios[i].Loans {
-				note, payoffErr := conf.Scenarios[i].Loans[j].CheckEarlyPayoffThreshold(date, conf.Common.DeathDate, projectedBalance)
-				if payoffErr != nil {
-					return results, payoffErr
-				}
-				if note != "" {
-					result.Notes[date] = append(result.Notes[date], note)
-				}
+			if payoffErr := checkEarlyPayoffs(conf.Scenarios[i].Loans, "scenario", assets, date, conf.Common.DeathDate, projectedBalance, result.Notes); payoffErr != nil {
+				return results, payoffErr
 			}
-
-			for j := range conf.Common.Loans {
-				note, payoffErr := conf.Common.Loans[j].CheckEarlyPayoffThreshold(date, conf.Common.DeathDate, projectedBalance)
-				if payoffErr != nil {
-					return results, payoffErr
-				}
-				if note != "" {
-					result.Notes[date] = append(result.Notes[date], note)
-				}
+			if payoffErr := checkEarlyPayoffs(conf.Common.Loans, "common", assets, date, conf.Common.DeathDate, projectedBalance, result.Notes); payoffErr != nil {
+				return results, payoffErr
 			}
 
 			// Process loan payments
-			scenarioLoansChanges, scenarioLoansErr := forecastEngine.ProcessMonthlyChanges(date, nil, scenarioLoans, config.DateTimeLayout)
+			scenarioLoansChanges, scenarioLoansErr := processAccountLoans(forecastEngine, date, scenarioLoans, accountFlows)
 			if scenarioLoansErr != nil {
 				return results, scenarioLoansErr
 			}
 
-			commonLoansChanges, commonLoansErr := forecastEngine.ProcessMonthlyChanges(date, nil, commonLoans, config.DateTimeLayout)
+			commonLoansChanges, commonLoansErr := processAccountLoans(forecastEngine, date, commonLoans, accountFlows)
 			if commonLoansErr != nil {
 				return results, commonLoansErr
 			}
+			addLoanNotes(result.Notes, date, "scenario", conf.Scenarios[i].Loans)
+			addLoanNotes(result.Notes, date, "common", conf.Common.Loans)
 
-			cashDelta := scenarioChanges + commonChanges + scenarioLoansChanges + commonLoansChanges
-			cashDelta -= scenarioContributionOffset + commonContributionOffset
-			cashDelta += scenarioWithdrawalCash + commonWithdrawalCash
-			cashBalance += cashDelta
+			// Assets reaching their saleDate are sold into cash.
+			sellDueAssets(assets, date, accountFlows, result.Notes)
+
+			// Investment flows settle in the default cash account.
+			accountFlows[""] += scenarioWithdrawalCash + commonWithdrawalCash + sumWithdrawals(distributions) - scenarioContributionOffset - commonContributionOffset
+			for account, amount := range accountFlows {
+				if depositErr := ledger.Deposit(account, amount); depositErr != nil {
+					return results, depositErr
+				}
+			}
+
+			// Revolving debts are paid from the cash left after the month's flows.
+			revolvingChanges, revolvingErr := processRevolvingDebts(forecastEngine, ledger, date, revolvingDebts, result.Notes)
+			if revolvingErr != nil {
+				return results, revolvingErr
+			}
+
+			// Income tax on gross income, cash interest, and taxable withdrawals,
+			// less pre-tax contributions paid from cash.
+			taxesPaid := 0.0
+			if taxEngine != nil {
+				taxableIncome, taxErr := forecastEngine.ProcessMonthlyChanges(date, taxableEvents, nil, config.DateTimeLayout)
+				if taxErr != nil {
+					return results, taxErr
+				}
+				taxableIncome += interest
+				for _, changes := range [][]finance.InvestmentChange{scenarioInvestmentDetails, commonInvestmentDetails, distributions} {
+					taxableIncome += sumTaxableWithdrawals(changes) - sumPreTaxContributions(changes)
+				}
+				due := taxEngine.Month(dateT, taxableIncome)
+				if due.Total() != 0 {
+					if depositErr := ledger.Deposit("", -due.Total()); depositErr != nil {
+						return results, depositErr
+					}
+					result.Metrics.Taxes.add(dateT.Year(), due)
+					result.Notes[date] = append(result.Notes[date], fmt.Sprintf("taxes %+.2f (federal %.2f, state %.2f)", -due.Total(), due.Federal, due.State))
+				}
+				taxesPaid = due.Total()
+			}
+
+			ledger.Sweep()
+			cashBalance = ledger.Total()
+
+			// Sell investments in policy order rather than letting cash go negative.
+			coverShortfall := func() error {
+				for _, source := range shortfallOrder {
+					if !mathutil.IsNegative(cashBalance) {
+						break
+					}
+					change := forecastEngine.DrawForShortfall(source.investment, source.state, -cashBalance)
+					if change.Withdrawal == 0 {
+						continue
+					}
+					if depositErr := ledger.Deposit("", change.Withdrawal-change.WithdrawalTax); depositErr != nil {
+						return depositErr
+					}
+					cashBalance = ledger.Total()
+					if taxEngine != nil {
+						taxEngine.AddIncome(change.TaxableWithdrawal)
+					}
+					countWithdrawal(distributionAccounts, source.scope, change)
+					if source.scope == "scenario" {
+						scenarioInvestmentTotal -= change.Withdrawal
+					} else {
+						commonInvestmentTotal -= change.Withdrawal
+					}
+					result.Notes[date] = append(result.Notes[date], withdrawalNote(source.scope, "shortfall withdrawal", change))
+				}
+				return nil
+			}
+			if shortfallErr := coverShortfall(); shortfallErr != nil {
+				return results, shortfallErr
+			}
+
+			scenarioInvestmentTotal += scenarioInvestmentChange
+			commonInvestmentTotal += commonInvestmentChange
+			assetTotal = totalAssetValue(assets, date)
+
+			// Triggers react to the month-end balances.
+			for _, trigger := range triggers {
+				value := trigger.balance(date, cashBalance, cashBalance+scenarioInvestmentTotal+commonInvestmentTotal+assetTotal)
+				if !trigger.ready(value) {
+					continue
+				}
+				effect, triggerErr := trigger.fire(forecastEngine, ledger, taxEngine, schedule, dateT, value)
+				if triggerErr != nil {
+					return results, triggerErr
+				}
+				if effect.scope == "scenario" {
+					scenarioInvestmentTotal += effect.investmentDelta
+				} else {
+					commonInvestmentTotal += effect.investmentDelta
+				}
+				countWithdrawal(distributionAccounts, effect.scope, effect.sale)
+				if effect.stopped {
+					regroupEvents()
+				}
+				cashBalance = ledger.Total()
+				result.Notes[date] = append(result.Notes[date], effect.note)
+			}
+
+			// No later month settles the final one, so its tax is paid now,
+			// including tax on its shortfall draws and trigger sales. Paying it
+			// can force another shortfall draw, which is settled in turn.
+			if taxEngine != nil && date == conf.Common.DeathDate {
+				var settled tax.Liability
+				for {
+					final := taxEngine.Settle()
+					if mathutil.IsZero(final.Total()) {
+						break
+					}
+					if depositErr := ledger.Deposit("", -final.Total()); depositErr != nil {
+						return results, depositErr
+					}
+					settled = tax.Liability{Federal: settled.Federal + final.Federal, State: settled.State + final.State}
+					ledger.Sweep()
+					cashBalance = ledger.Total()
+					if shortfallErr := coverShortfall(); shortfallErr != nil {
+						return results, shortfallErr
+					}
+				}
+				if settled.Total() != 0 {
+					result.Metrics.Taxes.add(dateT.Year(), settled)
+					result.Notes[date] = append(result.Notes[date], fmt.Sprintf("final tax settlement %+.2f (federal %.2f, state %.2f)", -settled.Total(), settled.Federal, settled.State))
+					taxesPaid += settled.Total()
+				}
+			}
 
 			monthlyExpenses := calculateMonthlyExpenses(MonthlyExpenseInputs{
 				ScenarioEvents:     scenarioChanges,
 				CommonEvents:       commonChanges,
 				ScenarioLoans:      scenarioLoansChanges,
 				CommonLoans:        commonLoansChanges,
+				RevolvingDebts:     revolvingChanges,
 				OtherContributions: []float64{scenarioContributionOffset, commonContributionOffset},
+				Taxes:              taxesPaid,
 			})
 			totalMonthlyExpenses += monthlyExpenses
 			monthsObserved++
 
-			scenarioInvestmentTotal += scenarioInvestmentChange
-			commonInvestmentTotal += commonInvestmentChange
 			totalInvestments := scenarioInvestmentTotal + commonInvestmentTotal
 
 			result.Liquid[date] = cashBalance
-			result.Data[date] = cashBalance + totalInvestments
+			result.Data[date] = cashBalance + totalInvestments + assetTotal
+			result.recordLiabilities(date, debts.advance(date))
+			result.recordReal(date, conf.Inflation, monthsObserved)
+			result.recordAccounts(date, ledger)
+			result.recordAges(dateT, conf.Household)
 			if date == conf.Common.DeathDate {
 				break
 			}
@@ -238,6 +600,249 @@ func GetForecastWithFixedTime(logger *zap.Logger, conf config.Configuration, fix
 	return results, nil
 }
 
+// checkEarlyPayoffs applies early payoff thresholds to the loans. Loans that
+// sell a linked asset sell it at its value that month, and the asset leaves
+// the net worth once the loan sells it.
+func checkEarlyPayoffs(loans []config.Loan, scope string, assets []*heldAsset, date, deathDate string, balance float64, notes map[string][]string) error {
+	for j := range loans {
+		loan := &loans[j]
+		asset := linkedAsset(assets, *loan, scope)
+		if asset != nil && loan.SellProperty && loan.EarlyPayoffThreshold > 0 {
+			loan.SellPrice = asset.value(date)
+		}
+		note, err := loan.CheckEarlyPayoffThreshold(date, deathDate, balance)
+		if err != nil {
+			return err
+		}
+		if note != "" {
+			notes[date] = append(notes[date], note)
+		}
+		if asset != nil && loan.SellProperty && (note != "" || loan.EarlyPayoffDate == date) {
+			asset.sold = true
+		}
+	}
+	return nil
+}
+
+// addLoanNotes records the notes the loans' schedules report for date, such
+// as refinances.
+func addLoanNotes(notes map[string][]string, date, scope string, loans []config.Loan) {
+	for _, loan := range loans {
+		for _, note := range loan.Notes[date] {
+			notes[date] = append(notes[date], fmt.Sprintf("%s loan %s: %s", scope, loan.Name, note))
+		}
+	}
+}
+
+// recordLiabilities stores the loan balances and the resulting net worth for
+// date when the scenario has loans.
+func (f *Forecast) recordLiabilities(date string, owed float64) {
+	if f.NetWorth == nil {
+		return
+	}
+	f.Liabilities[date] = owed
+	f.NetWorth[date] = f.Data[date] - owed
+}
+
+// recordReal stores the deflated liquid and total values for date when real
+// series are being tracked.
+func (f *Forecast) recordReal(date string, inflation float64, months int) {
+	if f.RealData == nil {
+		return
+	}
+	f.RealLiquid[date] = mathutil.Deflate(f.Liquid[date], inflation, months)
+	f.RealData[date] = mathutil.Deflate(f.Data[date], inflation, months)
+	if f.RealNetWorth != nil {
+		f.RealNetWorth[date] = mathutil.Deflate(f.NetWorth[date], inflation, months)
+	}
+}
+
+// recordAccounts stores each configured cash account's balance for date.
+func (f *Forecast) recordAccounts(date string, ledger *finance.CashLedger) {
+	for i := range f.Accounts {
+		f.Accounts[i].Balances[date] = ledger.Balance(f.Accounts[i].Name)
+	}
+}
+
+// recordAges stores each household member's age on date.
+func (f *Forecast) recordAges(date time.Time, household *config.Household) {
+	for i := range f.Ages {
+		f.Ages[i].Ages[date.Format(config.DateTimeLayout)] = household.Members[i].AgeAt(date)
+	}
+}
+
+// accountEvents groups events by the cash account they settle in.
+type accountEvents struct {
+	account string
+	events  []finance.EventWithDates
+}
+
+// accountLoans groups loans by the cash account their payments come from.
+type accountLoans struct {
+	account string
+	loans   []finance.LoanWithSchedule
+}
+
+func groupEventsByAccount(events []config.Event) []accountEvents {
+	var groups []accountEvents
+	index := make(map[string]int)
+	for _, event := range events {
+		i, ok := index[event.Account]
+		if !ok {
+			i = len(groups)
+			index[event.Account] = i
+			groups = append(groups, accountEvents{account: event.Account})
+		}
+		groups[i].events = append(groups[i].events, adapters.ConfigEventAdapter{Event: event})
+	}
+	return groups
+}
+
+func groupLoansByAccount(loans []config.Loan) []accountLoans {
+	var groups []accountLoans
+	index := make(map[string]int)
+	for _, loan := range loans {
+		i, ok := index[loan.Account]
+		if !ok {
+			i = len(groups)
+			index[loan.Account] = i
+			groups = append(groups, accountLoans{account: loan.Account})
+		}
+		groups[i].loans = append(groups[i].loans, adapters.ConfigLoanAdapter{Loan: loan})
+	}
+	return groups
+}
+
+// processAccountEvents totals the events active on date, accumulating each
+// group's amount into flows by account.
+func processAccountEvents(engine *finance.ForecastEngine, date string, groups []accountEvents, flows map[string]float64) (float64, error) {
+	total := 0.0
+	for _, group := range groups {
+		amount, err := engine.ProcessMonthlyChanges(date, group.events, nil, config.DateTimeLayout)
+		if err != nil {
+			return 0, err
+		}
+		flows[group.account] += amount
+		total += amount
+	}
+	return total, nil
+}
+
+// processAccountLoans totals the loan payments due on date, accumulating each
+// group's amount into flows by account.
+func processAccountLoans(engine *finance.ForecastEngine, date string, groups []accountLoans, flows map[string]float64) (float64, error) {
+	total := 0.0
+	for _, group := range groups {
+		amount, err := engine.ProcessMonthlyChanges(date, nil, group.loans, config.DateTimeLayout)
+		if err != nil {
+			return 0, err
+		}
+		flows[group.account] += amount
+		total += amount
+	}
+	return total, nil
+}
+
+// investmentSource is one of the scenario's investments along with the scope
+// it belongs to and its running state.
+type investmentSource struct {
+	scope      string
+	investment finance.Investment
+	state      *finance.InvestmentState
+}
+
+// findInvestmentSource returns the named investment, preferring a scenario
+// investment over a common one of the same name.
+func findInvestmentSource(name string, scenarioInvestments []finance.Investment, scenarioStates map[string]*finance.InvestmentState, commonInvestments []finance.Investment, commonStates map[string]*finance.InvestmentState) (investmentSource, bool) {
+	find := func(investments []finance.Investment) finance.Investment {
+		for _, inv := range investments {
+			if inv != nil && inv.GetName() == name {
+				return inv
+			}
+		}
+		return nil
+	}
+
+	if inv := find(scenarioInvestments); inv != nil {
+		return investmentSource{scope: "scenario", investment: inv, state: scenarioStates[name]}, true
+	}
+	if inv := find(commonInvestments); inv != nil {
+		return investmentSource{scope: "common", investment: inv, state: commonStates[name]}, true
+	}
+	return investmentSource{}, false
+}
+
+// buildShortfallSources resolves the policy order against the scenario's
+// investments, preferring a scenario investment over a common one of the same
+// name. Names missing from this scenario are skipped.
+func buildShortfallSources(policy *config.ShortfallPolicy, scenarioInvestments []finance.Investment, scenarioStates map[string]*finance.InvestmentState, commonInvestments []finance.Investment, commonStates map[string]*finance.InvestmentState) []investmentSource {
+	if policy == nil {
+		return nil
+	}
+
+	var sources []investmentSource
+	for _, name := range policy.Order {
+		if source, ok := findInvestmentSource(name, scenarioInvestments, scenarioStates, commonInvestments, commonStates); ok {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// withdrawalNote describes a withdrawal made outside the investment's schedule.
+func withdrawalNote(scope, label string, change finance.InvestmentChange) string {
+	note := fmt.Sprintf("%s %s: %s %+0.2f (basis %+.2f, growth %+.2f)",
+		scope, change.Name, label, change.Withdrawal, change.WithdrawalFromBasis, change.WithdrawalFromGrowth)
+	if change.WithdrawalTax != 0 {
+		note += fmt.Sprintf(", withdrawal tax %.2f", change.WithdrawalTax)
+	}
+	return note
+}
+
+// rmdAccount tracks a traditional account's year-start balance and the
+// withdrawals taken from it so far this year.
+type rmdAccount struct {
+	scope          string
+	investment     finance.Investment
+	state          *finance.InvestmentState
+	yearStartValue float64
+	withdrawn      float64
+}
+
+// buildRMDAccounts returns the traditional accounts subject to required
+// minimum distributions.
+func buildRMDAccounts(scenarioInvestments []finance.Investment, scenarioStates map[string]*finance.InvestmentState, commonInvestments []finance.Investment, commonStates map[string]*finance.InvestmentState) []*rmdAccount {
+	var accounts []*rmdAccount
+	add := func(scope string, investments []finance.Investment, states map[string]*finance.InvestmentState) {
+		for _, inv := range investments {
+			if inv == nil || finance.CanonicalAccountType(inv.GetAccountType()) != finance.AccountTypeTraditional {
+				continue
+			}
+			accounts = append(accounts, &rmdAccount{scope: scope, investment: inv, state: states[inv.GetName()]})
+		}
+	}
+	add("scenario", scenarioInvestments, scenarioStates)
+	add("common", commonInvestments, commonStates)
+	return accounts
+}
+
+// startYear captures the prior year-end balance the year's RMD is based on.
+func (a *rmdAccount) startYear() {
+	a.yearStartValue = a.state.CurrentValue
+	a.withdrawn = 0
+}
+
+// countWithdrawal counts a withdrawal toward the year's distribution of the
+// scope's account it came from, whether scheduled, drawn for a shortfall or
+// sold by a trigger.
+func countWithdrawal(accounts []*rmdAccount, scope string, change finance.InvestmentChange) {
+	for _, account := range accounts {
+		if account.scope == scope && account.investment.GetName() == change.Name {
+			account.withdrawn += change.Withdrawal
+		}
+	}
+}
+
 func initializeInvestmentStates(investments []finance.Investment) map[string]*finance.InvestmentState {
 	states := make(map[string]*finance.InvestmentState)
 	for _, inv := range investments {
@@ -338,6 +943,40 @@ func sumIncomeReducingContributions(changes []finance.InvestmentChange) float64
 	return total
 }
 
+func sumTaxableWithdrawals(changes []finance.InvestmentChange) float64 {
+	total := 0.0
+	for _, change := range changes {
+		total += change.TaxableWithdrawal
+	}
+	return total
+}
+
+// sumPreTaxContributions totals cash-funded contributions to accounts whose
+// contributions reduce taxable income.
+func sumPreTaxContributions(changes []finance.InvestmentChange) float64 {
+	total := 0.0
+	for _, change := range changes {
+		if change.ContributionFromCash && finance.PreTaxContributions(change.AccountType) {
+			total += change.Contribution
+		}
+	}
+	return total
+}
+
+// filterTaxableEvents returns the scenario and common events whose amounts are
+// gross taxable income.
+func filterTaxableEvents(scenarioEvents, commonEvents []config.Event) []config.Event {
+	var taxable []config.Event
+	for _, events := range [][]config.Event{scenarioEvents, commonEvents} {
+		for _, event := range events {
+			if event.Taxable == config.TaxableGross {
+				taxable = append(taxable, event)
+			}
+		}
+	}
+	return taxable
+}
+
 func sumWithdrawals(changes []finance.InvestmentChange) float64 {
 	total := 0.0
 	for _, change := range changes {
@@ -354,7 +993,9 @@ type MonthlyExpenseInputs struct {
 	CommonEvents       float64
 	ScenarioLoans      float64
 	CommonLoans        float64
+	RevolvingDebts     float64
 	OtherContributions []float64 // cash-reducing contributions, already positive
+	Taxes              float64   // income tax paid this month
 }
 
 func calculateMonthlyExpenses(inputs MonthlyExpenseInputs) float64 {
@@ -371,10 +1012,16 @@ func calculateMonthlyExpenses(inputs MonthlyExpenseInputs) float64 {
 	if inputs.CommonLoans < 0 {
 		total += -inputs.CommonLoans
 	}
+	if inputs.RevolvingDebts < 0 {
+		total += -inputs.RevolvingDebts
+	}
 	for _, contribution := range inputs.OtherContributions {
 		if contribution > 0 {
 			total += contribution
 		}
 	}
+	if inputs.Taxes > 0 {
+		total += inputs.Taxes
+	}
 	return total
 }
@@ -371,6 +371,1101 @@ func TestGetForecastWithConfiguredStartDate(t *testing.T) {
 	}
 }
 
+func TestGetForecastRealSeries(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate: "2025-06",
+		Inflation: 3.0,
+		Common: config.Common{
+			StartingValue: 10000.0,
+			DeathDate:     "2026-06",
+		},
+		Scenarios: []config.Scenario{
+			{Name: "Flat", Active: true},
+		},
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	result := results[0]
+
+	if got := result.RealLiquid["2025-06"]; got != 10000.0 {
+		t.Errorf("RealLiquid at start = %.2f, want 10000.00", got)
+	}
+	expected := 10000.0 / 1.03
+	if got := result.RealData["2026-06"]; math.Abs(got-expected) > 0.01 {
+		t.Errorf("RealData after one year = %.2f, want %.2f", got, expected)
+	}
+	if got := result.Data["2026-06"]; got != 10000.0 {
+		t.Errorf("nominal Data after one year = %.2f, want 10000.00", got)
+	}
+
+	conf.Inflation = 0
+	results, err = GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	if results[0].RealData != nil || results[0].RealLiquid != nil {
+		t.Errorf("expected no real series without inflation")
+	}
+}
+
+func TestGetForecastCashAccounts(t *testing.T) {
+	logger := zap.NewNop()
+	sweepAbove := 2000.0
+
+	conf := config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			StartingValue: 500.0,
+			DeathDate:     "2025-03",
+			CashAccounts: []config.CashAccount{
+				{Name: "Checking", StartingValue: 1500, Sweep: &config.CashSweep{To: "Savings", Above: &sweepAbove}},
+				{Name: "Savings", StartingValue: 12000, InterestRate: 12.682503013196977}, // 1% per month
+			},
+			Events: []config.Event{
+				{Name: "Salary", Amount: 3000, Frequency: 1},
+				{Name: "Emergency", Amount: -100, Frequency: 1, Account: "Savings"},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{Name: "Base", Active: true},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	result := results[0]
+
+	if len(result.Accounts) != 2 || result.Accounts[0].Name != "Checking" || result.Accounts[1].Name != "Savings" {
+		t.Fatalf("unexpected account series: %+v", result.Accounts)
+	}
+	if got := result.Accounts[0].Balances["2025-01"]; got != 2000 {
+		t.Errorf("Checking at start = %.2f, want 2000 (includes common startingValue)", got)
+	}
+
+	// February: savings earns 120, checking receives salary and sweeps 3000 to savings.
+	checking := result.Accounts[0].Balances["2025-02"]
+	savings := result.Accounts[1].Balances["2025-02"]
+	if checking != 2000 {
+		t.Errorf("Checking in 2025-02 = %.2f, want 2000", checking)
+	}
+	if math.Abs(savings-15020) > 0.01 {
+		t.Errorf("Savings in 2025-02 = %.2f, want 15020.00", savings)
+	}
+	if got := result.Liquid["2025-02"]; math.Abs(got-(checking+savings)) > 0.001 {
+		t.Errorf("Liquid = %.2f, want sum of accounts %.2f", got, checking+savings)
+	}
+
+	conf.Common.Events[1].Account = "Brokerage"
+	if _, err := GetForecast(logger, conf); err == nil {
+		t.Error("expected error for unknown cash account")
+	}
+}
+
+func TestGetForecastShortfallPolicy(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate:       "2025-01",
+		ShortfallPolicy: &config.ShortfallPolicy{Order: []string{"Brokerage", "Roth IRA"}},
+		Common: config.Common{
+			StartingValue: 100.0,
+			DeathDate:     "2025-04",
+			Events: []config.Event{
+				{Name: "Rent", Amount: -1000, Frequency: 1},
+			},
+			Investments: []config.Investment{
+				{Name: "Roth IRA", StartingValue: 5000},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Base",
+				Active: true,
+				Investments: []config.Investment{
+					{Name: "Brokerage", StartingValue: 1500},
+				},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	result := results[0]
+
+	for _, date := range []string{"2025-02", "2025-03", "2025-04"} {
+		if liquid := result.Liquid[date]; math.Abs(liquid) > 0.001 {
+			t.Errorf("Liquid[%s] = %.2f, want 0 after shortfall draws", date, liquid)
+		}
+	}
+	if got := result.Data["2025-04"]; math.Abs(got-3600) > 0.001 {
+		t.Errorf("Data[2025-04] = %.2f, want 3600.00", got)
+	}
+
+	febNotes := strings.Join(result.Notes["2025-02"], "; ")
+	if !strings.Contains(febNotes, "scenario Brokerage: shortfall withdrawal +900.00") {
+		t.Errorf("expected brokerage shortfall note in 2025-02, got %q", febNotes)
+	}
+	marNotes := strings.Join(result.Notes["2025-03"], "; ")
+	if !strings.Contains(marNotes, "scenario Brokerage: shortfall withdrawal +600.00") ||
+		!strings.Contains(marNotes, "common Roth IRA: shortfall withdrawal +400.00") {
+		t.Errorf("expected brokerage then Roth draws in 2025-03, got %q", marNotes)
+	}
+}
+
+func TestGetForecastTaxes(t *testing.T) {
+	logger := zap.NewNop()
+	deduction := 0.0
+
+	conf := config.Configuration{
+		StartDate: "2024-12",
+		Taxes: &config.TaxConfig{
+			StateRate:         5,
+			StandardDeduction: &deduction,
+			Brackets:          []config.TaxBracket{{Threshold: 0, Rate: 10}, {Threshold: 60000, Rate: 20}},
+		},
+		Common: config.Common{
+			StartingValue: 0,
+			DeathDate:     "2025-12",
+			Events: []config.Event{
+				{Name: "Salary", Amount: 5000, Frequency: 1, Taxable: config.TaxableGross},
+				{Name: "Bonus", Amount: 12000, Frequency: 1, StartDate: "2025-12", EndDate: "2025-12", Taxable: config.TaxableGross},
+				{Name: "Gift", Amount: 100, Frequency: 1},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{Name: "Base", Active: true},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	result := results[0]
+
+	taxes := result.Metrics.Taxes
+	if taxes == nil {
+		t.Fatal("expected tax metrics")
+	}
+	if math.Abs(taxes.Federal-8400) > 0.01 || math.Abs(taxes.State-3600) > 0.01 {
+		t.Errorf("taxes paid = federal %.2f state %.2f, want 8400.00 and 3600.00", taxes.Federal, taxes.State)
+	}
+	if len(taxes.Years) != 1 || taxes.Years[0].Year != 2025 {
+		t.Errorf("unexpected tax years: %+v", taxes.Years)
+	}
+
+	janNotes := strings.Join(result.Notes["2025-01"], "; ")
+	if !strings.Contains(janNotes, "taxes -750.00 (federal 500.00, state 250.00)") {
+		t.Errorf("expected monthly withholding note, got %q", janNotes)
+	}
+
+	// 72,000 gross plus 1,200 in untaxed gifts, less 12,000 of taxes.
+	if got := result.Liquid["2025-12"]; math.Abs(got-61200) > 0.01 {
+		t.Errorf("Liquid[2025-12] = %.2f, want 61200.00", got)
+	}
+}
+
+func TestGetForecastTaxesEndingMidYear(t *testing.T) {
+	deduction := 0.0
+	for _, settlement := range []string{"monthly", "annual"} {
+		t.Run(settlement, func(t *testing.T) {
+			conf := config.Configuration{
+				StartDate: "2024-12",
+				Taxes: &config.TaxConfig{
+					StandardDeduction: &deduction,
+					Brackets:          []config.TaxBracket{{Threshold: 0, Rate: 10}, {Threshold: 30000, Rate: 20}},
+					Settlement:        settlement,
+				},
+				Common: config.Common{
+					DeathDate: "2025-06",
+					Events: []config.Event{
+						{Name: "Salary", Amount: 5000, Frequency: 1, Taxable: config.TaxableGross},
+					},
+				},
+				Scenarios: []config.Scenario{
+					{Name: "Base", Active: true},
+				},
+			}
+			if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+				t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+			}
+
+			results, err := GetForecast(zap.NewNop(), conf)
+			if err != nil {
+				t.Fatalf("GetForecast() error = %v", err)
+			}
+			result := results[0]
+
+			// Six months of salary owe 10% of 30,000, settled in the final
+			// month whether it was over-withheld on annualized income or not
+			// yet paid.
+			if got := result.Metrics.Taxes.Total(); math.Abs(got-3000) > 0.01 {
+				t.Errorf("taxes paid = %.2f, want 3000.00", got)
+			}
+			if got := result.Liquid["2025-06"]; math.Abs(got-27000) > 0.01 {
+				t.Errorf("Liquid[2025-06] = %.2f, want 27000.00", got)
+			}
+		})
+	}
+}
+
+func TestGetForecastTaxesFinalMonthShortfall(t *testing.T) {
+	deduction := 0.0
+	conf := config.Configuration{
+		StartDate: "2024-12",
+		Taxes: &config.TaxConfig{
+			StandardDeduction: &deduction,
+			Brackets:          []config.TaxBracket{{Threshold: 0, Rate: 10}},
+		},
+		ShortfallPolicy: &config.ShortfallPolicy{Order: []string{"IRA"}},
+		Common: config.Common{
+			DeathDate: "2025-03",
+			Events: []config.Event{
+				{Name: "Living", Amount: -1000, Frequency: 1},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:        "Retired",
+				Active:      true,
+				Investments: []config.Investment{{Name: "IRA", StartingValue: 100000, AccountType: "traditional"}},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(zap.NewNop(), conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	result := results[0]
+
+	// Every dollar spent is a taxable draw, including the final month's and
+	// those paying the final settlement: 3,000 of spending needs 3,333.33 of
+	// draws, a tenth of which is tax.
+	if got := result.Metrics.Taxes.Total(); math.Abs(got-333.33) > 0.01 {
+		t.Errorf("taxes paid = %.2f, want 333.33", got)
+	}
+	if got := result.Liquid["2025-03"]; math.Abs(got) > 0.01 {
+		t.Errorf("Liquid[2025-03] = %.2f, want 0.00", got)
+	}
+	if got := result.Data["2025-03"]; math.Abs(got-(100000-3333.33)) > 0.01 {
+		t.Errorf("Data[2025-03] = %.2f, want %.2f", got, 100000-3333.33)
+	}
+	marNotes := strings.Join(result.Notes["2025-03"], "; ")
+	if !strings.Contains(marNotes, "final tax settlement -123.33") {
+		t.Errorf("expected final settlement note, got %q", marNotes)
+	}
+}
+
+func TestGetForecastRequiredMinimumDistributions(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate: "2024-12",
+		Common: config.Common{
+			StartingValue: 0,
+			DeathDate:     "2026-12",
+			BirthDate:     "1952-06",
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Retired",
+				Active: true,
+				Investments: []config.Investment{
+					{Name: "IRA", StartingValue: 265000, AccountType: "traditional", WithdrawalTaxRate: 20,
+						Withdrawals: []config.Event{{Name: "Draw", Amount: 500, Frequency: 1, StartDate: "2026-01"}}},
+					{Name: "Roth", StartingValue: 100000, AccountType: "roth"},
+				},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	result := results[0]
+
+	// Age 73 in 2025: 265,000 / 26.5 = 10,000, less 20% withdrawal tax.
+	decNotes := strings.Join(result.Notes["2025-12"], "; ")
+	if !strings.Contains(decNotes, "scenario IRA: required minimum distribution +10000.00 (basis +10000.00, growth +0.00), withdrawal tax 2000.00") {
+		t.Errorf("expected RMD note, got %q", decNotes)
+	}
+	if got := result.Liquid["2025-12"]; math.Abs(got-8000) > 0.01 {
+		t.Errorf("Liquid[2025-12] = %.2f, want 8000.00", got)
+	}
+
+	// Age 74 in 2026: 255,000 / 25.5 = 10,000, of which 6,000 was already withdrawn.
+	if got := result.Liquid["2026-12"]; math.Abs(got-16000) > 0.01 {
+		t.Errorf("Liquid[2026-12] = %.2f, want 16000.00", got)
+	}
+	if got := result.Data["2026-12"]; math.Abs(got-(16000+245000+100000)) > 0.01 {
+		t.Errorf("Data[2026-12] = %.2f, want %.2f", got, 16000.0+245000+100000)
+	}
+	for _, note := range result.Notes["2026-12"] {
+		if strings.Contains(note, "Roth") {
+			t.Errorf("Roth accounts have no RMD, got %q", note)
+		}
+	}
+}
+
+func TestGetForecastDistributionsCountUnscheduledWithdrawals(t *testing.T) {
+	logger := zap.NewNop()
+	below := 1.0
+
+	tests := []struct {
+		name     string
+		policy   *config.ShortfallPolicy
+		scenario config.Scenario
+		wantRMD  string
+	}{
+		{
+			name:   "shortfall draws",
+			policy: &config.ShortfallPolicy{Order: []string{"IRA"}},
+			scenario: config.Scenario{
+				Name:   "Shortfall",
+				Active: true,
+				Events: []config.Event{{Name: "Living", Amount: -1000, Frequency: 1}},
+			},
+			// The 11,000 drawn through November covers the 10,000 due.
+		},
+		{
+			name: "trigger sale",
+			scenario: config.Scenario{
+				Name:   "Trigger sale",
+				Active: true,
+				Triggers: []config.Trigger{{
+					Name:     "Top up",
+					When:     config.TriggerCondition{Balance: "liquid", Below: &below},
+					Transfer: &config.TriggerTransfer{From: "IRA", Amount: 4000},
+				}},
+			},
+			// 10,000 is due for age 73, of which the sale covered 4,000.
+			wantRMD: "scenario IRA: required minimum distribution +6000.00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := tt.scenario
+			scenario.Investments = []config.Investment{{Name: "IRA", StartingValue: 265000, AccountType: "traditional"}}
+			conf := config.Configuration{
+				StartDate:       "2024-12",
+				ShortfallPolicy: tt.policy,
+				Common: config.Common{
+					StartingValue: 0,
+					DeathDate:     "2025-12",
+					BirthDate:     "1952-06",
+				},
+				Scenarios: []config.Scenario{scenario},
+			}
+			if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+				t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+			}
+
+			results, err := GetForecast(logger, conf)
+			if err != nil {
+				t.Fatalf("GetForecast() error = %v", err)
+			}
+
+			decNotes := strings.Join(results[0].Notes["2025-12"], "; ")
+			if tt.wantRMD == "" && strings.Contains(decNotes, "required minimum distribution") {
+				t.Errorf("expected no December distribution, got %q", decNotes)
+			}
+			if tt.wantRMD != "" && !strings.Contains(decNotes, tt.wantRMD) {
+				t.Errorf("expected %q, got %q", tt.wantRMD, decNotes)
+			}
+		})
+	}
+}
+
+func TestGetForecastTaxAdvantagedAccounts(t *testing.T) {
+	logger := zap.NewNop()
+	deduction := 0.0
+
+	conf := config.Configuration{
+		StartDate: "2024-12",
+		Taxes: &config.TaxConfig{
+			StandardDeduction: &deduction,
+			Brackets:          []config.TaxBracket{{Threshold: 0, Rate: 10}},
+		},
+		Common: config.Common{
+			StartingValue: 0,
+			DeathDate:     "2025-12",
+			Events: []config.Event{
+				{Name: "Salary", Amount: 5000, Frequency: 1, Taxable: config.TaxableGross},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Saver",
+				Active: true,
+				Investments: []config.Investment{
+					{Name: "401k", AccountType: "traditional", ContributionsFromCash: true,
+						Contributions: []config.Event{{Name: "Deferral", Amount: 1000, Frequency: 1}}},
+					{Name: "Roth", StartingValue: 50000, AccountType: "roth", AnnualReturnRate: 12,
+						Withdrawals: []config.Event{{Name: "Draw", Amount: 1000, Frequency: 1}}},
+				},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+
+	// Only salary net of the pre-tax deferral is taxed; Roth withdrawals are tax free.
+	taxes := results[0].Metrics.Taxes
+	if taxes == nil || math.Abs(taxes.Federal-4800) > 0.01 {
+		t.Fatalf("federal tax = %+v, want 4800.00", taxes)
+	}
+}
+
+func TestGetForecastHouseholdAges(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate: "2024-12",
+		Household: &config.Household{Members: []config.Member{
+			{Name: "Alex", BirthDate: "1963-03", LifeExpectancy: 63},
+		}},
+		Common: config.Common{
+			StartingValue: 0,
+			Events: []config.Event{
+				{Name: "Salary", Amount: 1000, Frequency: 1, EndAge: 62},
+				{Name: "Pension", Amount: 400, Frequency: 1, StartAge: 62},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{Name: "Base", Active: true},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	result := results[0]
+
+	if _, ok := result.Data["2026-03"]; !ok {
+		t.Fatal("expected the forecast to run until Alex turns 63 in 2026-03")
+	}
+	if _, ok := result.Data["2026-04"]; ok {
+		t.Error("forecast ran past the household life expectancy")
+	}
+
+	if len(result.Ages) != 1 || result.Ages[0].Name != "Alex" {
+		t.Fatalf("unexpected ages: %+v", result.Ages)
+	}
+	if got := result.Ages[0].Ages["2025-02"]; got != 61 {
+		t.Errorf("age in 2025-02 = %d, want 61", got)
+	}
+	if got := result.Ages[0].Ages["2025-03"]; got != 62 {
+		t.Errorf("age in 2025-03 = %d, want 62", got)
+	}
+
+	// Salary through 2025-02, pension from 2025-03.
+	if got := result.Liquid["2025-02"]; got != 2000 {
+		t.Errorf("Liquid[2025-02] = %.2f, want 2000.00", got)
+	}
+	if got := result.Liquid["2025-03"]; got != 2400 {
+		t.Errorf("Liquid[2025-03] = %.2f, want 2400.00", got)
+	}
+}
+
+func TestGetForecastAnchoredEvents(t *testing.T) {
+	logger := zap.NewNop()
+
+	loan := config.Loan{Name: "Car", StartDate: "2025-01", Principal: 600, Term: 6}
+	earlyLoan := loan
+	earlyLoan.EarlyPayoffThreshold = 1000
+	conf := config.Configuration{
+		StartDate: "2024-12",
+		Common: config.Common{
+			StartingValue: 2000,
+			DeathDate:     "2025-12",
+			Events: []config.Event{
+				{Name: "Save car payment", Amount: -100, Frequency: 1, StartAnchor: &config.DateAnchor{After: "loan:Car.payoff", Offset: 1}},
+				{Name: "Side job", Amount: 10, Frequency: 1, EndAnchor: &config.DateAnchor{After: "event:Save car payment.start", Offset: -1}},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{Name: "Scheduled", Active: true, Loans: []config.Loan{loan}},
+			{Name: "Early payoff", Active: true, Loans: []config.Loan{earlyLoan}},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2024-12")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+	if err := conf.ProcessLoans(logger); err != nil {
+		t.Fatalf("ProcessLoans() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+
+	tests := []struct {
+		scenario string
+		month    string
+		want     float64
+	}{
+		// Car payments of 100 plus the side job through 2025-06, then savings.
+		{"Scheduled", "2025-06", -90},
+		{"Scheduled", "2025-07", -100},
+		// The threshold pays the car off in 2025-02, pulling both anchors forward.
+		{"Early payoff", "2025-02", -490},
+		{"Early payoff", "2025-03", -100},
+	}
+	for _, tt := range tests {
+		var result Forecast
+		for _, candidate := range results {
+			if candidate.Name == tt.scenario {
+				result = candidate
+			}
+		}
+		previous, err := datetime.OffsetDate(tt.month, config.DateTimeLayout, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := result.Liquid[tt.month] - result.Liquid[previous]; math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("%s: change in %s = %.2f, want %.2f", tt.scenario, tt.month, got, tt.want)
+		}
+	}
+
+	if len(conf.Common.Events[0].DateList) != 0 {
+		t.Error("resolving anchors mutated the configuration's events")
+	}
+}
+
+func TestGetForecastTriggers(t *testing.T) {
+	logger := zap.NewNop()
+
+	above := 1500.0
+	below := 500.0
+	invest := config.Trigger{
+		Name:     "Invest",
+		When:     config.TriggerCondition{Balance: "liquid", Above: &above},
+		Transfer: &config.TriggerTransfer{To: "Brokerage", Amount: 1000},
+	}
+	repeating := invest
+	repeating.Repeat = true
+	conf := config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			StartingValue: 1000,
+			DeathDate:     "2025-07",
+			Events: []config.Event{
+				{Name: "Income", Amount: 300, Frequency: 1},
+			},
+			Investments: []config.Investment{
+				{Name: "Brokerage", StartingValue: 1000},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{Name: "One-shot", Active: true, Triggers: []config.Trigger{invest}},
+			{Name: "Repeating", Active: true, Triggers: []config.Trigger{repeating}},
+			{
+				Name:   "Cut back",
+				Active: true,
+				Events: []config.Event{{Name: "Vacation", Amount: -700, Frequency: 1}},
+				Triggers: []config.Trigger{{
+					Name:      "Cut back",
+					When:      config.TriggerCondition{Balance: "liquid", Below: &below},
+					Transfer:  &config.TriggerTransfer{From: "Brokerage", Amount: 200},
+					StopEvent: "Vacation",
+				}},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	tests := []struct {
+		scenario   int
+		date       string
+		wantLiquid float64
+		wantTotal  float64
+	}{
+		{0, "2025-03", 600, 2600},
+		{0, "2025-07", 1800, 3800},
+		{1, "2025-03", 600, 2600},
+		{1, "2025-07", 800, 3800},
+		{2, "2025-03", 400, 1200},
+		{2, "2025-05", 1000, 1800},
+	}
+	for _, tt := range tests {
+		result := results[tt.scenario]
+		if got := result.Liquid[tt.date]; math.Abs(got-tt.wantLiquid) > 1e-6 {
+			t.Errorf("%s: Liquid[%s] = %.2f, want %.2f", result.Name, tt.date, got, tt.wantLiquid)
+		}
+		if got := result.Data[tt.date]; math.Abs(got-tt.wantTotal) > 1e-6 {
+			t.Errorf("%s: Data[%s] = %.2f, want %.2f", result.Name, tt.date, got, tt.wantTotal)
+		}
+	}
+
+	wantNote := "trigger Invest: liquid above 1500.00 at 1600.00, transferred 1000.00 to Brokerage"
+	if notes := strings.Join(results[0].Notes["2025-03"], "; "); !strings.Contains(notes, wantNote) {
+		t.Errorf("expected %q in 2025-03 notes, got %q", wantNote, notes)
+	}
+	wantNote = "trigger Cut back: liquid below 500.00 at 200.00, transferred 200.00 from Brokerage, stopped Vacation"
+	if notes := strings.Join(results[2].Notes["2025-03"], "; "); !strings.Contains(notes, wantNote) {
+		t.Errorf("expected %q in 2025-03 notes, got %q", wantNote, notes)
+	}
+}
+
+func TestGetForecastPercentageEvents(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			DeathDate: "2025-04",
+			Events: []config.Event{
+				{Name: "Income", Amount: 1000, Frequency: 1, AmountSchedule: []config.AmountStep{{Date: "2025-04", Amount: 2000}}},
+				{Name: "Bonus", Amount: 500, Frequency: 1, StartDate: "2025-03", EndDate: "2025-03"},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Tithe",
+				Active: true,
+				Events: []config.Event{{Name: "Tithe", Percentage: -10, PercentOf: "income", Frequency: 1}},
+			},
+			{
+				Name:   "401k",
+				Active: true,
+				Investments: []config.Investment{{
+					Name:                  "Brokerage",
+					ContributionsFromCash: true,
+					Contributions:         []config.Event{{Name: "401k", Percentage: 10, PercentOf: "event:Income", Frequency: 1}},
+				}},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	tests := []struct {
+		scenario   int
+		date       string
+		wantLiquid float64
+		wantTotal  float64
+	}{
+		{0, "2025-02", 900, 900},
+		{0, "2025-03", 2250, 2250},
+		{0, "2025-04", 4050, 4050},
+		{1, "2025-02", 900, 1000},
+		{1, "2025-03", 2300, 2500},
+		{1, "2025-04", 4100, 4500},
+	}
+	for _, tt := range tests {
+		result := results[tt.scenario]
+		if got := result.Liquid[tt.date]; math.Abs(got-tt.wantLiquid) > 1e-6 {
+			t.Errorf("%s: Liquid[%s] = %.2f, want %.2f", result.Name, tt.date, got, tt.wantLiquid)
+		}
+		if got := result.Data[tt.date]; math.Abs(got-tt.wantTotal) > 1e-6 {
+			t.Errorf("%s: Data[%s] = %.2f, want %.2f", result.Name, tt.date, got, tt.wantTotal)
+		}
+	}
+
+	if conf.Scenarios[0].Events[0].AmountList != nil || conf.Scenarios[1].Investments[0].Contributions[0].AmountList != nil {
+		t.Error("forecast mutated the configured percentage amounts")
+	}
+
+	conf.Scenarios[1].Investments[0].Contributions[0].PercentOf = "event:Salary"
+	if _, err := GetForecast(logger, conf); err == nil {
+		t.Error("expected an error for an unknown percentOf event")
+	}
+}
+
+func TestGetForecastAssets(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			DeathDate: "2026-01",
+			Assets: []config.Asset{
+				{Name: "Car", PurchaseDate: "2025-01", PurchaseValue: 10000, AppreciationRate: -12},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Sell house",
+				Active: true,
+				Assets: []config.Asset{
+					{Name: "House", PurchaseDate: "2024-01", PurchaseValue: 100000, AppreciationRate: 10, SaleDate: "2026-01", SaleCosts: 5000},
+				},
+			},
+			{
+				Name:   "Sell boat with loan",
+				Active: true,
+				Assets: []config.Asset{
+					{Name: "Boat", PurchaseDate: "2025-01", PurchaseValue: 12000, SaleCosts: 1000},
+				},
+				Loans: []config.Loan{
+					{Name: "Boat loan", StartDate: "2025-02", Principal: 1200, Term: 12, EarlyPayoffDate: "2025-06", SellProperty: true, Asset: "Boat"},
+				},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+	if err := conf.ProcessLoans(logger); err != nil {
+		t.Fatalf("ProcessLoans() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	tests := []struct {
+		scenario   int
+		date       string
+		wantLiquid float64
+		wantTotal  float64
+	}{
+		{0, "2025-01", 0, 120000},
+		{0, "2025-12", 0, 10000*math.Pow(0.88, 11.0/12) + 110000*math.Pow(1.1, 11.0/12)},
+		{0, "2026-01", 116000, 116000 + 8800},
+		{1, "2025-05", -400, -400 + 12000 + 10000*math.Pow(0.88, 4.0/12)},
+		{1, "2025-06", 9800, 9800 + 10000*math.Pow(0.88, 5.0/12)},
+	}
+	for _, tt := range tests {
+		result := results[tt.scenario]
+		if got := result.Liquid[tt.date]; math.Abs(got-tt.wantLiquid) > 1e-6 {
+			t.Errorf("%s: Liquid[%s] = %.2f, want %.2f", result.Name, tt.date, got, tt.wantLiquid)
+		}
+		if got := result.Data[tt.date]; math.Abs(got-tt.wantTotal) > 1e-6 {
+			t.Errorf("%s: Data[%s] = %.2f, want %.2f", result.Name, tt.date, got, tt.wantTotal)
+		}
+	}
+
+	wantNote := "scenario asset House: sold for 121000.00 with 5000.00 sale costs"
+	if notes := strings.Join(results[0].Notes["2026-01"], "; "); !strings.Contains(notes, wantNote) {
+		t.Errorf("expected %q in 2026-01 notes, got %q", wantNote, notes)
+	}
+}
+
+func TestGetForecastNetWorth(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			StartingValue: 5000,
+			DeathDate:     "2025-05",
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Borrow",
+				Active: true,
+				Loans: []config.Loan{
+					{Name: "Older loan", StartDate: "2024-11", Principal: 600, Term: 6},
+					{Name: "New loan", StartDate: "2025-02", Principal: 1200, Term: 12},
+				},
+			},
+			{
+				Name:   "Debt free",
+				Active: true,
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+	if err := conf.ProcessLoans(logger); err != nil {
+		t.Fatalf("ProcessLoans() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	tests := []struct {
+		date            string
+		wantTotal       float64
+		wantLiabilities float64
+		wantNetWorth    float64
+	}{
+		{"2025-01", 5000, 300, 4700},
+		{"2025-02", 4800, 1300, 3500},
+		{"2025-03", 4600, 1100, 3500},
+		{"2025-04", 4400, 900, 3500},
+	}
+	result := results[0]
+	for _, tt := range tests {
+		if got := result.Data[tt.date]; math.Abs(got-tt.wantTotal) > 1e-6 {
+			t.Errorf("Data[%s] = %.2f, want %.2f", tt.date, got, tt.wantTotal)
+		}
+		if got := result.Liabilities[tt.date]; math.Abs(got-tt.wantLiabilities) > 1e-6 {
+			t.Errorf("Liabilities[%s] = %.2f, want %.2f", tt.date, got, tt.wantLiabilities)
+		}
+		if got := result.NetWorth[tt.date]; math.Abs(got-tt.wantNetWorth) > 1e-6 {
+			t.Errorf("NetWorth[%s] = %.2f, want %.2f", tt.date, got, tt.wantNetWorth)
+		}
+	}
+	if result.RealNetWorth != nil {
+		t.Error("expected no real net worth series without inflation")
+	}
+
+	if results[1].Liabilities != nil || results[1].NetWorth != nil {
+		t.Error("expected no net worth series for a scenario without loans")
+	}
+}
+
+func TestGetForecastDebtPayoff(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			StartingValue: 10000,
+			DeathDate:     "2027-01",
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Snowball",
+				Active: true,
+				Loans: []config.Loan{
+					{Name: "Car", StartDate: "2025-01", Principal: 1200, Term: 12},
+					{Name: "Card loan", StartDate: "2025-01", Principal: 2400, InterestRate: 12, Term: 24},
+				},
+				DebtPayoff: &config.DebtPayoff{Strategy: "snowball", Budget: 100},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+	if err := conf.ProcessLoans(logger); err != nil {
+		t.Fatalf("ProcessLoans() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	plans := results[0].Metrics.DebtPayoff
+	if len(plans) != 1 || plans[0].Scope != "scenario" || plans[0].Budget != 100 {
+		t.Fatalf("expected one scenario debt payoff summary, got %+v", plans)
+	}
+	if plans[0].Report.InterestSaved() <= 0 || len(plans[0].Comparison) != 2 {
+		t.Errorf("expected interest saved and a strategy comparison, got %+v", plans[0])
+	}
+
+	// The car takes 200 a month until July, when its regular payment clears it.
+	if got := results[0].Liquid["2025-02"]; math.Abs(got-(10000-200-conf.Scenarios[0].Loans[1].AmortizationSchedule["2025-02"].Payment)) > 1e-6 {
+		t.Errorf("Liquid[2025-02] = %.2f, want the planned payments deducted", got)
+	}
+	want := "scenario loan Car: paid off by the snowball plan, 5 months early"
+	if notes := results[0].Notes["2025-07"]; len(notes) != 1 || notes[0] != want {
+		t.Errorf("Notes[2025-07] = %v, want [%s]", notes, want)
+	}
+}
+
+func TestGetForecastRevolvingDebts(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate:       "2025-01",
+		Recommendations: config.RecommendationsConfig{EmergencyFundMonths: 6},
+		Common: config.Common{
+			StartingValue: 1000,
+			DeathDate:     "2025-04",
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:           "Minimums",
+				Active:         true,
+				RevolvingDebts: []config.RevolvingDebt{{Name: "Card", Balance: 300, MinimumPercent: 10, MinimumPayment: 50}},
+			},
+			{
+				Name:   "Pay in full",
+				Active: true,
+				RevolvingDebts: []config.RevolvingDebt{{
+					Name:      "Card",
+					Balance:   300,
+					APR:       24,
+					PayInFull: true,
+					Charges:   []config.Event{{Name: "Groceries", Amount: 200, Frequency: 1, StartDate: "2025-03"}},
+				}},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	tests := []struct {
+		scenario        int
+		date            string
+		wantLiquid      float64
+		wantLiabilities float64
+	}{
+		{0, "2025-01", 1000, 300},
+		{0, "2025-02", 950, 250},
+		{0, "2025-03", 900, 200},
+		{0, "2025-04", 850, 150},
+		{1, "2025-02", 694, 0},
+		{1, "2025-03", 494, 0},
+		{1, "2025-04", 294, 0},
+	}
+	for _, tt := range tests {
+		result := results[tt.scenario]
+		if got := result.Liquid[tt.date]; math.Abs(got-tt.wantLiquid) > 1e-6 {
+			t.Errorf("%s Liquid[%s] = %.2f, want %.2f", result.Name, tt.date, got, tt.wantLiquid)
+		}
+		if got := result.Liabilities[tt.date]; math.Abs(got-tt.wantLiabilities) > 1e-6 {
+			t.Errorf("%s Liabilities[%s] = %.2f, want %.2f", result.Name, tt.date, got, tt.wantLiabilities)
+		}
+		if got, want := result.NetWorth[tt.date], tt.wantLiquid-tt.wantLiabilities; math.Abs(got-want) > 1e-6 {
+			t.Errorf("%s NetWorth[%s] = %.2f, want %.2f", result.Name, tt.date, got, want)
+		}
+	}
+
+	if notes := results[1].Notes["2025-02"]; len(notes) != 1 || notes[0] != "scenario revolving debt Card: paid off" {
+		t.Errorf("expected a payoff note in 2025-02, got %v", notes)
+	}
+	if notes := results[1].Notes["2025-03"]; len(notes) != 0 {
+		t.Errorf("expected no note when only new charges are paid, got %v", notes)
+	}
+	if ef := results[0].Metrics.EmergencyFund; ef == nil || math.Abs(ef.AverageMonthlyExpenses-50) > 1e-6 {
+		t.Errorf("expected the 50.00 minimum payment as the average monthly expense, got %+v", ef)
+	}
+}
+
+func TestGetForecastRefinance(t *testing.T) {
+	logger := zap.NewNop()
+
+	conf := config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			StartingValue: 10000,
+			DeathDate:     "2025-06",
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Refinance",
+				Active: true,
+				Loans: []config.Loan{
+					{
+						Name:       "Mortgage",
+						StartDate:  "2025-01",
+						Principal:  12000,
+						Term:       12,
+						Refinances: []config.Refinance{{Date: "2025-03", Term: 20, ClosingCosts: 500, CashOut: 1000}},
+					},
+				},
+			},
+		},
+	}
+	if err := conf.ParseDateListsWithFixedTime(datetime.MustParseTime(config.DateTimeLayout, "2025-01")); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+	if err := conf.ProcessLoans(logger); err != nil {
+		t.Fatalf("ProcessLoans() error = %v", err)
+	}
+
+	results, err := GetForecast(logger, conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	result := results[0]
+
+	// 11000 is re-amortized over 20 months: 550 a month from 2025-03, whose
+	// payment also carries the closing costs and pays out the cash.
+	tests := []struct {
+		date            string
+		wantLiquid      float64
+		wantLiabilities float64
+	}{
+		{"2025-02", 9000, 10000},
+		{"2025-03", 9000 - 550 - 500 + 1000, 10450},
+		{"2025-04", 8950 - 550, 9900},
+	}
+	for _, tt := range tests {
+		if got := result.Liquid[tt.date]; math.Abs(got-tt.wantLiquid) > 1e-6 {
+			t.Errorf("Liquid[%s] = %.2f, want %.2f", tt.date, got, tt.wantLiquid)
+		}
+		if got := result.Liabilities[tt.date]; math.Abs(got-tt.wantLiabilities) > 1e-6 {
+			t.Errorf("Liabilities[%s] = %.2f, want %.2f", tt.date, got, tt.wantLiabilities)
+		}
+	}
+
+	want := "scenario loan Mortgage: refinanced 11000.00 at 0.00% for 20 months, payment 1000.00 -> 550.00, closing costs 500.00, cash out 1000.00, breaks even 2025-05 (2 months)"
+	if notes := strings.Join(result.Notes["2025-03"], "; "); !strings.Contains(notes, want) {
+		t.Errorf("expected %q in 2025-03 notes, got %q", want, notes)
+	}
+}
+
 func TestGetForecastWithInvestments(t *testing.T) {
 	logger := zap.NewNop()
 
@@ -0,0 +1,56 @@
+package forecast
+
+import (
+	"github.com/iwvelando/finance-forecast/internal/config"
+)
+
+// liabilities tracks the principal outstanding on a scenario's loans and the
+// balances carried on its revolving debts. Loan balances follow each loan's
+// amortization schedule, including schedules rebuilt by early payoffs, and
+// stay at zero until a loan's first payment.
+type liabilities struct {
+	loans     []*config.Loan
+	balances  []float64
+	revolving []*heldDebt
+}
+
+// trackLiabilities starts tracking the loans at their balances on date along
+// with the revolving debts.
+func trackLiabilities(date string, revolving []*heldDebt, loanLists ...[]config.Loan) *liabilities {
+	tracked := &liabilities{revolving: revolving}
+	for _, loans := range loanLists {
+		for i := range loans {
+			tracked.loans = append(tracked.loans, &loans[i])
+			tracked.balances = append(tracked.balances, loans[i].BalanceAt(date))
+		}
+	}
+	return tracked
+}
+
+// empty reports whether there are no loans or revolving debts to track.
+func (l *liabilities) empty() bool {
+	return len(l.loans) == 0 && len(l.revolving) == 0
+}
+
+// advance moves the balances to date and returns their total.
+func (l *liabilities) advance(date string) float64 {
+	for i, loan := range l.loans {
+		if payment, ok := loan.AmortizationSchedule[date]; ok {
+			l.balances[i] = payment.RemainingPrincipal
+		}
+	}
+	return l.total()
+}
+
+// total returns the principal outstanding across the loans plus the revolving
+// balances.
+func (l *liabilities) total() float64 {
+	total := 0.0
+	for _, balance := range l.balances {
+		total += balance
+	}
+	for _, debt := range l.revolving {
+		total += debt.Balance
+	}
+	return total
+}
@@ -0,0 +1,99 @@
+package forecast
+
+import (
+	"fmt"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+)
+
+// percentageEvent is an event or contribution whose amount is a percentage of
+// income or of another event.
+type percentageEvent struct {
+	event *config.Event
+	kind  string
+	name  string
+}
+
+// collectPercentages resolves the percentOf references of the scenario's
+// events and investment contributions.
+func (s *eventSchedule) collectPercentages(scenarioName string) error {
+	add := func(event *config.Event) error {
+		if !event.PercentageBased() {
+			return nil
+		}
+		kind, name, err := event.PercentOfSource()
+		if err != nil {
+			return err
+		}
+		if kind == config.PercentOfEvent {
+			referenced := s.findEvent(name)
+			if referenced == nil {
+				return fmt.Errorf("event %s: percentOf refers to an unknown event %q in scenario %s", event.Name, name, scenarioName)
+			}
+			if referenced.PercentageBased() {
+				return fmt.Errorf("event %s: percentOf cannot refer to %s, which is itself a percentage", event.Name, name)
+			}
+		}
+		s.percentages = append(s.percentages, &percentageEvent{event: event, kind: kind, name: name})
+		return nil
+	}
+
+	for _, events := range [][]config.Event{s.scenarioEvents, s.commonEvents} {
+		for i := range events {
+			if err := add(&events[i]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, investments := range [][]config.Investment{s.scenarioInvestments, s.commonInvestments} {
+		for i := range investments {
+			for j := range investments[i].Contributions {
+				if err := add(&investments[i].Contributions[j]); err != nil {
+					return fmt.Errorf("investment %s: %w", investments[i].Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// applyPercentages recomputes percentage-based amounts from the flows
+// currently scheduled, so they follow growth, optimized amounts, rescheduled
+// anchors, and stopped events. Income is the total of all positive events in
+// a month other than percentage-based ones.
+func (s *eventSchedule) applyPercentages() {
+	if len(s.percentages) == 0 {
+		return
+	}
+
+	income := make(map[string]float64)
+	for _, events := range [][]config.Event{s.scenarioEvents, s.commonEvents} {
+		for _, event := range events {
+			if event.PercentageBased() {
+				continue
+			}
+			for i, date := range event.DateList {
+				if amount := event.AmountAt(i); amount > 0 {
+					income[date.Format(config.DateTimeLayout)] += amount
+				}
+			}
+		}
+	}
+
+	for _, p := range s.percentages {
+		base := income
+		if p.kind == config.PercentOfEvent {
+			base = make(map[string]float64)
+			referenced := s.findEvent(p.name)
+			for i, date := range referenced.DateList {
+				base[date.Format(config.DateTimeLayout)] += referenced.AmountAt(i)
+			}
+		}
+		amounts := make([]float64, len(p.event.DateList))
+		for i, date := range p.event.DateList {
+			amounts[i] = base[date.Format(config.DateTimeLayout)] * p.event.Percentage / constants.PercentageMultiplier
+		}
+		p.event.AmountList = amounts
+	}
+}
@@ -0,0 +1,52 @@
+package forecast
+
+import (
+	"fmt"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+	"github.com/iwvelando/finance-forecast/pkg/adapters"
+	"github.com/iwvelando/finance-forecast/pkg/finance"
+)
+
+// heldDebt tracks one revolving debt through a scenario.
+type heldDebt struct {
+	*finance.RevolvingDebt
+	scope   string
+	account string
+}
+
+// buildRevolvingDebts lists the scenario's revolving debts followed by the
+// common ones, each starting at its configured balance.
+func buildRevolvingDebts(scenario, common []config.RevolvingDebt) []*heldDebt {
+	var debts []*heldDebt
+	for _, debt := range scenario {
+		debts = append(debts, &heldDebt{RevolvingDebt: adapters.RevolvingDebtToFinanceDebt(debt), scope: "scenario", account: debt.Account})
+	}
+	for _, debt := range common {
+		debts = append(debts, &heldDebt{RevolvingDebt: adapters.RevolvingDebtToFinanceDebt(debt), scope: "common", account: debt.Account})
+	}
+	return debts
+}
+
+// processRevolvingDebts makes the month's revolving debt payments from the
+// ledger and returns their total as a negative amount. Debts paying in full
+// may use whatever cash the ledger holds once earlier debts are paid.
+func processRevolvingDebts(engine *finance.ForecastEngine, ledger *finance.CashLedger, date string, debts []*heldDebt, notes map[string][]string) (float64, error) {
+	total := 0.0
+	for _, debt := range debts {
+		carried := debt.Balance
+		debt.Available = ledger.Total()
+		amount, err := engine.ProcessMonthlyChanges(date, nil, []finance.LoanWithSchedule{debt.RevolvingDebt}, config.DateTimeLayout)
+		if err != nil {
+			return 0, err
+		}
+		if err := ledger.Deposit(debt.account, amount); err != nil {
+			return 0, err
+		}
+		total += amount
+		if carried > 0 && debt.Balance == 0 {
+			notes[date] = append(notes[date], fmt.Sprintf("%s revolving debt %s: paid off", debt.scope, debt.Name))
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,282 @@
+package forecast
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+)
+
+// eventSchedule holds a scenario's working copies of its scenario and common
+// events and investments. It schedules events whose start or end is anchored
+// to another event or loan, re-reading milestones every month so anchors
+// follow early payoffs and other anchored events, stops events when triggers
+// fire, and keeps percentage-based amounts in step with the flows they follow.
+type eventSchedule struct {
+	conf                config.Configuration
+	fixedTime           time.Time
+	deathDate           time.Time
+	scenarioEvents      []config.Event
+	commonEvents        []config.Event
+	scenarioInvestments []config.Investment
+	commonInvestments   []config.Investment
+	scenarioLoans       []config.Loan
+	commonLoans         []config.Loan
+	anchored            []*anchoredEvent
+	percentages         []*percentageEvent
+	stopped             map[*config.Event]time.Time
+}
+
+// anchoredEvent remembers the months an anchored event was last scheduled for.
+type anchoredEvent struct {
+	event     *config.Event
+	scheduled bool
+	active    bool
+	start     time.Time
+	end       time.Time
+}
+
+// newEventSchedule copies the scenario's events and investments, so
+// rescheduling never leaks into the configuration, and validates their
+// anchors and percentOf references.
+func newEventSchedule(conf config.Configuration, scenario config.Scenario, fixedTime time.Time) (*eventSchedule, error) {
+	s := &eventSchedule{
+		conf:                conf,
+		fixedTime:           fixedTime,
+		scenarioEvents:      cloneEvents(scenario.Events),
+		commonEvents:        cloneEvents(conf.Common.Events),
+		scenarioInvestments: cloneInvestments(scenario.Investments),
+		commonInvestments:   cloneInvestments(conf.Common.Investments),
+		scenarioLoans:       scenario.Loans,
+		commonLoans:         conf.Common.Loans,
+		stopped:             make(map[*config.Event]time.Time),
+	}
+	if anyAnchored(scenario.Events) || anyAnchored(conf.Common.Events) {
+		if err := s.collectAnchored(scenario.Name); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.collectPercentages(scenario.Name); err != nil {
+		return nil, err
+	}
+	s.applyPercentages()
+	return s, nil
+}
+
+// collectAnchored validates the anchored events' references and tracks them
+// for scheduling.
+func (s *eventSchedule) collectAnchored(scenarioName string) error {
+	deathDate, err := time.Parse(config.DateTimeLayout, s.conf.Common.DeathDate)
+	if err != nil {
+		return err
+	}
+	s.deathDate = deathDate
+	for _, events := range [][]config.Event{s.scenarioEvents, s.commonEvents} {
+		for i := range events {
+			event := &events[i]
+			if !event.Anchored() {
+				continue
+			}
+			for _, anchor := range []*config.DateAnchor{event.StartAnchor, event.EndAnchor} {
+				if anchor == nil {
+					continue
+				}
+				ref, err := anchor.Ref()
+				if err != nil {
+					return fmt.Errorf("event %s: %w", event.Name, err)
+				}
+				if !s.exists(ref) {
+					return fmt.Errorf("event %s: anchor %q refers to an unknown %s in scenario %s", event.Name, anchor.After, ref.Kind, scenarioName)
+				}
+			}
+			s.anchored = append(s.anchored, &anchoredEvent{event: event})
+		}
+	}
+	return nil
+}
+
+func anyAnchored(events []config.Event) bool {
+	for _, event := range events {
+		if event.Anchored() {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneEvents(events []config.Event) []config.Event {
+	if events == nil {
+		return nil
+	}
+	clones := make([]config.Event, len(events))
+	for i, event := range events {
+		clones[i] = event.Clone()
+	}
+	return clones
+}
+
+func cloneInvestments(investments []config.Investment) []config.Investment {
+	if investments == nil {
+		return nil
+	}
+	clones := make([]config.Investment, len(investments))
+	for i, investment := range investments {
+		clones[i] = investment.Clone()
+	}
+	return clones
+}
+
+// resolve reschedules anchored events whose milestones moved and reports
+// whether any schedule changed. Chained anchors settle within one call.
+func (s *eventSchedule) resolve() (bool, error) {
+	changed := false
+	for pass := 0; pass <= len(s.anchored); pass++ {
+		passChanged := false
+		for _, a := range s.anchored {
+			active, start, end := s.window(a.event)
+			if a.scheduled && active == a.active && start.Equal(a.start) && end.Equal(a.end) {
+				continue
+			}
+			a.scheduled, a.active, a.start, a.end = true, active, start, end
+			if !active {
+				a.event.DateList, a.event.AmountList = []time.Time{}, nil
+			} else if err := a.event.FormAnchoredDateList(s.conf, s.fixedTime, start, end); err != nil {
+				return changed, err
+			}
+			if after, ok := s.stopped[a.event]; ok {
+				truncateEvent(a.event, after)
+			}
+			passChanged = true
+		}
+		if !passChanged {
+			break
+		}
+		changed = true
+	}
+	if changed {
+		s.applyPercentages()
+	}
+	return changed, nil
+}
+
+// window returns the anchored event's start and end months. An event waits
+// while its start milestone is unknown and runs until the deathDate while
+// its end milestone is unknown.
+func (s *eventSchedule) window(event *config.Event) (bool, time.Time, time.Time) {
+	var start time.Time
+	end := s.deathDate
+	if event.StartAnchor != nil {
+		date, ok := s.milestone(*event.StartAnchor)
+		if !ok {
+			return false, time.Time{}, time.Time{}
+		}
+		start = date
+	}
+	if event.EndAnchor != nil {
+		if date, ok := s.milestone(*event.EndAnchor); ok {
+			end = date
+		}
+	}
+	return true, start, end
+}
+
+// milestone returns the anchored month, or false while it is not yet known.
+func (s *eventSchedule) milestone(anchor config.DateAnchor) (time.Time, bool) {
+	ref, err := anchor.Ref()
+	if err != nil {
+		return time.Time{}, false
+	}
+	var date time.Time
+	switch ref.Kind {
+	case config.AnchorKindEvent:
+		event := s.findEvent(ref.Name)
+		if event == nil || len(event.DateList) == 0 {
+			return time.Time{}, false
+		}
+		date = event.DateList[0]
+		if ref.Milestone == config.AnchorMilestoneEnd {
+			date = event.DateList[len(event.DateList)-1]
+		}
+	case config.AnchorKindLoan:
+		loan := s.findLoan(ref.Name)
+		if loan == nil {
+			return time.Time{}, false
+		}
+		var value string
+		if ref.Milestone == config.AnchorMilestonePayoff {
+			payoff, paid := loan.PayoffDate()
+			if !paid {
+				return time.Time{}, false
+			}
+			value = payoff
+		} else if dates := loan.PaymentDates(); len(dates) > 0 {
+			value = dates[0]
+		} else {
+			return time.Time{}, false
+		}
+		date, err = time.Parse(config.DateTimeLayout, value)
+		if err != nil {
+			return time.Time{}, false
+		}
+	}
+	return date.AddDate(0, anchor.Offset, 0), true
+}
+
+// stop ends the named event after the given month and reports whether the
+// event exists.
+func (s *eventSchedule) stop(name string, after time.Time) bool {
+	event := s.findEvent(name)
+	if event == nil {
+		return false
+	}
+	s.stopped[event] = after
+	truncateEvent(event, after)
+	s.applyPercentages()
+	return true
+}
+
+// truncateEvent drops the event's occurrences after the given month.
+func truncateEvent(event *config.Event, after time.Time) {
+	for i, date := range event.DateList {
+		if date.After(after) {
+			event.DateList = event.DateList[:i]
+			if len(event.AmountList) > i {
+				event.AmountList = event.AmountList[:i]
+			}
+			return
+		}
+	}
+}
+
+func (s *eventSchedule) exists(ref config.AnchorRef) bool {
+	if ref.Kind == config.AnchorKindLoan {
+		return s.findLoan(ref.Name) != nil
+	}
+	return s.findEvent(ref.Name) != nil
+}
+
+// findEvent returns the named event, preferring the scenario's over a common
+// event of the same name.
+func (s *eventSchedule) findEvent(name string) *config.Event {
+	for _, events := range [][]config.Event{s.scenarioEvents, s.commonEvents} {
+		for i := range events {
+			if events[i].Name == name {
+				return &events[i]
+			}
+		}
+	}
+	return nil
+}
+
+// findLoan returns the named loan, preferring the scenario's over a common
+// loan of the same name.
+func (s *eventSchedule) findLoan(name string) *config.Loan {
+	for _, loans := range [][]config.Loan{s.scenarioLoans, s.commonLoans} {
+		for i := range loans {
+			if loans[i].Name == name {
+				return &loans[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+	"github.com/iwvelando/finance-forecast/pkg/finance"
+	"github.com/iwvelando/finance-forecast/pkg/tax"
+)
+
+// activeTrigger is a trigger resolved against one scenario's investments,
+// loans, and events.
+type activeTrigger struct {
+	config.Trigger
+	kind     string
+	watched  investmentSource
+	loan     *config.Loan
+	transfer investmentSource
+	deposit  bool // whether the transfer moves cash into the investment
+	fired    bool
+}
+
+// triggerEffect is what firing a trigger changed.
+type triggerEffect struct {
+	scope           string  // scope of the investment a transfer touched
+	investmentDelta float64 // change in that scope's investment total
+	sale            finance.InvestmentChange
+	stopped         bool
+	note            string
+}
+
+// buildTriggers resolves the scenario's triggers followed by the common ones.
+func buildTriggers(scenario config.Scenario, common []config.Trigger, findInvestment func(string) (investmentSource, bool), schedule *eventSchedule) ([]*activeTrigger, error) {
+	var triggers []*activeTrigger
+	for _, trigger := range append(append([]config.Trigger(nil), scenario.Triggers...), common...) {
+		kind, name, err := trigger.When.Source()
+		if err != nil {
+			return nil, fmt.Errorf("trigger %s: %w", trigger.Name, err)
+		}
+		active := &activeTrigger{Trigger: trigger, kind: kind}
+		switch kind {
+		case config.TriggerBalanceInvestment:
+			source, ok := findInvestment(name)
+			if !ok {
+				return nil, fmt.Errorf("trigger %s: unknown investment %q in scenario %s", trigger.Name, name, scenario.Name)
+			}
+			active.watched = source
+		case config.TriggerBalanceLoan:
+			active.loan = schedule.findLoan(name)
+			if active.loan == nil {
+				return nil, fmt.Errorf("trigger %s: unknown loan %q in scenario %s", trigger.Name, name, scenario.Name)
+			}
+		}
+		if trigger.Transfer != nil {
+			account, deposit := trigger.Transfer.To, true
+			if account == "" {
+				account, deposit = trigger.Transfer.From, false
+			}
+			source, ok := findInvestment(account)
+			if !ok {
+				return nil, fmt.Errorf("trigger %s: unknown investment %q in scenario %s", trigger.Name, account, scenario.Name)
+			}
+			active.transfer = source
+			active.deposit = deposit
+		}
+		if trigger.StopEvent != "" && schedule.findEvent(trigger.StopEvent) == nil {
+			return nil, fmt.Errorf("trigger %s: unknown event %q in scenario %s", trigger.Name, trigger.StopEvent, scenario.Name)
+		}
+		triggers = append(triggers, active)
+	}
+	return triggers, nil
+}
+
+// balance returns the month-end value the trigger watches.
+func (t *activeTrigger) balance(date string, liquid, total float64) float64 {
+	switch t.kind {
+	case config.TriggerBalanceLiquid:
+		return liquid
+	case config.TriggerBalanceTotal:
+		return total
+	case config.TriggerBalanceInvestment:
+		return t.watched.state.CurrentValue
+	default:
+		return t.loan.BalanceAt(date)
+	}
+}
+
+// ready reports whether the trigger fires given the watched value.
+func (t *activeTrigger) ready(value float64) bool {
+	return (!t.fired || t.Repeat) && t.When.Met(value)
+}
+
+// fire applies the trigger's transfer and stopEvent actions. Transfers into an
+// investment are capped at the cash on hand; sales follow the investment's
+// withdrawal tax treatment and count toward taxable income.
+func (t *activeTrigger) fire(engine *finance.ForecastEngine, ledger *finance.CashLedger, taxEngine *tax.Engine, schedule *eventSchedule, date time.Time, value float64) (triggerEffect, error) {
+	t.fired = true
+	effect := triggerEffect{scope: t.transfer.scope}
+	parts := []string{fmt.Sprintf("trigger %s: %s at %.2f", t.Name, t.When, value)}
+
+	if t.Transfer != nil && t.deposit {
+		amount := math.Min(t.Transfer.Amount, math.Max(ledger.Total(), 0))
+		if amount > 0 {
+			if err := ledger.Deposit("", -amount); err != nil {
+				return effect, err
+			}
+			t.transfer.state.Deposit(amount)
+			effect.investmentDelta = amount
+			parts = append(parts, fmt.Sprintf("transferred %.2f to %s", amount, t.Transfer.To))
+		}
+	}
+	if t.Transfer != nil && !t.deposit {
+		change := engine.Sell(t.transfer.investment, t.transfer.state, t.Transfer.Amount)
+		if change.Withdrawal > 0 {
+			if err := ledger.Deposit("", change.Withdrawal-change.WithdrawalTax); err != nil {
+				return effect, err
+			}
+			if taxEngine != nil {
+				taxEngine.AddIncome(change.TaxableWithdrawal)
+			}
+			effect.investmentDelta = -change.Withdrawal
+			effect.sale = change
+			note := fmt.Sprintf("transferred %.2f from %s", change.Withdrawal, t.Transfer.From)
+			if change.WithdrawalTax != 0 {
+				note += fmt.Sprintf(" (withdrawal tax %.2f)", change.WithdrawalTax)
+			}
+			parts = append(parts, note)
+		}
+	}
+	if t.StopEvent != "" {
+		schedule.stop(t.StopEvent, date)
+		effect.stopped = true
+		parts = append(parts, fmt.Sprintf("stopped %s", t.StopEvent))
+	}
+
+	effect.note = strings.Join(parts, ", ")
+	return effect, nil
+}
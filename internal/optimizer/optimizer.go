@@ -9,6 +9,7 @@ import (
 
 	"github.com/iwvelando/finance-forecast/internal/config"
 	"github.com/iwvelando/finance-forecast/internal/forecast"
+	"github.com/iwvelando/finance-forecast/pkg/constants"
 	formatutil "github.com/iwvelando/finance-forecast/pkg/format"
 	"github.com/iwvelando/finance-forecast/pkg/mathutil"
 	"github.com/iwvelando/finance-forecast/pkg/optimization"
@@ -652,9 +653,9 @@ func boundsForField(cfg *config.OptimizerConfig) (float64, float64, error) {
 			return 0, 0, fmt.Errorf("optimizer field %s requires numeric min and max values", config.OptimizerFieldAmount)
 		}
 		return *cfg.Min, *cfg.Max, nil
-	case config.OptimizerFieldFrequency:
+	case config.OptimizerFieldFrequency, config.OptimizerFieldClaimingAge:
 		if cfg.Min == nil || cfg.Max == nil {
-			return 0, 0, fmt.Errorf("optimizer field %s requires numeric min and max values", config.OptimizerFieldFrequency)
+			return 0, 0, fmt.Errorf("optimizer field %s requires numeric min and max values", field)
 		}
 		return *cfg.Min, *cfg.Max, nil
 	case config.OptimizerFieldStartDate:
@@ -702,6 +703,12 @@ func getEventFieldState(event *config.Event, field string) (fieldState, error) {
 			return fieldState{}, err
 		}
 		return fieldState{numeric: float64(index), display: event.StartDate}, nil
+	case config.OptimizerFieldClaimingAge:
+		if event.SocialSecurity == nil {
+			return fieldState{}, fmt.Errorf("event %s requires socialSecurity to optimize claimingAge", event.Name)
+		}
+		value := snapFieldValue(normalized, event.SocialSecurity.ClaimingAge)
+		return fieldState{numeric: value, display: formatClaimingAge(value)}, nil
 	case config.OptimizerFieldEndDate:
 		if strings.TrimSpace(event.EndDate) == "" {
 			return fieldState{}, fmt.Errorf("event %s requires an endDate to optimize", event.Name)
@@ -734,6 +741,8 @@ func (r *Runner) setEventFieldValue(target eventTarget, value float64) (func(),
 		event.Amount = rounded
 		restore = func() { event.Amount = previous }
 		state = fieldState{numeric: rounded, display: formatutil.Currency(rounded)}
+		// Grown amounts are derived from Amount and must be rebuilt.
+		needSchedule = event.AmountList != nil
 	case config.OptimizerFieldFrequency:
 		previous := event.Frequency
 		rounded := int(math.Round(value))
@@ -766,6 +775,16 @@ func (r *Runner) setEventFieldValue(target eventTarget, value float64) (func(),
 		restore = func() { event.EndDate = previous }
 		state = fieldState{numeric: float64(index), display: formatted}
 		needSchedule = true
+	case config.OptimizerFieldClaimingAge:
+		if event.SocialSecurity == nil {
+			return nil, fieldState{}, fmt.Errorf("event %s requires socialSecurity to optimize claimingAge", event.Name)
+		}
+		previous := event.SocialSecurity.ClaimingAge
+		age := snapFieldValue(normalized, value)
+		event.SocialSecurity.ClaimingAge = age
+		restore = func() { event.SocialSecurity.ClaimingAge = previous }
+		state = fieldState{numeric: age, display: formatClaimingAge(age)}
+		needSchedule = true
 	default:
 		return nil, fieldState{}, fmt.Errorf("optimizer field %q is not supported", target.field)
 	}
@@ -799,6 +818,8 @@ func snapFieldValue(field string, value float64) float64 {
 		return mathutil.Round(value)
 	case config.OptimizerFieldFrequency, config.OptimizerFieldStartDate, config.OptimizerFieldEndDate:
 		return math.Round(value)
+	case config.OptimizerFieldClaimingAge:
+		return math.Round(value*constants.MonthsPerYear) / constants.MonthsPerYear
 	default:
 		return value
 	}
@@ -822,11 +843,22 @@ func formatFieldDisplay(field string, value float64) string {
 		return fmt.Sprintf("%d", int(math.Round(value)))
 	case config.OptimizerFieldStartDate, config.OptimizerFieldEndDate:
 		return monthIndexToString(int(math.Round(value)))
+	case config.OptimizerFieldClaimingAge:
+		return formatClaimingAge(value)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
 }
 
+// formatClaimingAge renders an age in years as whole years and months.
+func formatClaimingAge(age float64) string {
+	months := int(math.Round(age * constants.MonthsPerYear))
+	if months%constants.MonthsPerYear == 0 {
+		return fmt.Sprintf("%d", months/constants.MonthsPerYear)
+	}
+	return fmt.Sprintf("%dy %dm", months/constants.MonthsPerYear, months%constants.MonthsPerYear)
+}
+
 func monthIndexFromString(value string) (int, error) {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
@@ -222,6 +222,82 @@ func TestRunnerSetEventFieldValueRefreshesSchedule(t *testing.T) {
 	}
 }
 
+func TestRunnerClaimingAgeRefreshesSocialSecuritySchedule(t *testing.T) {
+	conf := &config.Configuration{
+		StartDate:       "2025-01",
+		Recommendations: config.RecommendationsConfig{EmergencyFundMonths: 6},
+		Household:       &config.Household{Members: []config.Member{{Name: "Alex", BirthDate: "1960-01"}}},
+		Common: config.Common{
+			StartingValue: 5000,
+			DeathDate:     "2035-12",
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Claiming",
+				Active: true,
+				Events: []config.Event{
+					{
+						Name:           "Social Security",
+						SocialSecurity: &config.SocialSecurity{PIA: 2000, ClaimingAge: 67, COLA: floatPtr(0)},
+						Optimizer: &config.OptimizerConfig{
+							Field: config.OptimizerFieldClaimingAge,
+							Min:   floatPtr(62),
+							Max:   floatPtr(70),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	startTime, err := time.Parse(config.DateTimeLayout, conf.StartDate)
+	if err != nil {
+		t.Fatalf("parse start date: %v", err)
+	}
+	if err := conf.ParseDateListsWithFixedTime(startTime); err != nil {
+		t.Fatalf("parse date lists: %v", err)
+	}
+
+	runner, err := NewRunner(zap.NewNop(), conf)
+	if err != nil {
+		t.Fatalf("create runner: %v", err)
+	}
+
+	event := &conf.Scenarios[0].Events[0]
+	originalDates := append([]time.Time(nil), event.DateList...)
+	target := eventTarget{
+		scenarioIndex: 0,
+		eventIndex:    0,
+		scenarioName:  conf.Scenarios[0].Name,
+		event:         event,
+		field:         config.OptimizerFieldClaimingAge,
+		minValue:      62,
+		maxValue:      70,
+	}
+
+	restore, state, err := runner.setEventFieldValue(target, 68.4)
+	if err != nil {
+		t.Fatalf("set event field value: %v", err)
+	}
+	if state.display != "68y 5m" {
+		t.Fatalf("expected claiming age snapped to 68y 5m, got %s", state.display)
+	}
+	if got := event.DateList[0].Format(config.DateTimeLayout); got != "2028-06" {
+		t.Fatalf("expected benefits to start 2028-06, got %s", got)
+	}
+	if math.Abs(event.AmountList[0]-2000*(1+17.0*2/3/100)) > 0.01 {
+		t.Fatalf("expected delayed credits in benefit, got %.2f", event.AmountList[0])
+	}
+
+	restore()
+	if event.SocialSecurity.ClaimingAge != 67 {
+		t.Fatalf("expected claiming age restored to 67, got %.2f", event.SocialSecurity.ClaimingAge)
+	}
+	if !reflect.DeepEqual(event.DateList, originalDates) {
+		t.Fatalf("expected date list to be restored")
+	}
+}
+
 func TestRunnerEndDateOptimizerExtendsExpenseWhenFeasible(t *testing.T) {
 	t.Helper()
 
@@ -17,6 +17,7 @@ import (
 	"github.com/iwvelando/finance-forecast/internal/config"
 	"github.com/iwvelando/finance-forecast/internal/forecast"
 	"github.com/iwvelando/finance-forecast/internal/optimizer"
+	"github.com/iwvelando/finance-forecast/internal/simulation"
 	"github.com/iwvelando/finance-forecast/pkg/constants"
 	"github.com/iwvelando/finance-forecast/pkg/output"
 	"go.uber.org/zap"
@@ -33,7 +34,9 @@ type handler struct {
 }
 
 type forecastOptions struct {
-	Optimize bool
+	Optimize   bool
+	MonteCarlo int
+	Backtest   bool
 }
 
 // NewHandler constructs the HTTP handler that serves the web UI and forecast API.
@@ -87,22 +90,142 @@ type forecastResponse struct {
 	Duration   string                 `json:"duration"`
 	Config     map[string]interface{} `json:"config,omitempty"`
 	ConfigYAML string                 `json:"configYaml,omitempty"`
+	MonteCarlo *monteCarloPayload     `json:"monteCarlo,omitempty"`
+	Backtest   *backtestPayload       `json:"backtest,omitempty"`
+	Sweeps     []sweepPayload         `json:"sweeps,omitempty"`
+}
+
+// sweepPayload lays out a sweep as matrices indexed [row][column], with rows
+// following the first axis and columns the second.
+type sweepPayload struct {
+	Name          string            `json:"name"`
+	Scenario      string            `json:"scenario"`
+	Rows          sweepAxisPayload  `json:"rows"`
+	Columns       *sweepAxisPayload `json:"columns,omitempty"`
+	Scenarios     [][]string        `json:"scenarios"`
+	EndNetWorth   [][]float64       `json:"endNetWorth"`
+	MinLiquid     [][]float64       `json:"minLiquid"`
+	DepletionDate [][]string        `json:"depletionDate"`
+}
+
+type sweepAxisPayload struct {
+	Label  string    `json:"label"`
+	Values []float64 `json:"values"`
+}
+
+type backtestPayload struct {
+	StartYears []int                     `json:"startYears"`
+	Scenarios  []backtestScenarioPayload `json:"scenarios"`
+}
+
+type backtestScenarioPayload struct {
+	Name        string              `json:"name"`
+	Windows     int                 `json:"windows"`
+	Successes   int                 `json:"successes"`
+	SuccessRate float64             `json:"successRate"`
+	Worst       backtestPathPayload `json:"worst"`
+	Median      backtestPathPayload `json:"median"`
+}
+
+type backtestPathPayload struct {
+	StartYear   int           `json:"startYear"`
+	EndingTotal float64       `json:"endingTotal"`
+	Rows        []backtestRow `json:"rows"`
+}
+
+type backtestRow struct {
+	Date   string  `json:"date"`
+	Liquid float64 `json:"liquid"`
+	Total  float64 `json:"total"`
+}
+
+type monteCarloPayload struct {
+	Iterations int                         `json:"iterations"`
+	Seed       int64                       `json:"seed"`
+	Scenarios  []monteCarloScenarioPayload `json:"scenarios"`
+}
+
+type monteCarloScenarioPayload struct {
+	Name                      string          `json:"name"`
+	ProbabilityLiquidNegative float64         `json:"probabilityLiquidNegative"`
+	ProbabilityTotalNegative  float64         `json:"probabilityTotalNegative"`
+	Rows                      []monteCarloRow `json:"rows"`
+}
+
+type monteCarloRow struct {
+	Date   string          `json:"date"`
+	Liquid *monteCarloBand `json:"liquid,omitempty"`
+	Total  *monteCarloBand `json:"total,omitempty"`
+}
+
+type monteCarloBand struct {
+	P10 float64 `json:"p10"`
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
 }
 
 type forecastRow struct {
 	Date   string          `json:"date"`
+	Ages   map[string]int  `json:"ages,omitempty"`
 	Values []scenarioValue `json:"values"`
 }
 
 type scenarioValue struct {
-	Liquid *float64 `json:"liquid,omitempty"`
-	Total  *float64 `json:"total,omitempty"`
-	Notes  []string `json:"notes,omitempty"`
+	Liquid       *float64           `json:"liquid,omitempty"`
+	Total        *float64           `json:"total,omitempty"`
+	Liabilities  *float64           `json:"liabilities,omitempty"`
+	NetWorth     *float64           `json:"netWorth,omitempty"`
+	RealLiquid   *float64           `json:"realLiquid,omitempty"`
+	RealTotal    *float64           `json:"realTotal,omitempty"`
+	RealNetWorth *float64           `json:"realNetWorth,omitempty"`
+	Accounts     map[string]float64 `json:"accounts,omitempty"`
+	Notes        []string           `json:"notes,omitempty"`
 }
 
 type scenarioMetrics struct {
 	EmergencyFund *emergencyFundMetric `json:"emergencyFund,omitempty"`
 	Optimizations []optimizationMetric `json:"optimizations,omitempty"`
+	Taxes         *taxMetric           `json:"taxes,omitempty"`
+	DebtPayoff    []debtPayoffMetric   `json:"debtPayoff,omitempty"`
+}
+
+type debtPayoffMetric struct {
+	Scope         string                 `json:"scope"`
+	Strategy      string                 `json:"strategy"`
+	Budget        float64                `json:"budget"`
+	DebtFree      string                 `json:"debtFree,omitempty"`
+	Interest      float64                `json:"interest"`
+	InterestSaved float64                `json:"interestSaved"`
+	Loans         []debtPayoffLoanMetric `json:"loans"`
+	Comparison    []debtStrategyMetric   `json:"comparison,omitempty"`
+}
+
+type debtPayoffLoanMetric struct {
+	Name             string  `json:"name"`
+	Payoff           string  `json:"payoff,omitempty"`
+	BaselinePayoff   string  `json:"baselinePayoff,omitempty"`
+	Interest         float64 `json:"interest"`
+	BaselineInterest float64 `json:"baselineInterest"`
+}
+
+type debtStrategyMetric struct {
+	Strategy      string  `json:"strategy"`
+	DebtFree      string  `json:"debtFree,omitempty"`
+	Interest      float64 `json:"interest"`
+	InterestSaved float64 `json:"interestSaved"`
+}
+
+type taxMetric struct {
+	Total   float64         `json:"total"`
+	Federal float64         `json:"federal"`
+	State   float64         `json:"state"`
+	Years   []taxYearMetric `json:"years,omitempty"`
+}
+
+type taxYearMetric struct {
+	Year    int     `json:"year"`
+	Federal float64 `json:"federal"`
+	State   float64 `json:"state"`
 }
 
 type optimizationMetric struct {
@@ -176,7 +299,24 @@ func (h *handler) handleForecast(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.runForecast(w, configBytes, configMap, start, "server.handleForecast", forecastOptions{})
+	options := forecastOptions{}
+	if raw := strings.TrimSpace(r.FormValue("monteCarlo")); raw != "" {
+		iterations, err := strconv.Atoi(raw)
+		if err != nil || iterations < 0 {
+			h.respondError(w, http.StatusBadRequest, fmt.Sprintf("invalid monteCarlo value %q", raw))
+			return
+		}
+		if iterations > constants.MaxMonteCarloIterations {
+			h.respondError(w, http.StatusBadRequest, fmt.Sprintf("monteCarlo value %d exceeds the maximum of %d", iterations, constants.MaxMonteCarloIterations))
+			return
+		}
+		options.MonteCarlo = iterations
+	}
+	if raw := r.FormValue("backtest"); raw != "" {
+		options.Backtest = coerceBool(raw)
+	}
+
+	h.runForecast(w, configBytes, configMap, start, "server.handleForecast", options)
 }
 
 func (h *handler) handleVersion(w http.ResponseWriter, r *http.Request) {
@@ -227,6 +367,21 @@ func (h *handler) handleForecastEditor(w http.ResponseWriter, r *http.Request) {
 		if optimizeVal, ok := optsMap["optimize"]; ok {
 			options.Optimize = coerceBool(optimizeVal)
 		}
+		if monteCarloVal, ok := optsMap["monteCarlo"]; ok {
+			iterations, ok := coerceInt(monteCarloVal)
+			if !ok || iterations < 0 {
+				h.respondErrorWithOp(w, http.StatusBadRequest, fmt.Sprintf("invalid monteCarlo option: %v", monteCarloVal), "server.handleForecastEditor")
+				return
+			}
+			if iterations > constants.MaxMonteCarloIterations {
+				h.respondErrorWithOp(w, http.StatusBadRequest, fmt.Sprintf("monteCarlo option %d exceeds the maximum of %d", iterations, constants.MaxMonteCarloIterations), "server.handleForecastEditor")
+				return
+			}
+			options.MonteCarlo = iterations
+		}
+		if backtestVal, ok := optsMap["backtest"]; ok {
+			options.Backtest = coerceBool(backtestVal)
+		}
 	}
 
 	configBytes, err := yaml.Marshal(configPayload)
@@ -361,6 +516,47 @@ func (h *handler) runForecast(w http.ResponseWriter, configBytes []byte, configM
 		}
 	}
 
+	if opts.MonteCarlo > 0 {
+		cfg.MonteCarlo.Iterations = opts.MonteCarlo
+	}
+	if cfg.MonteCarlo.Iterations > constants.MaxMonteCarloIterations {
+		h.respondErrorWithOp(w, http.StatusBadRequest, fmt.Sprintf("monteCarlo.iterations %d exceeds the maximum of %d", cfg.MonteCarlo.Iterations, constants.MaxMonteCarloIterations), op)
+		return
+	}
+
+	var monteCarloResult *simulation.MonteCarloResult
+	if cfg.MonteCarlo.Iterations > 0 {
+		runner, err := simulation.NewMonteCarloRunner(h.logger, cfg, simulation.MonteCarloOptions{
+			Iterations: cfg.MonteCarlo.Iterations,
+			Seed:       cfg.MonteCarlo.Seed,
+		})
+		if err != nil {
+			h.respondErrorWithOp(w, http.StatusBadRequest, fmt.Sprintf("failed to initialize Monte Carlo simulation: %v", err), op)
+			return
+		}
+
+		monteCarloResult, err = runner.Run()
+		if err != nil {
+			h.respondErrorWithOp(w, http.StatusBadRequest, fmt.Sprintf("Monte Carlo simulation failed: %v", err), op)
+			return
+		}
+	}
+
+	var backtestResult *simulation.BacktestResult
+	if opts.Backtest {
+		runner, err := simulation.NewBacktestRunner(h.logger, cfg)
+		if err != nil {
+			h.respondErrorWithOp(w, http.StatusBadRequest, fmt.Sprintf("failed to initialize backtest: %v", err), op)
+			return
+		}
+
+		backtestResult, err = runner.Run()
+		if err != nil {
+			h.respondErrorWithOp(w, http.StatusBadRequest, fmt.Sprintf("backtest failed: %v", err), op)
+			return
+		}
+	}
+
 	results, err := forecast.GetForecast(h.logger, *cfg)
 	if err != nil {
 		h.respondErrorWithOp(w, http.StatusInternalServerError, fmt.Sprintf("failed to compute forecast: %v", err), op)
@@ -371,8 +567,14 @@ func (h *handler) runForecast(w http.ResponseWriter, configBytes []byte, configM
 		optimizationResult.Apply(results)
 	}
 
+	sweepResult, err := simulation.SummarizeSweeps(cfg, results)
+	if err != nil {
+		h.respondErrorWithOp(w, http.StatusInternalServerError, fmt.Sprintf("failed to summarize sweeps: %v", err), op)
+		return
+	}
+
 	if opts.Optimize {
-		updatedBytes, err := yaml.Marshal(cfg)
+		updatedBytes, err := yaml.Marshal(cfg.Compact())
 		if err != nil {
 			if h.logger != nil {
 				h.logger.Warn("failed to marshal optimized configuration",
@@ -408,6 +610,9 @@ func (h *handler) runForecast(w http.ResponseWriter, configBytes []byte, configM
 		Duration:   elapsed.String(),
 		Config:     configMap,
 		ConfigYAML: string(configBytes),
+		MonteCarlo: buildMonteCarlo(monteCarloResult),
+		Backtest:   buildBacktest(backtestResult),
+		Sweeps:     buildSweeps(sweepResult),
 	}
 
 	if h.logger != nil {
@@ -486,29 +691,23 @@ func buildRows(results []forecast.Forecast) []forecastRow {
 
 	rows := make([]forecastRow, 0, len(dates))
 	for _, date := range dates {
-		row := forecastRow{Date: date}
+		row := forecastRow{Date: date, Ages: memberAges(results, date)}
 		for _, scenario := range results {
-			liquidVal, liquidOK := scenario.Liquid[date]
-			totalVal, totalOK := scenario.Data[date]
+			liquidPtr := valuePointer(scenario.Liquid, date)
+			totalPtr := valuePointer(scenario.Data, date)
 			notes := scenario.Notes[date]
 
-			var liquidPtr *float64
-			if liquidOK {
-				v := liquidVal
-				liquidPtr = &v
-			}
-
-			var totalPtr *float64
-			if totalOK {
-				v := totalVal
-				totalPtr = &v
-			}
-
 			if liquidPtr != nil || totalPtr != nil || len(notes) > 0 {
 				row.Values = append(row.Values, scenarioValue{
-					Liquid: liquidPtr,
-					Total:  totalPtr,
-					Notes:  normalizeNotes(notes),
+					Liquid:       liquidPtr,
+					Total:        totalPtr,
+					Liabilities:  valuePointer(scenario.Liabilities, date),
+					NetWorth:     valuePointer(scenario.NetWorth, date),
+					RealLiquid:   valuePointer(scenario.RealLiquid, date),
+					RealTotal:    valuePointer(scenario.RealData, date),
+					RealNetWorth: valuePointer(scenario.RealNetWorth, date),
+					Accounts:     accountBalances(scenario.Accounts, date),
+					Notes:        normalizeNotes(notes),
 				})
 			} else {
 				row.Values = append(row.Values, scenarioValue{})
@@ -520,6 +719,50 @@ func buildRows(results []forecast.Forecast) []forecastRow {
 	return rows
 }
 
+// memberAges returns each household member's age for date, or nil when no
+// household is configured. Ages are the same in every scenario.
+func memberAges(results []forecast.Forecast, date string) map[string]int {
+	for _, scenario := range results {
+		if len(scenario.Ages) == 0 {
+			continue
+		}
+		ages := make(map[string]int, len(scenario.Ages))
+		for _, member := range scenario.Ages {
+			if age, ok := member.Ages[date]; ok {
+				ages[member.Name] = age
+			}
+		}
+		if len(ages) > 0 {
+			return ages
+		}
+	}
+	return nil
+}
+
+// accountBalances returns each cash account's balance for date, or nil when no
+// accounts are configured.
+func accountBalances(accounts []forecast.AccountSeries, date string) map[string]float64 {
+	if len(accounts) == 0 {
+		return nil
+	}
+	balances := make(map[string]float64, len(accounts))
+	for _, account := range accounts {
+		if balance, ok := account.Balances[date]; ok {
+			balances[account.Name] = balance
+		}
+	}
+	return balances
+}
+
+// valuePointer returns a copy of the value for date, or nil when it is missing.
+func valuePointer(values map[string]float64, date string) *float64 {
+	value, ok := values[date]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
 func buildMetrics(results []forecast.Forecast) []scenarioMetrics {
 	if len(results) == 0 {
 		return nil
@@ -557,12 +800,169 @@ func buildMetrics(results []forecast.Forecast) []scenarioMetrics {
 			}
 			scenarioMetric.Optimizations = summaries
 		}
+		if taxes := scenario.Metrics.Taxes; taxes != nil {
+			metric := &taxMetric{Total: taxes.Total(), Federal: taxes.Federal, State: taxes.State}
+			for _, year := range taxes.Years {
+				metric.Years = append(metric.Years, taxYearMetric{Year: year.Year, Federal: year.Federal, State: year.State})
+			}
+			scenarioMetric.Taxes = metric
+		}
+		for _, plan := range scenario.Metrics.DebtPayoff {
+			debtFree, _ := plan.Report.DebtFree()
+			metric := debtPayoffMetric{
+				Scope:         plan.Scope,
+				Strategy:      plan.Report.Strategy,
+				Budget:        plan.Budget,
+				DebtFree:      debtFree,
+				Interest:      plan.Report.Interest(),
+				InterestSaved: plan.Report.InterestSaved(),
+			}
+			for _, loan := range plan.Report.Loans {
+				metric.Loans = append(metric.Loans, debtPayoffLoanMetric{
+					Name:             loan.Name,
+					Payoff:           loan.Payoff,
+					BaselinePayoff:   loan.BaselinePayoff,
+					Interest:         loan.Interest,
+					BaselineInterest: loan.BaselineInterest,
+				})
+			}
+			for _, report := range plan.Comparison {
+				debtFree, _ := report.DebtFree()
+				metric.Comparison = append(metric.Comparison, debtStrategyMetric{
+					Strategy:      report.Strategy,
+					DebtFree:      debtFree,
+					Interest:      report.Interest(),
+					InterestSaved: report.InterestSaved(),
+				})
+			}
+			scenarioMetric.DebtPayoff = append(scenarioMetric.DebtPayoff, metric)
+		}
 		metrics = append(metrics, scenarioMetric)
 	}
 
 	return metrics
 }
 
+func buildMonteCarlo(result *simulation.MonteCarloResult) *monteCarloPayload {
+	if result == nil {
+		return nil
+	}
+
+	payload := &monteCarloPayload{
+		Iterations: result.Iterations,
+		Seed:       result.Seed,
+		Scenarios:  make([]monteCarloScenarioPayload, 0, len(result.Scenarios)),
+	}
+
+	for _, scenario := range result.Scenarios {
+		dateSet := make(map[string]struct{}, len(scenario.Total))
+		for date := range scenario.Total {
+			dateSet[date] = struct{}{}
+		}
+		for date := range scenario.Liquid {
+			dateSet[date] = struct{}{}
+		}
+		dates := make([]string, 0, len(dateSet))
+		for date := range dateSet {
+			dates = append(dates, date)
+		}
+		sort.Strings(dates)
+
+		rows := make([]monteCarloRow, 0, len(dates))
+		for _, date := range dates {
+			row := monteCarloRow{Date: date}
+			if band, ok := scenario.Liquid[date]; ok {
+				row.Liquid = &monteCarloBand{P10: band.P10, P50: band.P50, P90: band.P90}
+			}
+			if band, ok := scenario.Total[date]; ok {
+				row.Total = &monteCarloBand{P10: band.P10, P50: band.P50, P90: band.P90}
+			}
+			rows = append(rows, row)
+		}
+
+		payload.Scenarios = append(payload.Scenarios, monteCarloScenarioPayload{
+			Name:                      scenario.Name,
+			ProbabilityLiquidNegative: scenario.ProbabilityLiquidNegative,
+			ProbabilityTotalNegative:  scenario.ProbabilityTotalNegative,
+			Rows:                      rows,
+		})
+	}
+
+	return payload
+}
+
+func buildBacktest(result *simulation.BacktestResult) *backtestPayload {
+	if result == nil {
+		return nil
+	}
+
+	payload := &backtestPayload{
+		StartYears: result.StartYears,
+		Scenarios:  make([]backtestScenarioPayload, 0, len(result.Scenarios)),
+	}
+	for _, scenario := range result.Scenarios {
+		payload.Scenarios = append(payload.Scenarios, backtestScenarioPayload{
+			Name:        scenario.Name,
+			Windows:     scenario.Windows,
+			Successes:   scenario.Successes,
+			SuccessRate: scenario.SuccessRate,
+			Worst:       buildBacktestPath(scenario.Worst),
+			Median:      buildBacktestPath(scenario.Median),
+		})
+	}
+	return payload
+}
+
+func buildSweeps(result *simulation.SweepResult) []sweepPayload {
+	if result == nil {
+		return nil
+	}
+
+	payloads := make([]sweepPayload, 0, len(result.Sweeps))
+	for _, grid := range result.Sweeps {
+		payload := sweepPayload{
+			Name:          grid.Name,
+			Scenario:      grid.Scenario,
+			Rows:          sweepAxisPayload{Label: grid.Rows.Label, Values: grid.Rows.Values},
+			Scenarios:     make([][]string, len(grid.Cells)),
+			EndNetWorth:   make([][]float64, len(grid.Cells)),
+			MinLiquid:     make([][]float64, len(grid.Cells)),
+			DepletionDate: make([][]string, len(grid.Cells)),
+		}
+		if len(grid.Columns.Values) > 0 {
+			payload.Columns = &sweepAxisPayload{Label: grid.Columns.Label, Values: grid.Columns.Values}
+		}
+		for i, row := range grid.Cells {
+			for _, cell := range row {
+				payload.Scenarios[i] = append(payload.Scenarios[i], cell.Scenario)
+				payload.EndNetWorth[i] = append(payload.EndNetWorth[i], cell.EndNetWorth)
+				payload.MinLiquid[i] = append(payload.MinLiquid[i], cell.MinLiquid)
+				payload.DepletionDate[i] = append(payload.DepletionDate[i], cell.DepletionDate)
+			}
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads
+}
+
+func buildBacktestPath(path simulation.BacktestPath) backtestPathPayload {
+	dates := make([]string, 0, len(path.Total))
+	for date := range path.Total {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	rows := make([]backtestRow, 0, len(dates))
+	for _, date := range dates {
+		rows = append(rows, backtestRow{Date: date, Liquid: path.Liquid[date], Total: path.Total[date]})
+	}
+	return backtestPathPayload{
+		StartYear:   path.StartYear,
+		EndingTotal: path.EndingTotal,
+		Rows:        rows,
+	}
+}
+
 func normalizeNotes(notes []string) []string {
 	if len(notes) == 0 {
 		return nil
@@ -605,3 +1005,36 @@ func coerceBool(value interface{}) bool {
 	}
 	return false
 }
+
+func coerceInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false
+		}
+		return int(v), true
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" {
+			return 0, true
+		}
+		parsed, err := strconv.Atoi(trimmed)
+		if err != nil {
+			return 0, false
+		}
+		return parsed, true
+	case json.Number:
+		parsed, err := strconv.Atoi(v.String())
+		if err != nil {
+			return 0, false
+		}
+		return parsed, true
+	case nil:
+		return 0, true
+	}
+	return 0, false
+}
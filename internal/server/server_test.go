@@ -3,11 +3,14 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -120,6 +123,389 @@ func TestHandleForecastEditorSuccess(t *testing.T) {
 	}
 }
 
+func TestHandleForecastEditorMonteCarlo(t *testing.T) {
+	handler := NewHandler(zap.NewNop(), constants.DefaultMaxUploadSizeBytes, "test-version")
+
+	configPath := filepath.Join("..", "..", "test", "test_config.yaml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read test config: %v", err)
+	}
+
+	var configPayload map[string]interface{}
+	if err := yaml.Unmarshal(data, &configPayload); err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+	configPayload["monteCarlo"] = map[string]interface{}{"seed": 11}
+
+	payload := map[string]interface{}{
+		"config":  configPayload,
+		"options": map[string]interface{}{"monteCarlo": 3},
+	}
+	rr := performEditorJSON(t, handler, payload, "/api/editor/forecast")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp forecastResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.MonteCarlo == nil {
+		t.Fatal("expected Monte Carlo results in response")
+	}
+	if resp.MonteCarlo.Iterations != 3 || resp.MonteCarlo.Seed != 11 {
+		t.Fatalf("unexpected Monte Carlo metadata: iterations=%d seed=%d", resp.MonteCarlo.Iterations, resp.MonteCarlo.Seed)
+	}
+	if len(resp.MonteCarlo.Scenarios) != len(resp.Scenarios) {
+		t.Fatalf("expected Monte Carlo results for each scenario, got %d", len(resp.MonteCarlo.Scenarios))
+	}
+	rows := resp.MonteCarlo.Scenarios[0].Rows
+	if len(rows) == 0 || rows[0].Total == nil {
+		t.Fatal("expected percentile rows in Monte Carlo results")
+	}
+
+	payload["options"] = map[string]interface{}{"monteCarlo": "many"}
+	rr = performEditorJSON(t, handler, payload, "/api/editor/forecast")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for invalid monteCarlo option, got %d", rr.Code)
+	}
+
+	payload["options"] = map[string]interface{}{"monteCarlo": constants.MaxMonteCarloIterations + 1}
+	rr = performEditorJSON(t, handler, payload, "/api/editor/forecast")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for too many monteCarlo iterations, got %d", rr.Code)
+	}
+}
+
+func TestHandleForecastMonteCarloIterationLimit(t *testing.T) {
+	handler := NewHandler(zap.NewNop(), constants.DefaultMaxUploadSizeBytes, "test-version")
+
+	configPath := filepath.Join("..", "..", "test", "test_config.yaml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read test config: %v", err)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "test_config.yaml")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		t.Fatalf("failed to write form data: %v", err)
+	}
+	if err := writer.WriteField("monteCarlo", strconv.Itoa(constants.MaxMonteCarloIterations+1)); err != nil {
+		t.Fatalf("failed to write monteCarlo field: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close writer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/forecast", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for too many monteCarlo iterations, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), "exceeds the maximum") {
+		t.Errorf("expected the limit in the error, got %s", rr.Body.String())
+	}
+
+	// The limit also applies to iterations set in the uploaded configuration.
+	rr = performUpload(t, handler, string(data)+fmt.Sprintf("\nmonteCarlo:\n  iterations: %d\n", constants.MaxMonteCarloIterations+1), "test_config.yaml")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for too many configured iterations, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestHandleForecastEditorRealValuesAndAccounts(t *testing.T) {
+	handler := NewHandler(zap.NewNop(), constants.DefaultMaxUploadSizeBytes, "test-version")
+
+	configPath := filepath.Join("..", "..", "test", "test_config.yaml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read test config: %v", err)
+	}
+
+	var configPayload map[string]interface{}
+	if err := yaml.Unmarshal(data, &configPayload); err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+	configPayload["inflation"] = 3.0
+	common := configPayload["common"].(map[string]interface{})
+	common["cashAccounts"] = []interface{}{
+		map[string]interface{}{"name": "Checking"},
+		map[string]interface{}{"name": "Savings", "interestRate": 4.5},
+	}
+
+	rr := performEditorJSON(t, handler, map[string]interface{}{"config": configPayload}, "/api/editor/forecast")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp forecastResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Rows) < 13 {
+		t.Fatalf("expected at least 13 rows, got %d", len(resp.Rows))
+	}
+	value := resp.Rows[12].Values[0]
+	if value.RealTotal == nil || value.Total == nil {
+		t.Fatal("expected real and nominal totals in rows")
+	}
+	if *value.RealTotal == *value.Total {
+		t.Errorf("expected real total to differ from nominal after a year, both %.2f", *value.Total)
+	}
+	if !strings.Contains(resp.CSV, "real total") {
+		t.Error("expected real columns in CSV output")
+	}
+	if _, ok := value.Accounts["Savings"]; !ok {
+		t.Errorf("expected per-account balances in rows, got %v", value.Accounts)
+	}
+	first := resp.Rows[0].Values[0]
+	if first.Liabilities == nil || first.NetWorth == nil || first.RealNetWorth == nil {
+		t.Fatal("expected liabilities and net worth in rows for a scenario with loans")
+	}
+	if diff := *first.Total - *first.Liabilities - *first.NetWorth; diff > 0.01 || diff < -0.01 {
+		t.Errorf("expected net worth %.2f to equal total %.2f less liabilities %.2f", *first.NetWorth, *first.Total, *first.Liabilities)
+	}
+	if !strings.Contains(resp.CSV, "net worth") {
+		t.Error("expected net worth columns in CSV output")
+	}
+}
+
+func TestHandleForecastEditorHouseholdAges(t *testing.T) {
+	handler := NewHandler(zap.NewNop(), constants.DefaultMaxUploadSizeBytes, "test-version")
+
+	configPath := filepath.Join("..", "..", "test", "test_config.yaml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read test config: %v", err)
+	}
+
+	var configPayload map[string]interface{}
+	if err := yaml.Unmarshal(data, &configPayload); err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+	configPayload["household"] = map[string]interface{}{
+		"members": []interface{}{
+			map[string]interface{}{"name": "Alex", "birthDate": "1980-04"},
+		},
+	}
+
+	rr := performEditorJSON(t, handler, map[string]interface{}{"config": configPayload}, "/api/editor/forecast")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp forecastResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Rows) == 0 {
+		t.Fatal("expected forecast rows")
+	}
+	if _, ok := resp.Rows[0].Ages["Alex"]; !ok {
+		t.Errorf("expected member ages in rows, got %v", resp.Rows[0].Ages)
+	}
+	if !strings.Contains(resp.CSV, `"age (Alex)"`) {
+		t.Error("expected age column in CSV output")
+	}
+}
+
+func TestHandleForecastEditorBacktest(t *testing.T) {
+	handler := NewHandler(zap.NewNop(), constants.DefaultMaxUploadSizeBytes, "test-version")
+
+	configPath := filepath.Join("..", "..", "test", "test_config.yaml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read test config: %v", err)
+	}
+
+	var configPayload map[string]interface{}
+	if err := yaml.Unmarshal(data, &configPayload); err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+
+	payload := map[string]interface{}{
+		"config":  configPayload,
+		"options": map[string]interface{}{"backtest": true},
+	}
+	rr := performEditorJSON(t, handler, payload, "/api/editor/forecast")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without a returns series, got %d", rr.Code)
+	}
+
+	common := configPayload["common"].(map[string]interface{})
+	common["deathDate"] = "2035-06"
+	investment := common["investments"].([]interface{})[0].(map[string]interface{})
+	investment["returns"] = map[string]interface{}{"series": "60-40"}
+
+	rr = performEditorJSON(t, handler, payload, "/api/editor/forecast")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp forecastResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Backtest == nil || len(resp.Backtest.StartYears) == 0 {
+		t.Fatal("expected backtest results in response")
+	}
+	if len(resp.Backtest.Scenarios) != len(resp.Scenarios) {
+		t.Fatalf("expected backtest results for each scenario, got %d", len(resp.Backtest.Scenarios))
+	}
+	scenario := resp.Backtest.Scenarios[0]
+	if scenario.Windows != len(resp.Backtest.StartYears) || len(scenario.Worst.Rows) == 0 || len(scenario.Median.Rows) == 0 {
+		t.Fatalf("unexpected backtest scenario payload: windows=%d worst rows=%d median rows=%d",
+			scenario.Windows, len(scenario.Worst.Rows), len(scenario.Median.Rows))
+	}
+}
+
+func TestHandleForecastEditorScenarioInheritance(t *testing.T) {
+	handler := NewHandler(zap.NewNop(), constants.DefaultMaxUploadSizeBytes, "test-version")
+
+	var payload map[string]interface{}
+	if err := yaml.Unmarshal([]byte(`
+startDate: "2025-01"
+common:
+  startingValue: 10000
+  deathDate: "2027-01"
+scenarios:
+  - name: Base
+    active: true
+    events:
+      - name: Salary
+        amount: 5000
+        frequency: 1
+      - name: Rent
+        amount: -2000
+        frequency: 1
+  - name: Cheaper rent
+    active: true
+    extends: Base
+    events:
+      - name: Rent
+        amount: -1500
+        frequency: 1
+`), &payload); err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+
+	// The optimizer returns the configuration it ran, which should keep the
+	// compact form the editor sent.
+	request := map[string]interface{}{"config": payload, "options": map[string]interface{}{"optimize": true}}
+	rr := performEditorJSON(t, handler, request, "/api/editor/forecast")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp forecastResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Scenarios) != 2 {
+		t.Fatalf("expected 2 scenarios, got %v", resp.Scenarios)
+	}
+
+	// Cheaper rent inherits the salary and saves 500 a month over Base.
+	last := resp.Rows[len(resp.Rows)-1]
+	if last.Values[0].Liquid == nil || last.Values[1].Liquid == nil {
+		t.Fatalf("expected liquid values for both scenarios, got %+v", last.Values)
+	}
+	if diff := *last.Values[1].Liquid - *last.Values[0].Liquid; diff <= 0 || math.Mod(diff, 500) != 0 {
+		t.Errorf("expected Cheaper rent to end a multiple of 500 ahead of Base, got %.2f", diff)
+	}
+
+	scenarios, ok := resp.Config["scenarios"].([]interface{})
+	if !ok || len(scenarios) != 2 {
+		t.Fatalf("expected 2 scenarios in the returned config, got %v", resp.Config["scenarios"])
+	}
+	child, _ := scenarios[1].(map[string]interface{})
+	events, _ := child["events"].([]interface{})
+	if child["extends"] != "Base" || len(events) != 1 {
+		t.Errorf("expected the compact child scenario, got %v", child)
+	}
+}
+
+func TestHandleForecastSweeps(t *testing.T) {
+	handler := NewHandler(zap.NewNop(), constants.DefaultMaxUploadSizeBytes, "test-version")
+
+	rr := performUpload(t, handler, `
+startDate: "2025-01"
+common:
+  startingValue: 5000
+  deathDate: "2026-01"
+scenarios:
+  - name: Base
+    active: true
+    events:
+      - name: Income
+        amount: 0
+        frequency: 1
+      - name: Expenses
+        amount: -400
+        frequency: 1
+sweeps:
+  - name: Budget
+    scenario: Base
+    axes:
+      - name: expenses
+        targets: [event:Expenses.amount]
+        from: -600
+        to: -200
+        step: 200
+      - name: income
+        targets: [event:Income.amount]
+        from: 0
+        to: 200
+        step: 200
+`, "sweep.yaml")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp forecastResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Scenarios) != 7 {
+		t.Fatalf("expected the base and 6 generated scenarios, got %v", resp.Scenarios)
+	}
+	if len(resp.Sweeps) != 1 {
+		t.Fatalf("expected one sweep, got %d", len(resp.Sweeps))
+	}
+
+	sweep := resp.Sweeps[0]
+	if sweep.Rows.Label != "expenses" || len(sweep.Rows.Values) != 3 || sweep.Columns == nil || len(sweep.Columns.Values) != 2 {
+		t.Fatalf("unexpected sweep axes: %+v by %+v", sweep.Rows, sweep.Columns)
+	}
+	for name, rows := range map[string]int{
+		"scenarios":     len(sweep.Scenarios),
+		"endNetWorth":   len(sweep.EndNetWorth),
+		"minLiquid":     len(sweep.MinLiquid),
+		"depletionDate": len(sweep.DepletionDate),
+	} {
+		if rows != 3 {
+			t.Errorf("expected 3 rows of %s, got %d", name, rows)
+		}
+	}
+	if sweep.Scenarios[0][1] != "Budget [expenses -600, income 200]" {
+		t.Errorf("unexpected scenario for cell [0][1]: %s", sweep.Scenarios[0][1])
+	}
+	if sweep.DepletionDate[0][0] == "" || sweep.DepletionDate[2][1] != "" {
+		t.Errorf("expected only overspending cells to deplete, got %v", sweep.DepletionDate)
+	}
+	if sweep.EndNetWorth[2][1] <= sweep.EndNetWorth[0][0] {
+		t.Errorf("expected the frugal cell to end ahead, got %v", sweep.EndNetWorth)
+	}
+}
+
 func TestHandleConfigExport(t *testing.T) {
 	handler := NewHandler(zap.NewNop(), constants.DefaultMaxUploadSizeBytes, "test-version")
 
@@ -19,6 +19,7 @@ const chartTooltipEl = document.getElementById("results-chart-tooltip");
 const chartTooltipDateEl = chartTooltipEl ? chartTooltipEl.querySelector('[data-role="tooltip-date"]') : null;
 const chartTooltipLiquidEl = chartTooltipEl ? chartTooltipEl.querySelector('[data-role="tooltip-liquid"]') : null;
 const chartTooltipTotalEl = chartTooltipEl ? chartTooltipEl.querySelector('[data-role="tooltip-total"]') : null;
+const chartTooltipNetWorthEl = chartTooltipEl ? chartTooltipEl.querySelector('[data-role="tooltip-net-worth"]') : null;
 const configEditorRoot = document.getElementById("config-editor");
 const uploadConfigInput = document.getElementById("upload-config-input");
 const uploadConfigButton = document.getElementById("upload-config-button");
@@ -30,6 +31,8 @@ const tablistContainer = document.querySelector(".tablist-container");
 const versionFooter = document.getElementById("workspace-footer");
 const versionLabel = document.getElementById("app-version-label");
 const optimizerToggleInput = document.getElementById("optimizer-toggle-input");
+const realValuesToggleInput = document.getElementById("real-values-toggle");
+const realValuesToggleLabel = realValuesToggleInput ? realValuesToggleInput.closest("label") : null;
 if (configPanel) {
 	configPanel.classList.add("sticky-headers");
 }
@@ -70,6 +73,7 @@ let editorPersistTimer = null;
 let editorStorageAvailable = null;
 let editorPersistenceHandlersRegistered = false;
 let chartResizeFrame = null;
+let showRealValues = false;
 let stickyInlineErrorEl = null;
 let stickyInlineErrorAnchor = null;
 const sectionHighlightTimers = new WeakMap();
@@ -151,6 +155,25 @@ function initializeOptimizerControls() {
 	});
 }
 
+function initializeRealValuesToggle() {
+	if (!realValuesToggleInput) {
+		return;
+	}
+
+	realValuesToggleInput.checked = showRealValues;
+	realValuesToggleInput.addEventListener("change", () => {
+		showRealValues = realValuesToggleInput.checked;
+		renderScenarioChart();
+	});
+}
+
+function datasetHasRealValues(rows) {
+	return rows.some((row) =>
+		Array.isArray(row.values) &&
+		row.values.some((value) => value && (typeof value.realTotal === "number" || typeof value.realLiquid === "number")),
+	);
+}
+
 const MONTH_PATTERN = /^\d{4}-(0[1-9]|1[0-2])$/;
 const SVG_NS = "http://www.w3.org/2000/svg";
 const CHART_MARGIN = {
@@ -165,6 +188,8 @@ const CHART_ASPECT_RATIO = 0.55;
 const CHART_SERIES = [
 	{ key: "liquid", label: "Liquid Net Worth", lineClass: "chart-line--liquid", pointClass: "chart-point--liquid", swatchClass: "chart-legend-swatch--liquid" },
 	{ key: "total", label: "Total Net Worth", lineClass: "chart-line--total", pointClass: "chart-point--total", swatchClass: "chart-legend-swatch--total" },
+	// Net worth after loan balances is only reported for scenarios with loans.
+	{ key: "netWorth", label: "Net Worth", lineClass: "chart-line--net-worth", pointClass: "chart-point--net-worth", swatchClass: "chart-legend-swatch--net-worth", optional: true },
 ];
 const SUMMARY_CURRENCY_FORMATTER = new Intl.NumberFormat(undefined, {
 	style: "currency",
@@ -188,6 +213,7 @@ const OPTIMIZER_FIELD_OPTIONS = [
 	{ label: "Frequency", value: "frequency" },
 	{ label: "Start date", value: "startDate" },
 	{ label: "End date", value: "endDate" },
+	{ label: "Social Security claiming age", value: "claimingAge" },
 ];
 
 const OPTIMIZER_FIELD_DESCRIPTIONS = {
@@ -195,6 +221,7 @@ const OPTIMIZER_FIELD_DESCRIPTIONS = {
 	frequency: "Adjust how often this event recurs to help maintain the emergency-fund floor.",
 	startDate: "Adjust when this event begins to align cash flow with the emergency-fund floor.",
 	endDate: "Adjust when this event ends to maintain the emergency-fund floor.",
+	claimingAge: "Adjust the Social Security claiming age of this socialSecurity event to maintain the emergency-fund floor.",
 };
 
 const NET_WORTH_METRIC_LABELS = {
@@ -212,6 +239,9 @@ const OPTIMIZER_FIELD_CANONICAL = {
 	enddate: "endDate",
 	"end-date": "endDate",
 	end_date: "endDate",
+	claimingage: "claimingAge",
+	"claiming-age": "claimingAge",
+	claiming_age: "claimingAge",
 };
 
 function formatSummaryCurrency(value) {
@@ -397,6 +427,10 @@ function formatOptimizerDisplay(summary, kind) {
 	if (field === "startdate" || field === "enddate") {
 		return formatMonthIndexValue(numericValue);
 	}
+	if (field === "claimingage") {
+		const months = Math.round(numericValue * 12);
+		return months % 12 === 0 ? String(months / 12) : `${Math.trunc(months / 12)}y ${months % 12}m`;
+	}
 
 	return String(numericValue);
 }
@@ -524,6 +558,16 @@ function ensureOptimizerDefaults(event, field, options = {}) {
 			optimizer.maxDate = coalesceMonthValue(currentMax, defaultMax);
 		}
 		ensureTolerance(1);
+	} else if (normalized === "claimingAge") {
+		delete optimizer.minDate;
+		delete optimizer.maxDate;
+		if (resetBounds || !Number.isFinite(optimizer.min)) {
+			optimizer.min = 62;
+		}
+		if (resetBounds || !Number.isFinite(optimizer.max)) {
+			optimizer.max = 70;
+		}
+		ensureTolerance(1 / 12);
 	} else {
 		ensureTolerance(0.01);
 	}
@@ -555,6 +599,26 @@ function getOptimizerFieldMeta(field) {
 			toleranceValidation: { type: "integer", min: 0 },
 		};
 	}
+	if (normalized === "claimingAge") {
+		return {
+			minPath: "min",
+			maxPath: "max",
+			minLabel: "Earliest claiming age",
+			maxLabel: "Latest claiming age",
+			minTooltip: "Youngest claiming age, in years, the optimizer will consider (62 or later).",
+			maxTooltip: "Oldest claiming age, in years, the optimizer will consider (70 or earlier).",
+			inputType: "number",
+			step: "0.25",
+			arrowStep: ARROW_STEP_SMALL,
+			validation: { type: "number", min: 62, max: 70, required: true },
+			toleranceLabel: "Tolerance (years)",
+			toleranceTooltip: "Stop when bounds differ by this many years. Leave blank or zero to use the default (one month).",
+			toleranceInputType: "number",
+			toleranceStep: "0.01",
+			toleranceArrowStep: ARROW_STEP_SMALL,
+			toleranceValidation: { type: "number", min: 0 },
+		};
+	}
 	if (normalized === "startDate") {
 		return {
 			minPath: "minDate",
@@ -675,6 +739,7 @@ window.addEventListener("load", updateStickyMetrics);
 window.addEventListener("resize", scheduleChartRerender);
 
 initializeOptimizerControls();
+initializeRealValuesToggle();
 initializeWorkspace();
 initializeThemeControls();
 initializeVersionFooter();
@@ -1590,6 +1655,57 @@ function renderScenarioSummary() {
 		}
 	}
 
+	const debtPlans = Array.isArray(metrics.debtPayoff) ? metrics.debtPayoff : [];
+	debtPlans.forEach((plan) => {
+		if (!plan) {
+			return;
+		}
+		const planBlock = document.createElement("div");
+		planBlock.className = "results-summary__emergency";
+		const heading = document.createElement("div");
+		heading.className = "results-summary__heading";
+		heading.textContent = `Debt payoff plan (${plan.scope} loans, ${plan.strategy})`;
+		planBlock.appendChild(heading);
+
+		const list = document.createElement("ul");
+		list.className = "results-summary__list";
+		const overview = document.createElement("li");
+		const overviewText = document.createElement("div");
+		overviewText.className = "results-summary__item";
+		overviewText.textContent = [
+			`${formatSummaryCurrency(plan.budget)}/month extra`,
+			plan.debtFree ? `Debt free ${plan.debtFree}` : "Not debt free within the forecast",
+			`Interest saved: ${formatSummaryCurrency(plan.interestSaved)}`,
+		].join(" • ");
+		overview.appendChild(overviewText);
+		const comparison = Array.isArray(plan.comparison) ? plan.comparison : [];
+		if (comparison.length > 0) {
+			const compareEl = document.createElement("div");
+			compareEl.className = "results-summary__notes muted-text";
+			compareEl.textContent = comparison
+				.map((entry) => `${entry.strategy}: ${entry.debtFree ? `debt free ${entry.debtFree}` : "not debt free"}, interest ${formatSummaryCurrency(entry.interest)}`)
+				.join(" • ");
+			overview.appendChild(compareEl);
+		}
+		list.appendChild(overview);
+
+		const loans = Array.isArray(plan.loans) ? plan.loans : [];
+		loans.forEach((loan) => {
+			const item = document.createElement("li");
+			const description = document.createElement("div");
+			description.className = "results-summary__item";
+			const payoff = loan.payoff || "after the forecast";
+			const baseline = loan.baselinePayoff || "after the forecast";
+			description.textContent = `${loan.name} • Paid off ${payoff} (minimum payments ${baseline}) • Interest ${formatSummaryCurrency(loan.interest)}`;
+			item.appendChild(description);
+			list.appendChild(item);
+		});
+
+		planBlock.appendChild(list);
+		resultsSummaryEl.appendChild(planBlock);
+		hasContent = true;
+	});
+
 	if (hasContent) {
 		resultsSummaryEl.classList.remove("hidden");
 	}
@@ -1625,6 +1741,14 @@ function renderScenarioChart() {
 	const scenarioIndex = clampActiveScenarioIndex();
 	const scenarioName = forecastDataset.scenarios[scenarioIndex] || `Scenario ${scenarioIndex + 1}`;
 	const rows = Array.isArray(forecastDataset.rows) ? forecastDataset.rows : [];
+	const hasRealValues = datasetHasRealValues(rows);
+	if (realValuesToggleLabel) {
+		realValuesToggleLabel.classList.toggle("hidden", !hasRealValues);
+	}
+	const useRealValues = hasRealValues && showRealValues;
+	const liquidKey = useRealValues ? "realLiquid" : "liquid";
+	const totalKey = useRealValues ? "realTotal" : "total";
+	const netWorthKey = useRealValues ? "realNetWorth" : "netWorth";
 
 	const points = rows
 		.map((row) => {
@@ -1633,8 +1757,9 @@ function renderScenarioChart() {
 				return null;
 			}
 			const value = Array.isArray(row.values) ? row.values[scenarioIndex] || null : null;
-			const liquid = getScenarioValue(value, "liquid");
-			const total = getScenarioValue(value, "total");
+			const liquid = getScenarioValue(value, liquidKey);
+			const total = getScenarioValue(value, totalKey);
+			const netWorth = getScenarioValue(value, netWorthKey);
 			if (liquid === null && total === null) {
 				return null;
 			}
@@ -1644,12 +1769,15 @@ function renderScenarioChart() {
 				time: parsedDate.getTime(),
 				liquid,
 				total,
+				netWorth,
 			};
 		})
 		.filter(Boolean);
 
 	if (chartTitleEl) {
-		chartTitleEl.textContent = `Net Worth Over Time — ${scenarioName}`;
+		chartTitleEl.textContent = useRealValues
+			? `Net Worth Over Time (today's dollars) — ${scenarioName}`
+			: `Net Worth Over Time — ${scenarioName}`;
 	}
 	if (chartCaptionEl) {
 		chartCaptionEl.textContent = `Line chart showing liquid and total net worth over time for the selected scenario: ${scenarioName}.`;
@@ -1704,6 +1832,9 @@ function renderScenarioChart() {
 		if (typeof point.total === "number" && Number.isFinite(point.total)) {
 			yValues.push(point.total);
 		}
+		if (typeof point.netWorth === "number" && Number.isFinite(point.netWorth)) {
+			yValues.push(point.netWorth);
+		}
 	});
 
 	if (yValues.length > 0) {
@@ -2012,6 +2143,15 @@ function renderScenarioChart() {
 	updateStickyMetrics();
 }
 
+function formatAges(ages) {
+	if (!ages || typeof ages !== "object") {
+		return "";
+	}
+	return Object.entries(ages)
+		.map(([name, age]) => `${escapeHtml(name)} ${age}`)
+		.join(", ");
+}
+
 function renderScenarioTable() {
 	tableHead.innerHTML = "";
 	tableBody.innerHTML = "";
@@ -2026,10 +2166,17 @@ function renderScenarioTable() {
 		? rawScenarioName
 		: `Scenario ${scenarioIndex + 1}`;
 
+	const rows = Array.isArray(forecastDataset.rows) ? forecastDataset.rows : [];
+	const showAges = rows.some((row) => row && row.ages && Object.keys(row.ages).length > 0);
+	const showNetWorth = rows.some((row) => {
+		const value = row && Array.isArray(row.values) ? row.values[scenarioIndex] : null;
+		return value && typeof value.netWorth === "number";
+	});
+
 	const headRow = document.createElement("tr");
 	headRow.classList.add("primary-header-row");
 	const scenarioHeader = createHeaderCell(scenarioLabel);
-	scenarioHeader.colSpan = 4;
+	scenarioHeader.colSpan = 4 + (showAges ? 1 : 0) + (showNetWorth ? 1 : 0);
 	scenarioHeader.classList.add("scenario-heading");
 	headRow.appendChild(scenarioHeader);
 	tableHead.appendChild(headRow);
@@ -2037,8 +2184,14 @@ function renderScenarioTable() {
 	const subHeadRow = document.createElement("tr");
 	subHeadRow.classList.add("secondary-header-row");
 	subHeadRow.appendChild(createHeaderCell("Date", "subhead"));
+	if (showAges) {
+		subHeadRow.appendChild(createHeaderCell("Ages", "subhead"));
+	}
 	subHeadRow.appendChild(createHeaderCell("Liquid Net Worth", "subhead"));
 	subHeadRow.appendChild(createHeaderCell("Total Net Worth", "subhead"));
+	if (showNetWorth) {
+		subHeadRow.appendChild(createHeaderCell("Net Worth", "subhead"));
+	}
 	subHeadRow.appendChild(createHeaderCell("Notes", "subhead"));
 	tableHead.appendChild(subHeadRow);
 
@@ -2049,10 +2202,12 @@ function renderScenarioTable() {
 	});
 
 	const noValueMarkup = '<span class="muted-text">—</span>';
-	const rows = Array.isArray(forecastDataset.rows) ? forecastDataset.rows : [];
 	rows.forEach((row) => {
 		const tr = document.createElement("tr");
 		tr.appendChild(createCell(row.date));
+		if (showAges) {
+			tr.appendChild(createCell(formatAges(row.ages) || noValueMarkup));
+		}
 
 		const value = Array.isArray(row.values) ? row.values[scenarioIndex] || {} : {};
 		const liquidAmount = typeof value.liquid === "number"
@@ -2075,6 +2230,10 @@ function renderScenarioTable() {
 
 		tr.appendChild(createCell(liquidValue, "amount-cell"));
 		tr.appendChild(createCell(totalValue, "amount-cell"));
+		if (showNetWorth) {
+			const netWorthValue = typeof value.netWorth === "number" ? currencyFormatter.format(value.netWorth) : noValueMarkup;
+			tr.appendChild(createCell(netWorthValue, "amount-cell"));
+		}
 		tr.appendChild(createCell(formatNotes(value.notes)));
 
 		tableBody.appendChild(tr);
@@ -2094,6 +2253,9 @@ function renderScenarioTable() {
 			const hasValues = hasAnyEntries
 				? entries.some((point) => typeof point[series.key] === "number" && Number.isFinite(point[series.key]))
 				: false;
+			if (!hasValues && series.optional) {
+				return;
+			}
 			const item = document.createElement("span");
 			item.className = "chart-legend-item";
 			item.setAttribute("role", "listitem");
@@ -2388,6 +2550,11 @@ function renderScenarioTable() {
 				if (chartTooltipTotalEl) {
 					chartTooltipTotalEl.textContent = formatTooltipCurrency(data.total);
 				}
+				if (chartTooltipNetWorthEl) {
+					const hasNetWorth = typeof data.netWorth === "number" && Number.isFinite(data.netWorth);
+					chartTooltipNetWorthEl.textContent = formatTooltipCurrency(data.netWorth);
+					chartTooltipNetWorthEl.closest(".chart-tooltip__row").classList.toggle("hidden", !hasNetWorth);
+				}
 
 				chartTooltipEl.classList.remove("hidden");
 				chartTooltipEl.setAttribute("aria-hidden", "false");
@@ -0,0 +1,236 @@
+package simulation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+	"github.com/iwvelando/finance-forecast/internal/forecast"
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+	"github.com/iwvelando/finance-forecast/pkg/returns"
+	"go.uber.org/zap"
+)
+
+// BacktestRunner replays a configuration against every historical window that
+// fits the embedded return series referenced by its investments.
+type BacktestRunner struct {
+	logger    *zap.Logger
+	conf      *config.Configuration
+	fixedTime time.Time
+	series    map[string]*returns.Series
+}
+
+// BacktestResult summarizes every rolling-window replay for each active scenario.
+type BacktestResult struct {
+	StartYears []int
+	Scenarios  []BacktestScenario
+}
+
+// BacktestScenario reports the success rate plus the worst and median windows
+// for a single scenario. A window succeeds when total net worth never drops
+// below zero before the death date.
+type BacktestScenario struct {
+	Name        string
+	Windows     int
+	Successes   int
+	SuccessRate float64
+	Worst       BacktestPath
+	Median      BacktestPath
+}
+
+// BacktestPath is the forecast produced by replaying history from StartYear.
+type BacktestPath struct {
+	StartYear   int
+	EndingTotal float64
+	Liquid      map[string]float64
+	Total       map[string]float64
+}
+
+// NewBacktestRunner constructs a runner for the provided configuration. The
+// configuration must already have its date lists parsed and loans processed,
+// and at least one investment must reference a historical return series.
+func NewBacktestRunner(logger *zap.Logger, conf *config.Configuration) (*BacktestRunner, error) {
+	if conf == nil {
+		return nil, fmt.Errorf("configuration cannot be nil")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	fixedTime, err := simulationStartTime(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make(map[string]*returns.Series)
+	collect := func(investments []config.Investment) error {
+		for _, investment := range investments {
+			if !investment.Historical() {
+				continue
+			}
+			if _, ok := series[investment.Returns.Series]; ok {
+				continue
+			}
+			loaded, err := returns.LoadSeries(investment.Returns.Series)
+			if err != nil {
+				return fmt.Errorf("investment %s returns: %w", investment.Name, err)
+			}
+			series[investment.Returns.Series] = loaded
+		}
+		return nil
+	}
+	if err := collect(conf.Common.Investments); err != nil {
+		return nil, err
+	}
+	for _, scenario := range conf.Scenarios {
+		if err := collect(scenario.Investments); err != nil {
+			return nil, err
+		}
+	}
+	if len(series) == 0 {
+		return nil, fmt.Errorf("backtesting requires at least one investment with a historical returns series")
+	}
+
+	return &BacktestRunner{
+		logger:    logger,
+		conf:      conf.Clone(),
+		fixedTime: fixedTime,
+		series:    series,
+	}, nil
+}
+
+// Run replays the forecast for every historical start year and aggregates the results.
+func (r *BacktestRunner) Run() (*BacktestResult, error) {
+	dates, err := simulationDates(r.fixedTime, r.conf.Common.DeathDate)
+	if err != nil {
+		return nil, err
+	}
+
+	startYears, err := r.startYears(len(dates))
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string][]BacktestPath)
+	var order []string
+
+	for _, startYear := range startYears {
+		windowConf := r.conf.Clone()
+		if err := r.assignReturnPaths(windowConf, startYear, dates); err != nil {
+			return nil, err
+		}
+
+		forecasts, err := forecast.GetForecastWithFixedTime(r.logger, *windowConf, r.fixedTime)
+		if err != nil {
+			return nil, fmt.Errorf("backtest starting %d failed: %w", startYear, err)
+		}
+
+		for _, fc := range forecasts {
+			if _, ok := paths[fc.Name]; !ok {
+				order = append(order, fc.Name)
+			}
+			paths[fc.Name] = append(paths[fc.Name], BacktestPath{
+				StartYear:   startYear,
+				EndingTotal: fc.Data[dates[len(dates)-1]],
+				Liquid:      fc.Liquid,
+				Total:       fc.Data,
+			})
+		}
+	}
+
+	result := &BacktestResult{StartYears: startYears}
+	for _, name := range order {
+		result.Scenarios = append(result.Scenarios, summarizeBacktest(name, paths[name]))
+	}
+
+	r.logger.Info("historical backtest completed",
+		zap.String("op", "simulation.Backtest"),
+		zap.Int("windows", len(startYears)),
+		zap.Int("scenarios", len(result.Scenarios)),
+	)
+
+	return result, nil
+}
+
+// startYears lists every historical start year for which all referenced series
+// cover the full forecast horizon.
+func (r *BacktestRunner) startYears(months int) ([]int, error) {
+	years := (months + constants.MonthsPerYear - 1) / constants.MonthsPerYear
+
+	first, last := 0, 0
+	for _, series := range r.series {
+		if first == 0 || series.FirstYear > first {
+			first = series.FirstYear
+		}
+		if last == 0 || series.LastYear() < last {
+			last = series.LastYear()
+		}
+	}
+
+	if last-first+1 < years {
+		return nil, fmt.Errorf("forecast horizon of %d years exceeds the available return history %d-%d", years, first, last)
+	}
+
+	startYears := make([]int, 0, last-first-years+2)
+	for year := first; year+years-1 <= last; year++ {
+		startYears = append(startYears, year)
+	}
+	return startYears, nil
+}
+
+func (r *BacktestRunner) assignReturnPaths(conf *config.Configuration, startYear int, dates []string) error {
+	assign := func(investments []config.Investment) error {
+		for i := range investments {
+			investment := &investments[i]
+			if !investment.Historical() {
+				continue
+			}
+			path, err := r.series[investment.Returns.Series].MonthlyPath(dates, startYear)
+			if err != nil {
+				return fmt.Errorf("investment %s returns: %w", investment.Name, err)
+			}
+			investment.ReturnPath = path
+		}
+		return nil
+	}
+
+	if err := assign(conf.Common.Investments); err != nil {
+		return err
+	}
+	for i := range conf.Scenarios {
+		if err := assign(conf.Scenarios[i].Investments); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func summarizeBacktest(name string, paths []BacktestPath) BacktestScenario {
+	summary := BacktestScenario{Name: name, Windows: len(paths)}
+	for _, path := range paths {
+		succeeded := true
+		for _, value := range path.Total {
+			if value < 0 {
+				succeeded = false
+				break
+			}
+		}
+		if succeeded {
+			summary.Successes++
+		}
+	}
+	if summary.Windows > 0 {
+		summary.SuccessRate = float64(summary.Successes) / float64(summary.Windows)
+	}
+
+	sorted := append([]BacktestPath(nil), paths...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].EndingTotal < sorted[j].EndingTotal
+	})
+	if len(sorted) > 0 {
+		summary.Worst = sorted[0]
+		summary.Median = sorted[(len(sorted)-1)/2]
+	}
+	return summary
+}
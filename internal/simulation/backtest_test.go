@@ -0,0 +1,116 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+	"go.uber.org/zap"
+)
+
+func backtestTestConfig(t *testing.T, deathDate string, monthlySpending float64) *config.Configuration {
+	t.Helper()
+
+	conf := &config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			StartingValue: 10000,
+			DeathDate:     deathDate,
+			Events: []config.Event{
+				{Name: "Spending", Amount: -monthlySpending, Frequency: 1},
+			},
+			Investments: []config.Investment{
+				{
+					Name:             "Stocks",
+					StartingValue:    100000,
+					AnnualReturnRate: 7,
+					Returns:          &config.ReturnModel{Series: "us-stocks"},
+				},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{Name: "Base", Active: true},
+		},
+	}
+
+	startTime, err := time.Parse(config.DateTimeLayout, conf.StartDate)
+	if err != nil {
+		t.Fatalf("failed to parse start date: %v", err)
+	}
+	if err := conf.ParseDateListsWithFixedTime(startTime); err != nil {
+		t.Fatalf("failed to parse date lists: %v", err)
+	}
+	if err := conf.ProcessLoans(zap.NewNop()); err != nil {
+		t.Fatalf("failed to process loans: %v", err)
+	}
+	return conf
+}
+
+func TestBacktestRunnerRollingWindows(t *testing.T) {
+	conf := backtestTestConfig(t, "2034-12", 100)
+
+	runner, err := NewBacktestRunner(zap.NewNop(), conf)
+	if err != nil {
+		t.Fatalf("NewBacktestRunner returned error: %v", err)
+	}
+	result, err := runner.Run()
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if len(result.StartYears) != 87 || result.StartYears[0] != 1928 || result.StartYears[86] != 2014 {
+		t.Fatalf("unexpected start years: %d windows from %v", len(result.StartYears), result.StartYears[:1])
+	}
+	if len(result.Scenarios) != 1 {
+		t.Fatalf("expected one scenario, got %d", len(result.Scenarios))
+	}
+
+	scenario := result.Scenarios[0]
+	if scenario.Windows != 87 || scenario.Successes != 87 || scenario.SuccessRate != 1 {
+		t.Fatalf("expected every window to succeed, got %+v", scenario)
+	}
+	if scenario.Worst.EndingTotal > scenario.Median.EndingTotal {
+		t.Fatalf("worst ending total %.2f exceeds median %.2f", scenario.Worst.EndingTotal, scenario.Median.EndingTotal)
+	}
+	if scenario.Worst.StartYear < 1928 || len(scenario.Worst.Total) == 0 {
+		t.Fatalf("expected a populated worst path, got start year %d", scenario.Worst.StartYear)
+	}
+}
+
+func TestBacktestRunnerReportsFailures(t *testing.T) {
+	conf := backtestTestConfig(t, "2034-12", 1500)
+
+	runner, err := NewBacktestRunner(zap.NewNop(), conf)
+	if err != nil {
+		t.Fatalf("NewBacktestRunner returned error: %v", err)
+	}
+	result, err := runner.Run()
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	scenario := result.Scenarios[0]
+	if scenario.SuccessRate >= 1 || scenario.SuccessRate <= 0 {
+		t.Fatalf("expected some but not all windows to fail, got success rate %.2f", scenario.SuccessRate)
+	}
+	if scenario.Worst.EndingTotal >= 0 {
+		t.Fatalf("expected the worst window to end negative, got %.2f", scenario.Worst.EndingTotal)
+	}
+}
+
+func TestBacktestRunnerValidation(t *testing.T) {
+	conf := backtestTestConfig(t, "2034-12", 100)
+	conf.Common.Investments[0].Returns = nil
+	if _, err := NewBacktestRunner(zap.NewNop(), conf); err == nil {
+		t.Fatal("expected error when no investment references a series")
+	}
+
+	long := backtestTestConfig(t, "2130-12", 100)
+	runner, err := NewBacktestRunner(zap.NewNop(), long)
+	if err != nil {
+		t.Fatalf("NewBacktestRunner returned error: %v", err)
+	}
+	if _, err := runner.Run(); err == nil {
+		t.Fatal("expected error when the horizon exceeds the available history")
+	}
+}
@@ -0,0 +1,253 @@
+// Package simulation runs repeated forecasts to summarize the range of outcomes
+// produced by uncertain investment returns.
+package simulation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+	"github.com/iwvelando/finance-forecast/internal/forecast"
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+	"github.com/iwvelando/finance-forecast/pkg/returns"
+	"go.uber.org/zap"
+)
+
+// MonteCarloOptions controls a Monte Carlo run.
+type MonteCarloOptions struct {
+	Iterations int
+	Seed       int64
+}
+
+// MonteCarloRunner repeatedly forecasts a configuration with sampled investment returns.
+type MonteCarloRunner struct {
+	logger    *zap.Logger
+	conf      *config.Configuration
+	fixedTime time.Time
+	options   MonteCarloOptions
+}
+
+// MonteCarloResult summarizes the outcome distribution for every active scenario.
+type MonteCarloResult struct {
+	Iterations int
+	Seed       int64
+	Scenarios  []ScenarioDistribution
+}
+
+// ScenarioDistribution holds the per-month percentile bands for a single scenario.
+type ScenarioDistribution struct {
+	Name                      string
+	Liquid                    map[string]PercentileBand
+	Total                     map[string]PercentileBand
+	ProbabilityLiquidNegative float64
+	ProbabilityTotalNegative  float64
+}
+
+// PercentileBand captures the 10th, 50th, and 90th percentile of a monthly value.
+type PercentileBand struct {
+	P10 float64
+	P50 float64
+	P90 float64
+}
+
+// NewMonteCarloRunner constructs a runner for the provided configuration. The
+// configuration must already have its date lists parsed and loans processed; it
+// is cloned so that later deterministic forecasts are unaffected.
+func NewMonteCarloRunner(logger *zap.Logger, conf *config.Configuration, options MonteCarloOptions) (*MonteCarloRunner, error) {
+	if conf == nil {
+		return nil, fmt.Errorf("configuration cannot be nil")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if options.Iterations <= 0 {
+		return nil, fmt.Errorf("monte carlo iterations must be greater than zero, got %d", options.Iterations)
+	}
+
+	fixedTime, err := simulationStartTime(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MonteCarloRunner{
+		logger:    logger,
+		conf:      conf.Clone(),
+		fixedTime: fixedTime,
+		options:   options,
+	}, nil
+}
+
+// Run executes every iteration and aggregates the results.
+func (r *MonteCarloRunner) Run() (*MonteCarloResult, error) {
+	dates, err := simulationDates(r.fixedTime, r.conf.Common.DeathDate)
+	if err != nil {
+		return nil, err
+	}
+
+	collectors := make(map[string]*pathCollector)
+	var order []string
+
+	for iteration := 0; iteration < r.options.Iterations; iteration++ {
+		iterationConf := r.conf.Clone()
+		if err := r.assignReturnPaths(iterationConf, iteration, dates); err != nil {
+			return nil, err
+		}
+		if err := r.assignIndexPaths(iterationConf, iteration, dates); err != nil {
+			return nil, err
+		}
+
+		forecasts, err := forecast.GetForecastWithFixedTime(r.logger, *iterationConf, r.fixedTime)
+		if err != nil {
+			return nil, fmt.Errorf("monte carlo iteration %d failed: %w", iteration, err)
+		}
+
+		for _, fc := range forecasts {
+			collector, ok := collectors[fc.Name]
+			if !ok {
+				collector = newPathCollector()
+				collectors[fc.Name] = collector
+				order = append(order, fc.Name)
+			}
+			collector.add(fc)
+		}
+	}
+
+	result := &MonteCarloResult{
+		Iterations: r.options.Iterations,
+		Seed:       r.options.Seed,
+	}
+	for _, name := range order {
+		collector := collectors[name]
+		result.Scenarios = append(result.Scenarios, ScenarioDistribution{
+			Name:                      name,
+			Liquid:                    collector.liquidBands(),
+			Total:                     collector.totalBands(),
+			ProbabilityLiquidNegative: collector.probabilityLiquidNegative(),
+			ProbabilityTotalNegative:  collector.probabilityTotalNegative(),
+		})
+	}
+
+	r.logger.Info("monte carlo simulation completed",
+		zap.String("op", "simulation.MonteCarlo"),
+		zap.Int("iterations", r.options.Iterations),
+		zap.Int64("seed", r.options.Seed),
+		zap.Int("scenarios", len(result.Scenarios)),
+	)
+
+	return result, nil
+}
+
+// assignReturnPaths samples a monthly return path for every stochastic investment.
+// Investments are keyed by name so that an investment shared by several scenarios
+// sees the same market in a given iteration, keeping scenario comparisons fair.
+func (r *MonteCarloRunner) assignReturnPaths(conf *config.Configuration, iteration int, dates []string) error {
+	assign := func(investments []config.Investment) error {
+		for i := range investments {
+			investment := &investments[i]
+			if !investment.Stochastic() {
+				continue
+			}
+			seed := r.options.Seed
+			if investment.Returns.Seed != nil {
+				seed = *investment.Returns.Seed
+			}
+			sampler, err := returns.NewSampler(investment.ToReturnsModel(), returns.DeriveSeed(seed, iteration, investment.Name))
+			if err != nil {
+				return fmt.Errorf("investment %s returns: %w", investment.Name, err)
+			}
+			investment.ReturnPath = sampler.Path(dates)
+		}
+		return nil
+	}
+
+	if err := assign(conf.Common.Investments); err != nil {
+		return err
+	}
+	for i := range conf.Scenarios {
+		if err := assign(conf.Scenarios[i].Investments); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignIndexPaths samples the index of every adjustable-rate loan with an
+// indexVolatility and rebuilds its amortization schedule, then replans any
+// debt payoff. Like returns, paths are keyed by loan name.
+func (r *MonteCarloRunner) assignIndexPaths(conf *config.Configuration, iteration int, dates []string) error {
+	sampled := false
+	assign := func(loans []config.Loan) error {
+		for i := range loans {
+			loan := &loans[i]
+			if !loan.AdjustableRate.Stochastic() {
+				continue
+			}
+			if err := loan.AdjustableRate.SampleIndexPath(dates, returns.DeriveSeed(r.options.Seed, iteration, "loan:"+loan.Name)); err != nil {
+				return fmt.Errorf("loan %s index: %w", loan.Name, err)
+			}
+			if err := loan.GetAmortizationSchedule(r.logger, *conf); err != nil {
+				return err
+			}
+			sampled = true
+		}
+		return nil
+	}
+
+	if err := assign(conf.Common.Loans); err != nil {
+		return err
+	}
+	for i := range conf.Scenarios {
+		if err := assign(conf.Scenarios[i].Loans); err != nil {
+			return err
+		}
+	}
+	if !sampled {
+		return nil
+	}
+	return conf.ApplyDebtPayoffPlans(r.logger)
+}
+
+func simulationStartTime(conf *config.Configuration) (time.Time, error) {
+	if conf.StartDate == "" {
+		return time.Now(), nil
+	}
+	parsed, err := time.Parse(config.DateTimeLayout, conf.StartDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start date %q: %w", conf.StartDate, err)
+	}
+	return parsed, nil
+}
+
+// simulationDates lists every month processed by the forecast, which begins the
+// month after the start date and ends at the death date.
+func simulationDates(fixedTime time.Time, deathDate string) ([]string, error) {
+	if deathDate == "" {
+		return nil, fmt.Errorf("deathDate is required for stochastic simulations")
+	}
+	death, err := time.Parse(config.DateTimeLayout, deathDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deathDate %q: %w", deathDate, err)
+	}
+
+	var dates []string
+	current := fixedTime.Format(config.DateTimeLayout)
+	for {
+		next, err := datetime.OffsetDate(current, config.DateTimeLayout, 1)
+		if err != nil {
+			return nil, err
+		}
+		nextT, err := time.Parse(config.DateTimeLayout, next)
+		if err != nil {
+			return nil, err
+		}
+		if nextT.After(death) {
+			break
+		}
+		dates = append(dates, next)
+		current = next
+	}
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("deathDate %s must be after the simulation start %s", deathDate, fixedTime.Format(config.DateTimeLayout))
+	}
+	return dates, nil
+}
@@ -0,0 +1,230 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+	"github.com/iwvelando/finance-forecast/internal/forecast"
+	"go.uber.org/zap"
+)
+
+func monteCarloTestConfig(t *testing.T, volatility float64) *config.Configuration {
+	t.Helper()
+
+	conf := &config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			StartingValue: 5000,
+			DeathDate:     "2027-12",
+			Events: []config.Event{
+				{Name: "Expenses", Amount: -400, Frequency: 1},
+			},
+			Investments: []config.Investment{
+				{
+					Name:             "Brokerage",
+					StartingValue:    20000,
+					AnnualReturnRate: 6,
+					Returns:          &config.ReturnModel{Volatility: volatility},
+					Withdrawals: []config.Event{
+						{Amount: 300, Frequency: 1, StartDate: "2025-02"},
+					},
+				},
+			},
+		},
+		Scenarios: []config.Scenario{
+			{Name: "Base", Active: true},
+			{Name: "Inactive", Active: false},
+		},
+	}
+
+	startTime, err := time.Parse(config.DateTimeLayout, conf.StartDate)
+	if err != nil {
+		t.Fatalf("failed to parse start date: %v", err)
+	}
+	if err := conf.ParseDateListsWithFixedTime(startTime); err != nil {
+		t.Fatalf("failed to parse date lists: %v", err)
+	}
+	if err := conf.ProcessLoans(zap.NewNop()); err != nil {
+		t.Fatalf("failed to process loans: %v", err)
+	}
+	return conf
+}
+
+func TestMonteCarloRunnerProducesOrderedBands(t *testing.T) {
+	conf := monteCarloTestConfig(t, 18)
+
+	runner, err := NewMonteCarloRunner(zap.NewNop(), conf, MonteCarloOptions{Iterations: 200, Seed: 7})
+	if err != nil {
+		t.Fatalf("NewMonteCarloRunner returned error: %v", err)
+	}
+	result, err := runner.Run()
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if result.Iterations != 200 || result.Seed != 7 {
+		t.Fatalf("unexpected result metadata: %+v", result)
+	}
+	if len(result.Scenarios) != 1 || result.Scenarios[0].Name != "Base" {
+		t.Fatalf("expected only the active scenario, got %+v", result.Scenarios)
+	}
+
+	scenario := result.Scenarios[0]
+	band, ok := scenario.Total["2027-12"]
+	if !ok {
+		t.Fatal("expected a band for the death date")
+	}
+	if !(band.P10 <= band.P50 && band.P50 <= band.P90) {
+		t.Fatalf("expected ordered percentiles, got %+v", band)
+	}
+	if band.P90-band.P10 <= 0 {
+		t.Fatalf("expected a spread between p10 and p90 with volatility, got %+v", band)
+	}
+	if start := scenario.Total["2025-01"]; start.P10 != 25000 || start.P90 != 25000 {
+		t.Fatalf("expected the starting month to be identical across iterations, got %+v", start)
+	}
+}
+
+func TestMonteCarloRunnerIsReproducible(t *testing.T) {
+	run := func() *MonteCarloResult {
+		conf := monteCarloTestConfig(t, 15)
+		runner, err := NewMonteCarloRunner(zap.NewNop(), conf, MonteCarloOptions{Iterations: 25, Seed: 99})
+		if err != nil {
+			t.Fatalf("NewMonteCarloRunner returned error: %v", err)
+		}
+		result, err := runner.Run()
+		if err != nil {
+			t.Fatalf("Run returned error: %v", err)
+		}
+		return result
+	}
+
+	first := run()
+	second := run()
+	for date, band := range first.Scenarios[0].Total {
+		if second.Scenarios[0].Total[date] != band {
+			t.Fatalf("expected identical bands for %s, got %+v and %+v", date, band, second.Scenarios[0].Total[date])
+		}
+	}
+}
+
+func TestMonteCarloRunnerWithoutVolatilityMatchesDeterministicForecast(t *testing.T) {
+	conf := monteCarloTestConfig(t, 0)
+
+	runner, err := NewMonteCarloRunner(zap.NewNop(), conf, MonteCarloOptions{Iterations: 5})
+	if err != nil {
+		t.Fatalf("NewMonteCarloRunner returned error: %v", err)
+	}
+	result, err := runner.Run()
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	deterministic, err := forecast.GetForecastWithFixedTime(zap.NewNop(), *conf, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetForecastWithFixedTime returned error: %v", err)
+	}
+
+	for date, expected := range deterministic[0].Data {
+		band := result.Scenarios[0].Total[date]
+		if math.Abs(band.P50-expected) > 1e-6 || math.Abs(band.P10-expected) > 1e-6 {
+			t.Fatalf("band for %s = %+v, want all percentiles at %.2f", date, band, expected)
+		}
+	}
+	if result.Scenarios[0].ProbabilityLiquidNegative != 0 {
+		t.Fatalf("expected no negative liquid outcomes, got %.2f", result.Scenarios[0].ProbabilityLiquidNegative)
+	}
+}
+
+func TestMonteCarloRunnerSamplesAdjustableRateIndex(t *testing.T) {
+	conf := monteCarloTestConfig(t, 0)
+	conf.Common.Loans = []config.Loan{
+		{
+			Name:         "ARM",
+			StartDate:    "2025-01",
+			Principal:    20000,
+			InterestRate: 4,
+			Term:         36,
+			AdjustableRate: &config.AdjustableRate{
+				FixedMonths:        3,
+				AdjustmentInterval: 3,
+				Index:              3,
+				IndexVolatility:    3,
+				Margin:             2,
+			},
+		},
+	}
+	if err := conf.ProcessLoans(zap.NewNop()); err != nil {
+		t.Fatalf("failed to process loans: %v", err)
+	}
+
+	runner, err := NewMonteCarloRunner(zap.NewNop(), conf, MonteCarloOptions{Iterations: 50, Seed: 11})
+	if err != nil {
+		t.Fatalf("NewMonteCarloRunner returned error: %v", err)
+	}
+	result, err := runner.Run()
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	band := result.Scenarios[0].Liquid["2027-12"]
+	if band.P90-band.P10 < 1 {
+		t.Errorf("expected sampled index rates to spread liquid outcomes, got %+v", band)
+	}
+	if conf.Common.Loans[0].AdjustableRate.IndexPath != nil {
+		t.Error("expected the original configuration to keep its deterministic index")
+	}
+}
+
+func TestMonteCarloRunnerReportsNegativeProbability(t *testing.T) {
+	conf := monteCarloTestConfig(t, 10)
+	conf.Common.Events[0].Amount = -2000
+
+	runner, err := NewMonteCarloRunner(zap.NewNop(), conf, MonteCarloOptions{Iterations: 10, Seed: 3})
+	if err != nil {
+		t.Fatalf("NewMonteCarloRunner returned error: %v", err)
+	}
+	result, err := runner.Run()
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if result.Scenarios[0].ProbabilityLiquidNegative != 1 {
+		t.Fatalf("expected liquid to go negative in every iteration, got %.2f", result.Scenarios[0].ProbabilityLiquidNegative)
+	}
+}
+
+func TestNewMonteCarloRunnerValidation(t *testing.T) {
+	if _, err := NewMonteCarloRunner(zap.NewNop(), nil, MonteCarloOptions{Iterations: 1}); err == nil {
+		t.Fatal("expected error for nil configuration")
+	}
+	if _, err := NewMonteCarloRunner(zap.NewNop(), &config.Configuration{}, MonteCarloOptions{}); err == nil {
+		t.Fatal("expected error for zero iterations")
+	}
+	if _, err := NewMonteCarloRunner(zap.NewNop(), &config.Configuration{StartDate: "bad"}, MonteCarloOptions{Iterations: 1}); err == nil {
+		t.Fatal("expected error for invalid start date")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	tests := []struct {
+		p        float64
+		expected float64
+	}{
+		{0, 1},
+		{0.5, 3},
+		{1, 5},
+		{0.1, 1.4},
+		{0.9, 4.6},
+	}
+	for _, tt := range tests {
+		if got := percentile(values, tt.p); math.Abs(got-tt.expected) > 1e-9 {
+			t.Errorf("percentile(%.2f) = %.4f, want %.4f", tt.p, got, tt.expected)
+		}
+	}
+	if percentile(nil, 0.5) != 0 {
+		t.Error("expected 0 for empty input")
+	}
+}
@@ -0,0 +1,105 @@
+package simulation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/iwvelando/finance-forecast/internal/forecast"
+)
+
+// pathCollector accumulates the monthly values of repeated forecasts for one scenario.
+type pathCollector struct {
+	liquid          map[string][]float64
+	total           map[string][]float64
+	runs            int
+	liquidNegatives int
+	totalNegatives  int
+}
+
+func newPathCollector() *pathCollector {
+	return &pathCollector{
+		liquid: make(map[string][]float64),
+		total:  make(map[string][]float64),
+	}
+}
+
+func (c *pathCollector) add(fc forecast.Forecast) {
+	c.runs++
+
+	liquidNegative := false
+	for date, value := range fc.Liquid {
+		c.liquid[date] = append(c.liquid[date], value)
+		if value < 0 {
+			liquidNegative = true
+		}
+	}
+	totalNegative := false
+	for date, value := range fc.Data {
+		c.total[date] = append(c.total[date], value)
+		if value < 0 {
+			totalNegative = true
+		}
+	}
+
+	if liquidNegative {
+		c.liquidNegatives++
+	}
+	if totalNegative {
+		c.totalNegatives++
+	}
+}
+
+func (c *pathCollector) liquidBands() map[string]PercentileBand {
+	return bandsFor(c.liquid)
+}
+
+func (c *pathCollector) totalBands() map[string]PercentileBand {
+	return bandsFor(c.total)
+}
+
+func (c *pathCollector) probabilityLiquidNegative() float64 {
+	if c.runs == 0 {
+		return 0
+	}
+	return float64(c.liquidNegatives) / float64(c.runs)
+}
+
+func (c *pathCollector) probabilityTotalNegative() float64 {
+	if c.runs == 0 {
+		return 0
+	}
+	return float64(c.totalNegatives) / float64(c.runs)
+}
+
+func bandsFor(values map[string][]float64) map[string]PercentileBand {
+	bands := make(map[string]PercentileBand, len(values))
+	for date, samples := range values {
+		sorted := append([]float64(nil), samples...)
+		sort.Float64s(sorted)
+		bands[date] = PercentileBand{
+			P10: percentile(sorted, 0.10),
+			P50: percentile(sorted, 0.50),
+			P90: percentile(sorted, 0.90),
+		}
+	}
+	return bands
+}
+
+// percentile returns the p-th percentile (0 <= p <= 1) of an ascending slice using
+// linear interpolation between the closest ranks.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	weight := rank - float64(lower)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*weight
+}
@@ -0,0 +1,139 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+	"github.com/iwvelando/finance-forecast/internal/forecast"
+)
+
+// SweepResult summarizes the scenarios generated by every parameter sweep.
+type SweepResult struct {
+	Sweeps []SweepGrid
+}
+
+// SweepGrid lays out a sweep's scenarios as a matrix: one row per value of the
+// first axis and one column per value of the second. A sweep with one axis
+// has a single column and no Columns values.
+type SweepGrid struct {
+	Name     string
+	Scenario string
+	Rows     SweepAxisValues
+	Columns  SweepAxisValues
+	Cells    [][]SweepCell
+}
+
+// SweepAxisValues holds an axis label and the values it steps through.
+type SweepAxisValues struct {
+	Label  string
+	Values []float64
+}
+
+// SweepCell summarizes the forecast of one generated scenario.
+type SweepCell struct {
+	Scenario string
+	// EndNetWorth is net worth at the death date, less loan liabilities when
+	// the scenario has loans.
+	EndNetWorth float64
+	MinLiquid   float64
+	// DepletionDate is the first month liquid cash is negative, or empty when
+	// it never is.
+	DepletionDate string
+}
+
+// SummarizeSweeps summarizes the forecasts of the scenarios the configuration's
+// sweeps generated. It returns nil when no sweeps are configured.
+func SummarizeSweeps(conf *config.Configuration, results []forecast.Forecast) (*SweepResult, error) {
+	if conf == nil {
+		return nil, fmt.Errorf("configuration cannot be nil")
+	}
+	if len(conf.Sweeps) == 0 {
+		return nil, nil
+	}
+
+	byName := make(map[string]forecast.Forecast, len(results))
+	for _, result := range results {
+		byName[result.Name] = result
+	}
+
+	summary := &SweepResult{Sweeps: make([]SweepGrid, 0, len(conf.Sweeps))}
+	for _, sweep := range conf.Sweeps {
+		grid := SweepGrid{Name: sweep.Name, Scenario: sweep.Scenario}
+		if len(sweep.Axes) > 0 {
+			grid.Rows = SweepAxisValues{Label: sweep.Axes[0].Label(), Values: sweep.Axes[0].Values()}
+		}
+		if len(sweep.Axes) > 1 {
+			grid.Columns = SweepAxisValues{Label: sweep.Axes[1].Label(), Values: sweep.Axes[1].Values()}
+		}
+
+		columns := len(grid.Columns.Values)
+		if columns == 0 {
+			columns = 1
+		}
+		grid.Cells = make([][]SweepCell, len(grid.Rows.Values))
+		for i, values := range sweep.Cells() {
+			name := sweep.CellName(values)
+			result, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("sweep %s: no forecast for scenario %s", sweep.Name, name)
+			}
+			grid.Cells[i/columns] = append(grid.Cells[i/columns], summarizeSweepCell(result))
+		}
+		summary.Sweeps = append(summary.Sweeps, grid)
+	}
+	return summary, nil
+}
+
+// WithoutSweepScenarios returns the forecasts of the configured scenarios,
+// leaving out the scenarios sweeps generated, which SummarizeSweeps reports.
+func WithoutSweepScenarios(conf *config.Configuration, results []forecast.Forecast) []forecast.Forecast {
+	if conf == nil {
+		return results
+	}
+	generated := make(map[string]bool)
+	for _, scenario := range conf.Scenarios {
+		if scenario.Sweep != "" {
+			generated[scenario.Name] = true
+		}
+	}
+	var kept []forecast.Forecast
+	for _, result := range results {
+		if !generated[result.Name] {
+			kept = append(kept, result)
+		}
+	}
+	return kept
+}
+
+// summarizeSweepCell reduces a forecast to the values shown in a sweep grid.
+func summarizeSweepCell(result forecast.Forecast) SweepCell {
+	cell := SweepCell{Scenario: result.Name}
+
+	dates := make([]string, 0, len(result.Data))
+	for date := range result.Data {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	if len(dates) > 0 {
+		last := dates[len(dates)-1]
+		cell.EndNetWorth = result.Data[last]
+		if result.NetWorth != nil {
+			cell.EndNetWorth = result.NetWorth[last]
+		}
+	}
+
+	cell.MinLiquid = math.Inf(1)
+	for _, date := range dates {
+		liquid := result.Liquid[date]
+		cell.MinLiquid = math.Min(cell.MinLiquid, liquid)
+		if liquid < 0 && cell.DepletionDate == "" {
+			cell.DepletionDate = date
+		}
+	}
+	if len(dates) == 0 {
+		cell.MinLiquid = 0
+	}
+	return cell
+}
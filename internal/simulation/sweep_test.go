@@ -0,0 +1,97 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/internal/config"
+	"github.com/iwvelando/finance-forecast/internal/forecast"
+	"go.uber.org/zap"
+)
+
+func TestSummarizeSweeps(t *testing.T) {
+	conf := &config.Configuration{
+		StartDate: "2025-01",
+		Common: config.Common{
+			StartingValue: 5000,
+			DeathDate:     "2026-12",
+		},
+		Scenarios: []config.Scenario{
+			{
+				Name:   "Base",
+				Active: true,
+				Events: []config.Event{
+					{Name: "Income", Amount: 0, Frequency: 1},
+					{Name: "Expenses", Amount: -400, Frequency: 1},
+				},
+			},
+		},
+		Sweeps: []config.ParameterSweep{
+			{
+				Name:     "Budget",
+				Scenario: "Base",
+				Axes: []config.SweepAxis{
+					{Name: "expenses", Targets: []string{"event:Expenses.amount"}, From: -600, To: -200, Step: 200},
+					{Name: "income", Targets: []string{"event:Income.amount"}, From: 0, To: 200, Step: 200},
+				},
+			},
+		},
+	}
+	if err := conf.ExpandSweeps(); err != nil {
+		t.Fatalf("ExpandSweeps() error = %v", err)
+	}
+	if err := conf.ParseDateListsWithFixedTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("ParseDateListsWithFixedTime() error = %v", err)
+	}
+	results, err := forecast.GetForecast(zap.NewNop(), *conf)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+
+	summary, err := SummarizeSweeps(conf, results)
+	if err != nil {
+		t.Fatalf("SummarizeSweeps() error = %v", err)
+	}
+	if len(summary.Sweeps) != 1 {
+		t.Fatalf("got %d sweep grids, want 1", len(summary.Sweeps))
+	}
+	grid := summary.Sweeps[0]
+	if grid.Rows.Label != "expenses" || len(grid.Rows.Values) != 3 || grid.Columns.Label != "income" || len(grid.Columns.Values) != 2 {
+		t.Fatalf("grid axes = %+v by %+v, want 3 expenses by 2 incomes", grid.Rows, grid.Columns)
+	}
+	if len(grid.Cells) != 3 || len(grid.Cells[0]) != 2 {
+		t.Fatalf("grid is %d rows of %d cells, want 3 by 2", len(grid.Cells), len(grid.Cells[0]))
+	}
+
+	// Spending 600 a month with no income runs the 5000 starting cash out in
+	// the ninth month; the end value is 23 months of spending later.
+	worst := grid.Cells[0][0]
+	if worst.Scenario != "Budget [expenses -600, income 0]" {
+		t.Errorf("worst cell scenario = %s", worst.Scenario)
+	}
+	if worst.DepletionDate != "2025-10" {
+		t.Errorf("worst cell depletion date = %q, want 2025-10", worst.DepletionDate)
+	}
+	if worst.EndNetWorth != 5000-600*23 || worst.MinLiquid != worst.EndNetWorth {
+		t.Errorf("worst cell end net worth = %.2f, min liquid = %.2f, want %d for both", worst.EndNetWorth, worst.MinLiquid, 5000-600*23)
+	}
+
+	best := grid.Cells[2][1]
+	if best.DepletionDate != "" || best.MinLiquid != 5000 || best.EndNetWorth != 5000 {
+		t.Errorf("best cell = %+v, want cash held at 5000 and never depleted", best)
+	}
+
+	if kept := WithoutSweepScenarios(conf, results); len(kept) != 1 || kept[0].Name != "Base" {
+		t.Errorf("WithoutSweepScenarios() kept %d forecasts, want only Base", len(kept))
+	}
+
+	conf.Sweeps[0].Name = "Renamed"
+	if _, err := SummarizeSweeps(conf, results); err == nil {
+		t.Error("expected an error for a sweep without forecasts")
+	}
+
+	conf.Sweeps = nil
+	if summary, err := SummarizeSweeps(conf, results); summary != nil || err != nil {
+		t.Errorf("SummarizeSweeps() without sweeps = %v, %v, want nil", summary, err)
+	}
+}
@@ -28,6 +28,11 @@ func (w ConfigEventAdapter) GetDateList() []time.Time {
 	return w.Event.DateList
 }
 
+// GetAmountList returns the per-date event amounts, or nil when the amount is constant
+func (w ConfigEventAdapter) GetAmountList() []float64 {
+	return w.Event.AmountList
+}
+
 // EventsToFinanceEvents converts config.Event slices to finance.EventWithDates slices
 func EventsToFinanceEvents(events []config.Event) []finance.EventWithDates {
 	if events == nil {
@@ -70,6 +75,20 @@ func LoansToFinanceLoans(loans []config.Loan) []finance.LoanWithSchedule {
 	return financeLoans
 }
 
+// RevolvingDebtToFinanceDebt converts a config.RevolvingDebt into a
+// finance.RevolvingDebt starting at its configured balance.
+func RevolvingDebtToFinanceDebt(debt config.RevolvingDebt) *finance.RevolvingDebt {
+	return &finance.RevolvingDebt{
+		Name:           debt.Name,
+		Balance:        debt.Balance,
+		APR:            debt.APR,
+		MinimumPercent: debt.MinimumPercent,
+		MinimumPayment: debt.MinimumPayment,
+		PayInFull:      debt.PayInFull,
+		Charges:        EventsToFinanceEvents(debt.Charges),
+	}
+}
+
 // ConfigInvestmentAdapter wraps config.Investment to implement finance.Investment
 type ConfigInvestmentAdapter struct {
 	investment            config.Investment
@@ -90,19 +109,19 @@ func newConfigInvestmentAdapter(investment config.Investment) ConfigInvestmentAd
 	}
 
 	for _, contribution := range investment.Contributions {
-		for _, date := range contribution.DateList {
+		for i, date := range contribution.DateList {
 			key := date.Format(config.DateTimeLayout)
-			adapter.contributionSchedule[key] += contribution.Amount
+			adapter.contributionSchedule[key] += contribution.AmountAt(i)
 		}
 	}
 
 	for _, withdrawal := range investment.Withdrawals {
-		for _, date := range withdrawal.DateList {
+		for i, date := range withdrawal.DateList {
 			key := date.Format(config.DateTimeLayout)
 			if withdrawal.Percentage != 0 {
 				adapter.withdrawalPercentages[key] += withdrawal.Percentage
 			} else {
-				adapter.withdrawalSchedule[key] += withdrawal.Amount
+				adapter.withdrawalSchedule[key] += withdrawal.AmountAt(i)
 			}
 		}
 	}
@@ -125,6 +144,15 @@ func (a ConfigInvestmentAdapter) GetAnnualReturnRate() float64 {
 	return a.investment.AnnualReturnRate
 }
 
+// GetMonthlyReturnForDate returns the monthly return rate as a decimal, preferring
+// a sampled return path when one has been assigned to the investment
+func (a ConfigInvestmentAdapter) GetMonthlyReturnForDate(date string) float64 {
+	if rate, ok := a.investment.ReturnPath[date]; ok {
+		return rate
+	}
+	return finance.MonthlyRateFromAnnual(a.investment.AnnualReturnRate)
+}
+
 // GetTaxRate returns the tax rate percentage applied to gains
 func (a ConfigInvestmentAdapter) GetTaxRate() float64 {
 	return a.investment.TaxRate
@@ -135,6 +163,11 @@ func (a ConfigInvestmentAdapter) GetWithdrawalTaxRate() float64 {
 	return a.investment.WithdrawalTaxRate
 }
 
+// GetAccountType returns the tax treatment of the account
+func (a ConfigInvestmentAdapter) GetAccountType() string {
+	return a.investment.AccountType
+}
+
 // GetContributionForDate returns the total contribution scheduled for the provided date
 func (a ConfigInvestmentAdapter) GetContributionForDate(date string) float64 {
 	return a.contributionSchedule[date]
@@ -167,3 +200,26 @@ func InvestmentsToFinanceInvestments(investments []config.Investment) []finance.
 	}
 	return financeInvestments
 }
+
+// CashAccountsToFinanceConfigs converts config.CashAccount slices to finance.CashAccountConfig slices
+func CashAccountsToFinanceConfigs(accounts []config.CashAccount) []finance.CashAccountConfig {
+	if accounts == nil {
+		return nil
+	}
+
+	configs := make([]finance.CashAccountConfig, 0, len(accounts))
+	for _, account := range accounts {
+		cfg := finance.CashAccountConfig{
+			Name:          account.Name,
+			StartingValue: account.StartingValue,
+			InterestRate:  account.InterestRate,
+		}
+		if account.Sweep != nil {
+			cfg.SweepTo = account.Sweep.To
+			cfg.SweepAbove = account.Sweep.Above
+			cfg.SweepBelow = account.Sweep.Below
+		}
+		configs = append(configs, cfg)
+	}
+	return configs
+}
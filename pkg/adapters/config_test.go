@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -392,6 +393,59 @@ func TestInvestmentsToFinanceInvestments_PercentageWithdrawals(t *testing.T) {
 	}
 }
 
+func TestInvestmentsToFinanceInvestments_ReturnPath(t *testing.T) {
+	investment := config.Investment{
+		Name:             "Sampled",
+		AnnualReturnRate: 6.0,
+		ReturnPath:       map[string]float64{"2025-06": 0.02},
+	}
+
+	fi := InvestmentsToFinanceInvestments([]config.Investment{investment})[0]
+
+	if got := fi.GetMonthlyReturnForDate("2025-06"); got != 0.02 {
+		t.Errorf("GetMonthlyReturnForDate(2025-06) = %.4f, want 0.02", got)
+	}
+	if got := fi.GetMonthlyReturnForDate("2025-07"); math.Abs(got-0.005) > 1e-12 {
+		t.Errorf("GetMonthlyReturnForDate(2025-07) = %.4f, want 0.005", got)
+	}
+}
+
+func TestInvestmentsToFinanceInvestments_GrownAmounts(t *testing.T) {
+	jun25, _ := time.Parse(config.DateTimeLayout, "2025-06")
+	jun26, _ := time.Parse(config.DateTimeLayout, "2026-06")
+
+	investment := config.Investment{
+		Name: "Indexed",
+		Contributions: []config.Event{{
+			Amount:     100,
+			DateList:   []time.Time{jun25, jun26},
+			AmountList: []float64{100, 103},
+		}},
+		Withdrawals: []config.Event{{
+			Amount:     50,
+			DateList:   []time.Time{jun26},
+			AmountList: []float64{52},
+		}},
+	}
+
+	fi := InvestmentsToFinanceInvestments([]config.Investment{investment})[0]
+
+	if got := fi.GetContributionForDate("2025-06"); got != 100 {
+		t.Errorf("GetContributionForDate(2025-06) = %.2f, want 100", got)
+	}
+	if got := fi.GetContributionForDate("2026-06"); got != 103 {
+		t.Errorf("GetContributionForDate(2026-06) = %.2f, want 103", got)
+	}
+	if got := fi.GetWithdrawalForDate("2026-06"); got != 52 {
+		t.Errorf("GetWithdrawalForDate(2026-06) = %.2f, want 52", got)
+	}
+
+	events := EventsToFinanceEvents(investment.Contributions)
+	if list := events[0].GetAmountList(); len(list) != 2 || list[1] != 103 {
+		t.Errorf("GetAmountList() = %v, want [100 103]", list)
+	}
+}
+
 func TestInvestmentsToFinanceInvestmentsNil(t *testing.T) {
 	if len(InvestmentsToFinanceInvestments(nil)) != 0 {
 		t.Fatalf("expected nil investments to return empty slice")
@@ -54,6 +54,10 @@ const (
 
 	// DefaultMaxUploadSizeBytes is the default maximum upload size for YAML configs (256 KB)
 	DefaultMaxUploadSizeBytes int64 = 256 * 1024
+
+	// MaxMonteCarloIterations is the most Monte Carlo iterations a single web
+	// API request may run
+	MaxMonteCarloIterations = 10000
 )
 
 // Validation constants
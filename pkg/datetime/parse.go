@@ -11,6 +11,9 @@ const (
 	// DateTimeLayout is the format expected in config files and is also the output
 	// date format.
 	DateTimeLayout = constants.DateTimeLayout
+	// DayLayout is the format for calendar days, such as the anchor of a
+	// weekly schedule.
+	DayLayout = "2006-01-02"
 )
 
 // MustParseTime parses a date string using the given layout and panics on error.
@@ -59,3 +62,29 @@ func DateBeforeDate(firstDate string, secondDate string) (bool, error) {
 	}
 	return firstDateT.Before(secondDateT), nil
 }
+
+// MonthsBetween returns the number of calendar months from start to end.
+func MonthsBetween(start, end time.Time) int {
+	return (end.Year()-start.Year())*constants.MonthsPerYear + int(end.Month()) - int(start.Month())
+}
+
+// OccurrencesInMonth counts the days in month that fall a whole number of
+// periods of periodDays before or after anchor.
+func OccurrencesInMonth(anchor time.Time, periodDays int, month time.Time) int {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	next := first.AddDate(0, 1, 0)
+	anchor = time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 0, 0, 0, 0, time.UTC)
+	offset := int(first.Sub(anchor).Hours()/24) % periodDays
+	if offset < 0 {
+		offset += periodDays
+	}
+	day := first
+	if offset != 0 {
+		day = first.AddDate(0, 0, periodDays-offset)
+	}
+	count := 0
+	for ; day.Before(next); day = day.AddDate(0, 0, periodDays) {
+		count++
+	}
+	return count
+}
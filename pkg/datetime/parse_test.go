@@ -276,3 +276,55 @@ func TestTimeOperations(t *testing.T) {
 		t.Errorf("Round trip date operation failed: started with %s, ended with %s", baseDate, past)
 	}
 }
+
+func TestMonthsBetween(t *testing.T) {
+	tests := []struct {
+		start    string
+		end      string
+		expected int
+	}{
+		{"2025-01", "2025-01", 0},
+		{"2025-01", "2025-12", 11},
+		{"2025-06", "2026-06", 12},
+		{"2025-11", "2027-02", 15},
+		{"2026-03", "2025-03", -12},
+	}
+
+	for _, tt := range tests {
+		result := MonthsBetween(MustParseTime(DateTimeLayout, tt.start), MustParseTime(DateTimeLayout, tt.end))
+		if result != tt.expected {
+			t.Errorf("MonthsBetween(%s, %s) = %d, expected %d", tt.start, tt.end, result, tt.expected)
+		}
+	}
+}
+
+func TestOccurrencesInMonth(t *testing.T) {
+	anchor := MustParseTime(DayLayout, "2025-01-03")
+	tests := []struct {
+		month      string
+		periodDays int
+		expected   int
+	}{
+		{"2025-01", 14, 3}, // 3rd, 17th, 31st
+		{"2025-02", 14, 2}, // 14th, 28th
+		{"2024-12", 14, 2}, // 6th, 20th, before the anchor
+		{"2025-08", 14, 3}, // 1st, 15th, 29th
+		{"2025-01", 7, 5},
+		{"2025-02", 7, 4},
+	}
+
+	for _, tt := range tests {
+		result := OccurrencesInMonth(anchor, tt.periodDays, MustParseTime(DateTimeLayout, tt.month))
+		if result != tt.expected {
+			t.Errorf("OccurrencesInMonth(%s, %d) = %d, expected %d", tt.month, tt.periodDays, result, tt.expected)
+		}
+	}
+
+	total := 0
+	for month := MustParseTime(DateTimeLayout, "2025-01"); month.Year() == 2025; month = month.AddDate(0, 1, 0) {
+		total += OccurrencesInMonth(anchor, 14, month)
+	}
+	if total != 26 {
+		t.Errorf("biweekly occurrences in 2025 = %d, expected 26", total)
+	}
+}
@@ -0,0 +1,49 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCanonicalAccountType(t *testing.T) {
+	tests := map[string]string{
+		"":            AccountTypeTaxable,
+		"Brokerage":   AccountTypeTaxable,
+		"401k":        AccountTypeTraditional,
+		" IRA ":       AccountTypeTraditional,
+		"roth-ira":    AccountTypeRoth,
+		"HSA":         AccountTypeHSA,
+		"annuity":     "annuity",
+		"traditional": AccountTypeTraditional,
+	}
+	for input, want := range tests {
+		if got := CanonicalAccountType(input); got != want {
+			t.Errorf("CanonicalAccountType(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if err := ValidateAccountType("annuity"); err == nil {
+		t.Error("expected error for unsupported account type")
+	}
+}
+
+func TestRequiredMinimumDistribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance float64
+		age     int
+		want    float64
+	}{
+		{"age 73", 265000, 73, 10000},
+		{"age 90", 122000, 90, 10000},
+		{"below table uses first entry", 27400, 70, 1000},
+		{"above table uses last entry", 2000, 125, 1000},
+		{"empty account", 0, 80, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RequiredMinimumDistribution(tt.balance, tt.age); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("RequiredMinimumDistribution(%.2f, %d) = %.4f, want %.4f", tt.balance, tt.age, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package finance
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+)
+
+// CashAccountConfig describes a cash account tracked by a CashLedger.
+type CashAccountConfig struct {
+	Name          string
+	StartingValue float64
+	InterestRate  float64 // annual percentage yield
+	SweepTo       string
+	SweepAbove    *float64
+	SweepBelow    *float64
+}
+
+// CashTransfer records money moved between accounts by a sweep.
+type CashTransfer struct {
+	From   string
+	To     string
+	Amount float64
+}
+
+type cashAccount struct {
+	config      CashAccountConfig
+	balance     float64
+	monthlyRate float64
+}
+
+// CashLedger tracks balances across one or more cash accounts. The first
+// account is the default for flows that do not name an account.
+type CashLedger struct {
+	accounts []*cashAccount
+	byName   map[string]*cashAccount
+}
+
+// NewCashLedger creates a ledger for the given accounts. startingValue is added
+// to the default account; when no accounts are configured a single unnamed
+// account holds it.
+func NewCashLedger(startingValue float64, configs []CashAccountConfig) (*CashLedger, error) {
+	if len(configs) == 0 {
+		configs = []CashAccountConfig{{}}
+	}
+
+	ledger := &CashLedger{byName: make(map[string]*cashAccount, len(configs))}
+	for _, cfg := range configs {
+		if _, exists := ledger.byName[cfg.Name]; exists {
+			return nil, fmt.Errorf("duplicate cash account %q", cfg.Name)
+		}
+		account := &cashAccount{
+			config:      cfg,
+			balance:     cfg.StartingValue,
+			monthlyRate: MonthlyRateFromAPY(cfg.InterestRate),
+		}
+		ledger.accounts = append(ledger.accounts, account)
+		ledger.byName[cfg.Name] = account
+	}
+
+	for _, account := range ledger.accounts {
+		if account.config.SweepTo == "" {
+			continue
+		}
+		if _, ok := ledger.byName[account.config.SweepTo]; !ok {
+			return nil, fmt.Errorf("cash account %q sweeps into unknown account %q", account.config.Name, account.config.SweepTo)
+		}
+	}
+
+	ledger.accounts[0].balance += startingValue
+	return ledger, nil
+}
+
+// MonthlyRateFromAPY converts an annual percentage yield into the equivalent
+// monthly compounding rate as a decimal.
+func MonthlyRateFromAPY(apy float64) float64 {
+	if apy == 0 {
+		return 0
+	}
+	return math.Pow(1+percentToDecimal(apy), 1/float64(constants.MonthsPerYear)) - 1
+}
+
+// Deposit adds amount (negative for withdrawals) to the named account, or to
+// the default account when name is empty.
+func (l *CashLedger) Deposit(name string, amount float64) error {
+	account := l.accounts[0]
+	if name != "" {
+		var ok bool
+		account, ok = l.byName[name]
+		if !ok {
+			return fmt.Errorf("unknown cash account %q", name)
+		}
+	}
+	account.balance += amount
+	return nil
+}
+
+// AccrueInterest credits one month of interest on positive balances and returns
+// the total earned.
+func (l *CashLedger) AccrueInterest() float64 {
+	total := 0.0
+	for _, account := range l.accounts {
+		if account.monthlyRate == 0 || account.balance <= 0 {
+			continue
+		}
+		interest := account.balance * account.monthlyRate
+		account.balance += interest
+		total += interest
+	}
+	return total
+}
+
+// Sweep applies each account's sweep rule in configuration order and returns
+// the transfers made.
+func (l *CashLedger) Sweep() []CashTransfer {
+	var transfers []CashTransfer
+	for _, account := range l.accounts {
+		cfg := account.config
+		if cfg.SweepTo == "" {
+			continue
+		}
+		target := l.byName[cfg.SweepTo]
+
+		if cfg.SweepAbove != nil && account.balance > *cfg.SweepAbove {
+			amount := account.balance - *cfg.SweepAbove
+			account.balance -= amount
+			target.balance += amount
+			transfers = append(transfers, CashTransfer{From: cfg.Name, To: cfg.SweepTo, Amount: amount})
+			continue
+		}
+
+		if cfg.SweepBelow != nil && account.balance < *cfg.SweepBelow && target.balance > 0 {
+			amount := math.Min(*cfg.SweepBelow-account.balance, target.balance)
+			target.balance -= amount
+			account.balance += amount
+			transfers = append(transfers, CashTransfer{From: cfg.SweepTo, To: cfg.Name, Amount: amount})
+		}
+	}
+	return transfers
+}
+
+// Total returns the combined balance of all accounts.
+func (l *CashLedger) Total() float64 {
+	total := 0.0
+	for _, account := range l.accounts {
+		total += account.balance
+	}
+	return total
+}
+
+// Balance returns the current balance of the named account.
+func (l *CashLedger) Balance(name string) float64 {
+	if account, ok := l.byName[name]; ok {
+		return account.balance
+	}
+	return 0
+}
+
+// Names returns the configured account names in order.
+func (l *CashLedger) Names() []string {
+	names := make([]string, 0, len(l.accounts))
+	for _, account := range l.accounts {
+		names = append(names, account.config.Name)
+	}
+	return names
+}
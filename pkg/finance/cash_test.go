@@ -0,0 +1,114 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewCashLedgerDefaultAccount(t *testing.T) {
+	ledger, err := NewCashLedger(1000, nil)
+	if err != nil {
+		t.Fatalf("NewCashLedger() error = %v", err)
+	}
+
+	if err := ledger.Deposit("", -250); err != nil {
+		t.Fatalf("Deposit() error = %v", err)
+	}
+	if interest := ledger.AccrueInterest(); interest != 0 {
+		t.Errorf("AccrueInterest() = %.2f, want 0 for the implicit account", interest)
+	}
+	if got := ledger.Total(); got != 750 {
+		t.Errorf("Total() = %.2f, want 750", got)
+	}
+	if err := ledger.Deposit("Savings", 10); err == nil {
+		t.Error("expected error depositing into an unknown account")
+	}
+}
+
+func TestCashLedgerInterest(t *testing.T) {
+	ledger, err := NewCashLedger(0, []CashAccountConfig{
+		{Name: "Checking", StartingValue: 1000},
+		{Name: "Savings", StartingValue: 10000, InterestRate: 4.5},
+	})
+	if err != nil {
+		t.Fatalf("NewCashLedger() error = %v", err)
+	}
+
+	total := 0.0
+	for month := 0; month < 12; month++ {
+		total += ledger.AccrueInterest()
+	}
+
+	if math.Abs(total-450) > 1e-6 {
+		t.Errorf("interest over a year = %.6f, want 450 (APY)", total)
+	}
+	if got := ledger.Balance("Checking"); got != 1000 {
+		t.Errorf("Checking balance = %.2f, want 1000", got)
+	}
+
+	if err := ledger.Deposit("Savings", -20000); err != nil {
+		t.Fatalf("Deposit() error = %v", err)
+	}
+	if interest := ledger.AccrueInterest(); interest != 0 {
+		t.Errorf("AccrueInterest() on negative balance = %.2f, want 0", interest)
+	}
+}
+
+func TestCashLedgerSweep(t *testing.T) {
+	above := 5000.0
+	below := 2000.0
+
+	tests := []struct {
+		name           string
+		checking       float64
+		savings        float64
+		wantChecking   float64
+		wantSavings    float64
+		wantTransfers  int
+		wantTransferTo string
+	}{
+		{"excess moves to savings", 8000, 1000, 5000, 4000, 1, "Savings"},
+		{"within band stays put", 3000, 1000, 3000, 1000, 0, ""},
+		{"shortfall refilled from savings", 500, 4000, 2000, 2500, 1, "Checking"},
+		{"refill limited by savings balance", -1000, 1200, 200, 0, 1, "Checking"},
+		{"empty savings cannot refill", 500, 0, 500, 0, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger, err := NewCashLedger(0, []CashAccountConfig{
+				{Name: "Checking", StartingValue: tt.checking, SweepTo: "Savings", SweepAbove: &above, SweepBelow: &below},
+				{Name: "Savings", StartingValue: tt.savings},
+			})
+			if err != nil {
+				t.Fatalf("NewCashLedger() error = %v", err)
+			}
+
+			transfers := ledger.Sweep()
+			if len(transfers) != tt.wantTransfers {
+				t.Fatalf("Sweep() made %d transfers, want %d", len(transfers), tt.wantTransfers)
+			}
+			if tt.wantTransfers > 0 && transfers[0].To != tt.wantTransferTo {
+				t.Errorf("transfer to %s, want %s", transfers[0].To, tt.wantTransferTo)
+			}
+			if got := ledger.Balance("Checking"); got != tt.wantChecking {
+				t.Errorf("Checking = %.2f, want %.2f", got, tt.wantChecking)
+			}
+			if got := ledger.Balance("Savings"); got != tt.wantSavings {
+				t.Errorf("Savings = %.2f, want %.2f", got, tt.wantSavings)
+			}
+			if got := ledger.Total(); got != tt.checking+tt.savings {
+				t.Errorf("Total() = %.2f, sweeps must conserve cash", got)
+			}
+		})
+	}
+}
+
+func TestNewCashLedgerInvalid(t *testing.T) {
+	if _, err := NewCashLedger(0, []CashAccountConfig{{Name: "A"}, {Name: "A"}}); err == nil {
+		t.Error("expected error for duplicate account names")
+	}
+	if _, err := NewCashLedger(0, []CashAccountConfig{{Name: "A", SweepTo: "B"}}); err == nil {
+		t.Error("expected error for unknown sweep target")
+	}
+}
@@ -15,13 +15,21 @@ func percentToDecimal(percent float64) float64 {
 	return percent / percentDivisor
 }
 
+// MonthlyRateFromAnnual converts an annual percentage rate into the simple monthly
+// decimal rate used for fixed-return investments.
+func MonthlyRateFromAnnual(annualPercent float64) float64 {
+	return percentToDecimal(annualPercent) / constants.MonthsPerYear
+}
+
 // Investment represents an investment account with contribution and withdrawal schedules.
 type Investment interface {
 	GetName() string
 	GetStartingValue() float64
 	GetAnnualReturnRate() float64
+	GetMonthlyReturnForDate(date string) float64
 	GetTaxRate() float64
 	GetWithdrawalTaxRate() float64
+	GetAccountType() string
 	GetContributionForDate(date string) float64
 	GetWithdrawalForDate(date string) float64
 	GetWithdrawalPercentageForDate(date string) float64
@@ -35,15 +43,48 @@ type InvestmentState struct {
 	GrowthBalance    float64
 }
 
+// Withdraw removes amount from the account, drawing on accumulated growth before
+// basis, and returns how much came from each. Callers cap amount at CurrentValue.
+func (s *InvestmentState) Withdraw(amount float64) (fromGrowth, fromBasis float64) {
+	if amount == 0 {
+		return 0, 0
+	}
+
+	availableGrowth := math.Max(s.GrowthBalance, 0)
+	fromGrowth = math.Min(availableGrowth, amount)
+	fromBasis = amount - fromGrowth
+
+	s.GrowthBalance -= fromGrowth
+	if s.GrowthBalance < 0 {
+		fromBasis += -s.GrowthBalance
+		s.GrowthBalance = 0
+	}
+	s.PrincipalBalance -= fromBasis
+	if s.PrincipalBalance < 0 {
+		s.PrincipalBalance = 0
+	}
+
+	s.CurrentValue = math.Max(s.CurrentValue-amount, 0)
+	return fromGrowth, fromBasis
+}
+
+// Deposit adds amount to the account's value and cost basis.
+func (s *InvestmentState) Deposit(amount float64) {
+	s.CurrentValue += amount
+	s.PrincipalBalance += amount
+}
+
 // InvestmentChange captures the computed deltas for a single investment in a given month.
 type InvestmentChange struct {
 	Name                 string
+	AccountType          string
 	Contribution         float64
 	Withdrawal           float64
 	WithdrawalPercentage float64
 	WithdrawalTax        float64
 	WithdrawalFromGrowth float64
 	WithdrawalFromBasis  float64
+	TaxableWithdrawal    float64 // portion of the withdrawal that is taxable income
 	Growth               float64
 	GrowthBeforeTax      float64
 	Tax                  float64
@@ -138,13 +179,15 @@ func (ip *InvestmentProcessor) ProcessInvestmentsForDate(date string, investment
 			}
 		}
 
-		monthlyRate := percentToDecimal(inv.GetAnnualReturnRate()) / constants.MonthsPerYear
+		monthlyRate := inv.GetMonthlyReturnForDate(date)
 		growthBeforeTax := state.CurrentValue * monthlyRate
 
+		accountType := CanonicalAccountType(inv.GetAccountType())
 		tax := 0.0
 		// Taxes are applied monthly to the investment growth and are deducted immediately from the account balance.
 		// This means that each month's growth is taxed before being added to the account, affecting compounding.
-		if growthBeforeTax > 0 && inv.GetTaxRate() > 0 {
+		// Tax-advantaged accounts defer or exempt growth.
+		if growthBeforeTax > 0 && inv.GetTaxRate() > 0 && TaxesGrowth(accountType) {
 			tax = growthBeforeTax * percentToDecimal(inv.GetTaxRate())
 		}
 
@@ -175,57 +218,30 @@ func (ip *InvestmentProcessor) ProcessInvestmentsForDate(date string, investment
 			withdrawal = 0
 		}
 
-		withdrawalFromGrowth := 0.0
-		withdrawalFromBasis := 0.0
-		if withdrawal != 0 {
-			availableGrowth := state.GrowthBalance
-			if availableGrowth < 0 {
-				availableGrowth = 0
-			}
-			if availableGrowth > 0 {
-				if availableGrowth >= withdrawal {
-					withdrawalFromGrowth = withdrawal
-				} else {
-					withdrawalFromGrowth = availableGrowth
-				}
-			}
-			withdrawalFromBasis = withdrawal - withdrawalFromGrowth
-
-			state.GrowthBalance -= withdrawalFromGrowth
-			if state.GrowthBalance < 0 {
-				withdrawalFromBasis += -state.GrowthBalance
-				state.GrowthBalance = 0
-			}
-			state.PrincipalBalance -= withdrawalFromBasis
-			if state.PrincipalBalance < 0 {
-				state.PrincipalBalance = 0
-			}
-
-			state.CurrentValue -= withdrawal
-		}
+		withdrawalFromGrowth, withdrawalFromBasis := state.Withdraw(withdrawal)
+		taxableWithdrawal := TaxableWithdrawal(accountType, withdrawal, withdrawalFromGrowth)
 
 		withdrawalTax := 0.0
-		if withdrawalFromGrowth > 0 {
+		if taxableWithdrawal > 0 {
 			taxRate := inv.GetWithdrawalTaxRate()
 			if taxRate > 0 {
-				withdrawalTax = withdrawalFromGrowth * percentToDecimal(taxRate)
+				withdrawalTax = taxableWithdrawal * percentToDecimal(taxRate)
 			}
 		}
-		if withdrawal != 0 {
-			state.CurrentValue = math.Max(state.CurrentValue, 0)
-		}
 
 		netChange := state.CurrentValue - previousValue
 		totalChange += netChange
 
 		changes = append(changes, InvestmentChange{
 			Name:                 inv.GetName(),
+			AccountType:          accountType,
 			Contribution:         contribution,
 			Withdrawal:           withdrawal,
 			WithdrawalPercentage: withdrawalPercent,
 			WithdrawalTax:        withdrawalTax,
 			WithdrawalFromGrowth: withdrawalFromGrowth,
 			WithdrawalFromBasis:  withdrawalFromBasis,
+			TaxableWithdrawal:    taxableWithdrawal,
 			Growth:               afterTaxGrowth,
 			GrowthBeforeTax:      growthBeforeTax,
 			Tax:                  tax,
@@ -236,3 +252,93 @@ func (ip *InvestmentProcessor) ProcessInvestmentsForDate(date string, investment
 
 	return totalChange, changes, nil
 }
+
+// DrawForShortfall sells enough of an investment for the after-tax proceeds to
+// cover shortfall, or as much as the balance allows. Withdrawal tax applies to
+// the taxable portion exactly as for scheduled withdrawals.
+func (ip *InvestmentProcessor) DrawForShortfall(inv Investment, state *InvestmentState, shortfall float64) InvestmentChange {
+	if inv == nil || state == nil || shortfall <= 0 || state.CurrentValue <= 0 {
+		return InvestmentChange{}
+	}
+
+	accountType := CanonicalAccountType(inv.GetAccountType())
+	taxRate := percentToDecimal(inv.GetWithdrawalTaxRate())
+	gross := shortfall
+	if taxRate > 0 {
+		switch accountType {
+		case AccountTypeTraditional:
+			gross = state.CurrentValue
+			if taxRate < 1 {
+				gross = shortfall / (1 - taxRate)
+			}
+		case AccountTypeTaxable:
+			availableGrowth := math.Max(state.GrowthBalance, 0)
+			if taxRate < 1 && shortfall <= availableGrowth*(1-taxRate) {
+				gross = shortfall / (1 - taxRate)
+			} else {
+				gross = shortfall + availableGrowth*taxRate
+			}
+		}
+	}
+
+	change := ip.withdraw(inv, state, gross)
+	ip.logger.Debug("Shortfall withdrawal",
+		zap.String("investment", change.Name),
+		zap.Float64("shortfall", shortfall),
+		zap.Float64("withdrawal", change.Withdrawal),
+		zap.Float64("withdrawalTax", change.WithdrawalTax),
+	)
+	return change
+}
+
+// Sell withdraws amount from an investment outside its schedule, or the whole
+// balance when it is smaller, taxed like any other withdrawal.
+func (ip *InvestmentProcessor) Sell(inv Investment, state *InvestmentState, amount float64) InvestmentChange {
+	if inv == nil || state == nil || amount <= 0 || state.CurrentValue <= 0 {
+		return InvestmentChange{}
+	}
+
+	change := ip.withdraw(inv, state, amount)
+	ip.logger.Debug("Investment sale",
+		zap.String("investment", change.Name),
+		zap.Float64("withdrawal", change.Withdrawal),
+		zap.Float64("withdrawalTax", change.WithdrawalTax),
+	)
+	return change
+}
+
+// DrawDistribution withdraws a required minimum distribution of amount, or the
+// whole balance when it is smaller, taxed like any other withdrawal.
+func (ip *InvestmentProcessor) DrawDistribution(inv Investment, state *InvestmentState, amount float64) InvestmentChange {
+	if inv == nil || state == nil || amount <= 0 || state.CurrentValue <= 0 {
+		return InvestmentChange{}
+	}
+
+	change := ip.withdraw(inv, state, amount)
+	ip.logger.Debug("Required minimum distribution",
+		zap.String("investment", change.Name),
+		zap.Float64("withdrawal", change.Withdrawal),
+		zap.Float64("withdrawalTax", change.WithdrawalTax),
+	)
+	return change
+}
+
+// withdraw takes gross (capped at the balance) out of the investment outside
+// its schedule and reports the change with withdrawal tax applied.
+func (ip *InvestmentProcessor) withdraw(inv Investment, state *InvestmentState, gross float64) InvestmentChange {
+	accountType := CanonicalAccountType(inv.GetAccountType())
+	gross = math.Min(gross, state.CurrentValue)
+
+	fromGrowth, fromBasis := state.Withdraw(gross)
+	taxable := TaxableWithdrawal(accountType, gross, fromGrowth)
+	return InvestmentChange{
+		Name:                 inv.GetName(),
+		AccountType:          accountType,
+		Withdrawal:           gross,
+		WithdrawalFromGrowth: fromGrowth,
+		WithdrawalFromBasis:  fromBasis,
+		TaxableWithdrawal:    taxable,
+		WithdrawalTax:        taxable * percentToDecimal(inv.GetWithdrawalTaxRate()),
+		NetChange:            -gross,
+	}
+}
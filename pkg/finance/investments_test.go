@@ -14,10 +14,12 @@ type stubInvestment struct {
 	annualRate    float64
 	taxRate       float64
 	withdrawalTax float64
+	accountType   string
 	contributions map[string]float64
 	withdrawals   map[string]float64
 	withdrawalPct map[string]float64
 	fromCash      bool
+	returnPath    map[string]float64
 }
 
 func (s stubInvestment) GetName() string {
@@ -32,6 +34,13 @@ func (s stubInvestment) GetAnnualReturnRate() float64 {
 	return s.annualRate
 }
 
+func (s stubInvestment) GetMonthlyReturnForDate(date string) float64 {
+	if rate, ok := s.returnPath[date]; ok {
+		return rate
+	}
+	return MonthlyRateFromAnnual(s.annualRate)
+}
+
 func (s stubInvestment) GetTaxRate() float64 {
 	return s.taxRate
 }
@@ -40,6 +49,10 @@ func (s stubInvestment) GetWithdrawalTaxRate() float64 {
 	return s.withdrawalTax
 }
 
+func (s stubInvestment) GetAccountType() string {
+	return s.accountType
+}
+
 func (s stubInvestment) GetContributionForDate(date string) float64 {
 	if s.contributions == nil {
 		return 0
@@ -143,6 +156,37 @@ func TestInvestmentProcessorProcessInvestmentsForDate_BasicGrowth(t *testing.T)
 	}
 }
 
+func TestInvestmentProcessorProcessInvestmentsForDate_SampledReturnPath(t *testing.T) {
+	processor := NewInvestmentProcessor(zap.NewNop())
+
+	inv := stubInvestment{
+		name:          "Sampled Fund",
+		startingValue: 1000,
+		annualRate:    12,
+		returnPath:    map[string]float64{"2025-07": -0.05},
+	}
+
+	investments := []Investment{inv}
+	states := processor.InitializeStates(investments)
+
+	totalChange, _, err := processor.ProcessInvestmentsForDate("2025-07", investments, constants.DateTimeLayout, states)
+	if err != nil {
+		t.Fatalf("ProcessInvestmentsForDate returned error: %v", err)
+	}
+	if math.Abs(totalChange-(-50)) > 1e-9 {
+		t.Errorf("totalChange with sampled return = %.2f, want -50", totalChange)
+	}
+
+	// Months missing from the sampled path fall back to the fixed annual rate.
+	totalChange, _, err = processor.ProcessInvestmentsForDate("2025-08", investments, constants.DateTimeLayout, states)
+	if err != nil {
+		t.Fatalf("ProcessInvestmentsForDate returned error: %v", err)
+	}
+	if math.Abs(totalChange-9.5) > 1e-9 {
+		t.Errorf("totalChange with fixed fallback = %.2f, want 9.50", totalChange)
+	}
+}
+
 func TestInvestmentProcessorProcessInvestmentsForDate_WithTaxesAndWithdrawal(t *testing.T) {
 	logger := zap.NewNop()
 	processor := NewInvestmentProcessor(logger)
@@ -366,3 +410,165 @@ func TestInvestmentProcessorProcessInvestmentsForDate_InvalidInput(t *testing.T)
 		t.Fatalf("expected error for empty layout, got nil")
 	}
 }
+
+func TestInvestmentProcessorDrawForShortfall(t *testing.T) {
+	tests := []struct {
+		name          string
+		withdrawalTax float64
+		accountType   string
+		state         InvestmentState
+		shortfall     float64
+		wantGross     float64
+		wantTax       float64
+		wantGrowth    float64
+	}{
+		{
+			name:      "untaxed draw",
+			state:     InvestmentState{CurrentValue: 1000, PrincipalBalance: 800, GrowthBalance: 200},
+			shortfall: 300,
+			wantGross: 300, wantTax: 0, wantGrowth: 200,
+		},
+		{
+			name:          "grossed up within growth",
+			withdrawalTax: 20,
+			state:         InvestmentState{CurrentValue: 1000, PrincipalBalance: 500, GrowthBalance: 500},
+			shortfall:     200,
+			wantGross:     250, wantTax: 50, wantGrowth: 250,
+		},
+		{
+			name:          "grossed up across growth and basis",
+			withdrawalTax: 20,
+			state:         InvestmentState{CurrentValue: 1000, PrincipalBalance: 900, GrowthBalance: 100},
+			shortfall:     300,
+			wantGross:     320, wantTax: 20, wantGrowth: 100,
+		},
+		{
+			name:          "traditional account taxes basis too",
+			withdrawalTax: 20,
+			accountType:   AccountTypeTraditional,
+			state:         InvestmentState{CurrentValue: 1000, PrincipalBalance: 1000},
+			shortfall:     200,
+			wantGross:     250, wantTax: 50, wantGrowth: 0,
+		},
+		{
+			name:          "roth account withdraws tax free",
+			withdrawalTax: 20,
+			accountType:   AccountTypeRoth,
+			state:         InvestmentState{CurrentValue: 1000, PrincipalBalance: 500, GrowthBalance: 500},
+			shortfall:     200,
+			wantGross:     200, wantTax: 0, wantGrowth: 200,
+		},
+		{
+			name:          "capped at balance",
+			withdrawalTax: 20,
+			state:         InvestmentState{CurrentValue: 100, PrincipalBalance: 100},
+			shortfall:     300,
+			wantGross:     100, wantTax: 0, wantGrowth: 0,
+		},
+	}
+
+	processor := NewInvestmentProcessor(zap.NewNop())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tt.state
+			inv := stubInvestment{name: "Brokerage", withdrawalTax: tt.withdrawalTax, accountType: tt.accountType}
+			change := processor.DrawForShortfall(inv, &state, tt.shortfall)
+
+			if math.Abs(change.Withdrawal-tt.wantGross) > 1e-9 {
+				t.Errorf("Withdrawal = %.4f, want %.4f", change.Withdrawal, tt.wantGross)
+			}
+			if math.Abs(change.WithdrawalTax-tt.wantTax) > 1e-9 {
+				t.Errorf("WithdrawalTax = %.4f, want %.4f", change.WithdrawalTax, tt.wantTax)
+			}
+			if math.Abs(change.WithdrawalFromGrowth-tt.wantGrowth) > 1e-9 {
+				t.Errorf("WithdrawalFromGrowth = %.4f, want %.4f", change.WithdrawalFromGrowth, tt.wantGrowth)
+			}
+			if math.Abs(state.CurrentValue-(tt.state.CurrentValue-tt.wantGross)) > 1e-9 {
+				t.Errorf("CurrentValue = %.4f, want %.4f", state.CurrentValue, tt.state.CurrentValue-tt.wantGross)
+			}
+			if tt.wantGross < tt.state.CurrentValue {
+				if net := change.Withdrawal - change.WithdrawalTax; math.Abs(net-tt.shortfall) > 1e-9 {
+					t.Errorf("net proceeds = %.4f, want %.4f", net, tt.shortfall)
+				}
+			}
+		})
+	}
+}
+
+func TestInvestmentProcessorAccountTypes(t *testing.T) {
+	processor := NewInvestmentProcessor(zap.NewNop())
+	date := "2025-01"
+
+	tests := []struct {
+		accountType     string
+		wantGrowthTax   float64
+		wantTaxable     float64
+		wantWithdrawTax float64
+	}{
+		{AccountTypeTaxable, 30, 90, 18},
+		{AccountTypeTraditional, 0, 500, 100},
+		{AccountTypeRoth, 0, 0, 0},
+		{AccountTypeHSA, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accountType, func(t *testing.T) {
+			inv := stubInvestment{
+				name:          "Account",
+				startingValue: 12000,
+				annualRate:    12,
+				taxRate:       25,
+				withdrawalTax: 20,
+				accountType:   tt.accountType,
+				withdrawals:   map[string]float64{date: 500},
+			}
+			states := processor.InitializeStates([]Investment{inv})
+
+			_, changes, err := processor.ProcessInvestmentsForDate(date, []Investment{inv}, constants.DateTimeLayout, states)
+			if err != nil {
+				t.Fatalf("ProcessInvestmentsForDate() error = %v", err)
+			}
+			change := changes[0]
+			if math.Abs(change.Tax-tt.wantGrowthTax) > 1e-9 {
+				t.Errorf("growth tax = %.4f, want %.4f", change.Tax, tt.wantGrowthTax)
+			}
+			if math.Abs(change.TaxableWithdrawal-tt.wantTaxable) > 1e-9 {
+				t.Errorf("TaxableWithdrawal = %.4f, want %.4f", change.TaxableWithdrawal, tt.wantTaxable)
+			}
+			if math.Abs(change.WithdrawalTax-tt.wantWithdrawTax) > 1e-9 {
+				t.Errorf("WithdrawalTax = %.4f, want %.4f", change.WithdrawalTax, tt.wantWithdrawTax)
+			}
+		})
+	}
+}
+
+func TestInvestmentProcessorDrawDistribution(t *testing.T) {
+	processor := NewInvestmentProcessor(zap.NewNop())
+	inv := stubInvestment{name: "IRA", withdrawalTax: 10, accountType: AccountTypeTraditional}
+	state := InvestmentState{CurrentValue: 3000, PrincipalBalance: 3000}
+
+	change := processor.DrawDistribution(inv, &state, 4000)
+	if change.Withdrawal != 3000 || state.CurrentValue != 0 {
+		t.Errorf("Withdrawal = %.2f, balance = %.2f; want whole balance distributed", change.Withdrawal, state.CurrentValue)
+	}
+	if math.Abs(change.WithdrawalTax-300) > 1e-9 {
+		t.Errorf("WithdrawalTax = %.4f, want 300", change.WithdrawalTax)
+	}
+}
+
+func TestInvestmentProcessorSell(t *testing.T) {
+	processor := NewInvestmentProcessor(zap.NewNop())
+	inv := stubInvestment{name: "IRA", withdrawalTax: 10, accountType: AccountTypeTraditional}
+	state := InvestmentState{CurrentValue: 5000, PrincipalBalance: 5000}
+
+	change := processor.Sell(inv, &state, 2000)
+	if change.Withdrawal != 2000 || state.CurrentValue != 3000 {
+		t.Errorf("Withdrawal = %.2f, balance = %.2f; want 2000 sold from 5000", change.Withdrawal, state.CurrentValue)
+	}
+	if math.Abs(change.WithdrawalTax-200) > 1e-9 {
+		t.Errorf("WithdrawalTax = %.4f, want 200", change.WithdrawalTax)
+	}
+	if empty := processor.Sell(inv, &state, 0); empty.Withdrawal != 0 {
+		t.Errorf("Sell(0) withdrew %.2f", empty.Withdrawal)
+	}
+}
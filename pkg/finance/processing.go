@@ -49,14 +49,19 @@ func (ep *EventProcessor) ProcessEventsForDate(date string, events []EventWithDa
 			continue
 		}
 
-		for _, eventDate := range eventDates {
+		amounts := event.GetAmountList()
+		for i, eventDate := range eventDates {
 			if dateT.Equal(eventDate) {
+				eventAmount := event.GetAmount()
+				if i < len(amounts) {
+					eventAmount = amounts[i]
+				}
 				ep.logger.Debug("Event active",
 					zap.String("date", date),
 					zap.String("event", event.GetName()),
-					zap.Float64("amount", event.GetAmount()),
+					zap.Float64("amount", eventAmount),
 				)
-				amount += event.GetAmount()
+				amount += eventAmount
 				break
 			}
 		}
@@ -104,11 +109,14 @@ func (lp *LoanProcessor) ProcessLoansForDate(date string, loans []LoanWithSchedu
 	return amount
 }
 
-// EventWithDates interface for events that have date lists
+// EventWithDates interface for events that have date lists. GetAmountList
+// optionally returns one amount per date; when it is shorter than the date list
+// GetAmount is used instead.
 type EventWithDates interface {
 	GetName() string
 	GetAmount() float64
 	GetDateList() []time.Time
+	GetAmountList() []float64
 }
 
 // LoanWithSchedule interface for loans that have amortization schedules
@@ -163,3 +171,27 @@ func (fe *ForecastEngine) ProcessInvestments(date string, investments []Investme
 	}
 	return fe.investmentProcessor.ProcessInvestmentsForDate(date, investments, layout, states)
 }
+
+// DrawForShortfall sells from an investment to cover a cash shortfall.
+func (fe *ForecastEngine) DrawForShortfall(inv Investment, state *InvestmentState, shortfall float64) InvestmentChange {
+	if fe.investmentProcessor == nil {
+		return InvestmentChange{}
+	}
+	return fe.investmentProcessor.DrawForShortfall(inv, state, shortfall)
+}
+
+// Sell withdraws an amount from an investment into cash.
+func (fe *ForecastEngine) Sell(inv Investment, state *InvestmentState, amount float64) InvestmentChange {
+	if fe.investmentProcessor == nil {
+		return InvestmentChange{}
+	}
+	return fe.investmentProcessor.Sell(inv, state, amount)
+}
+
+// DrawDistribution withdraws a required minimum distribution from an investment.
+func (fe *ForecastEngine) DrawDistribution(inv Investment, state *InvestmentState, amount float64) InvestmentChange {
+	if fe.investmentProcessor == nil {
+		return InvestmentChange{}
+	}
+	return fe.investmentProcessor.DrawDistribution(inv, state, amount)
+}
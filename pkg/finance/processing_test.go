@@ -9,9 +9,10 @@ import (
 
 // Mock implementations for testing
 type mockEvent struct {
-	name     string
-	amount   float64
-	dateList []time.Time
+	name       string
+	amount     float64
+	dateList   []time.Time
+	amountList []float64
 }
 
 func (m mockEvent) GetName() string {
@@ -26,6 +27,10 @@ func (m mockEvent) GetDateList() []time.Time {
 	return m.dateList
 }
 
+func (m mockEvent) GetAmountList() []float64 {
+	return m.amountList
+}
+
 type mockLoan struct {
 	name     string
 	schedule map[string]float64
@@ -101,6 +106,41 @@ func TestEventProcessor_ProcessEventsForDate(t *testing.T) {
 	}
 }
 
+func TestEventProcessor_ProcessEventsForDate_AmountList(t *testing.T) {
+	processor := NewEventProcessor(zap.NewNop())
+
+	date1, _ := time.Parse("2006-01", "2025-06")
+	date2, _ := time.Parse("2006-01", "2026-06")
+	date3, _ := time.Parse("2006-01", "2027-06")
+
+	events := []EventWithDates{
+		mockEvent{
+			name:       "Indexed expense",
+			amount:     -100.0,
+			dateList:   []time.Time{date1, date2, date3},
+			amountList: []float64{-100.0, -103.0},
+		},
+	}
+
+	tests := []struct {
+		date     string
+		expected float64
+	}{
+		{"2025-06", -100.0},
+		{"2026-06", -103.0},
+		{"2027-06", -100.0}, // falls back to GetAmount beyond the amount list
+	}
+	for _, tt := range tests {
+		amount, err := processor.ProcessEventsForDate(tt.date, events, "2006-01")
+		if err != nil {
+			t.Fatalf("ProcessEventsForDate(%s) returned error: %v", tt.date, err)
+		}
+		if amount != tt.expected {
+			t.Errorf("ProcessEventsForDate(%s) = %.2f, want %.2f", tt.date, amount, tt.expected)
+		}
+	}
+}
+
 func TestEventProcessor_ProcessEventsForDateInvalidDate(t *testing.T) {
 	logger := zap.NewNop()
 	processor := NewEventProcessor(logger)
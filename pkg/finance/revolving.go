@@ -0,0 +1,92 @@
+package finance
+
+import (
+	"math"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+// RevolvingDebt is a credit card style balance carried from month to month.
+// Unlike loans its payments are computed during the simulation: each month the
+// carried balance accrues interest at APR/12, the month's charges are added,
+// and the payment is the minimum (MinimumPercent of the balance, but at least
+// MinimumPayment). With PayInFull the payment rises toward the full balance as
+// far as Available cash allows. RevolvingDebt implements LoanWithSchedule.
+type RevolvingDebt struct {
+	Name           string
+	Balance        float64
+	APR            float64
+	MinimumPercent float64
+	MinimumPayment float64
+	PayInFull      bool
+	Charges        []EventWithDates
+
+	// Available is the cash that may go toward paying in full this month; the
+	// caller sets it before the month's payment is processed.
+	Available float64
+
+	lastDate    string
+	lastPayment float64
+	lastCharges float64
+	lastAccrued float64
+}
+
+// GetName returns the debt's name.
+func (d *RevolvingDebt) GetName() string {
+	return d.Name
+}
+
+// GetPaymentForDate advances the debt to date and returns the payment made.
+// Repeated calls for the same date return the same payment.
+func (d *RevolvingDebt) GetPaymentForDate(date string) (float64, bool) {
+	if date != d.lastDate {
+		d.advance(date)
+	}
+	return d.lastPayment, d.lastPayment > 0
+}
+
+// LastMonth returns the interest accrued and charges added by the most recent
+// month processed.
+func (d *RevolvingDebt) LastMonth() (interest, charges float64) {
+	return d.lastAccrued, d.lastCharges
+}
+
+// MinimumDue returns the minimum payment on balance.
+func (d *RevolvingDebt) MinimumDue(balance float64) float64 {
+	minimum := math.Max(balance*d.MinimumPercent/constants.PercentageMultiplier, d.MinimumPayment)
+	return math.Min(minimum, balance)
+}
+
+func (d *RevolvingDebt) advance(date string) {
+	d.lastDate = date
+	d.lastAccrued = math.Max(d.Balance, 0) * d.APR / (constants.PercentageMultiplier * constants.MonthsPerYear)
+	d.lastCharges = d.chargesFor(date)
+	d.Balance += d.lastAccrued + d.lastCharges
+
+	payment := d.MinimumDue(d.Balance)
+	if d.PayInFull {
+		payment = math.Max(payment, math.Min(d.Balance, d.Available))
+	}
+	d.Balance -= payment
+	d.lastPayment = payment
+}
+
+// chargesFor totals the charges falling on date.
+func (d *RevolvingDebt) chargesFor(date string) float64 {
+	total := 0.0
+	for _, charge := range d.Charges {
+		amounts := charge.GetAmountList()
+		for i, chargeDate := range charge.GetDateList() {
+			if chargeDate.Format(datetime.DateTimeLayout) != date {
+				continue
+			}
+			if i < len(amounts) {
+				total += amounts[i]
+			} else {
+				total += charge.GetAmount()
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,71 @@
+package finance
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRevolvingDebtMinimumPayments(t *testing.T) {
+	debt := &RevolvingDebt{Name: "Card", Balance: 1000, APR: 12, MinimumPercent: 2, MinimumPayment: 25}
+
+	// 10.00 of interest brings the balance to 1010; 2% is below the floor.
+	payment, ok := debt.GetPaymentForDate("2025-02")
+	if !ok || payment != 25 {
+		t.Fatalf("GetPaymentForDate() = %.2f, %v, want 25.00, true", payment, ok)
+	}
+	if math.Abs(debt.Balance-985) > 1e-9 {
+		t.Errorf("Balance = %.2f, want 985.00", debt.Balance)
+	}
+	if interest, charges := debt.LastMonth(); math.Abs(interest-10) > 1e-9 || charges != 0 {
+		t.Errorf("LastMonth() = %.2f, %.2f, want 10.00, 0.00", interest, charges)
+	}
+
+	// Asking again for the same month does not advance the debt.
+	if payment, _ := debt.GetPaymentForDate("2025-02"); payment != 25 || math.Abs(debt.Balance-985) > 1e-9 {
+		t.Errorf("repeated GetPaymentForDate() = %.2f with balance %.2f", payment, debt.Balance)
+	}
+
+	small := &RevolvingDebt{Name: "Store card", Balance: 10, MinimumPayment: 25}
+	if payment, _ := small.GetPaymentForDate("2025-02"); payment != 10 || small.Balance != 0 {
+		t.Errorf("minimum on a small balance = %.2f leaving %.2f, want 10.00 leaving 0", payment, small.Balance)
+	}
+	if _, ok := small.GetPaymentForDate("2025-03"); ok {
+		t.Error("expected no payment once the balance is cleared")
+	}
+}
+
+func TestRevolvingDebtPayInFull(t *testing.T) {
+	debt := &RevolvingDebt{
+		Name:           "Card",
+		Balance:        1000,
+		APR:            24,
+		MinimumPercent: 2,
+		PayInFull:      true,
+		Charges: []EventWithDates{mockEvent{
+			name:     "Groceries",
+			amount:   100,
+			dateList: []time.Time{time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		}},
+	}
+
+	// Only 600 of the 1020 balance can be paid from cash.
+	debt.Available = 600
+	if payment, _ := debt.GetPaymentForDate("2025-02"); payment != 600 || math.Abs(debt.Balance-420) > 1e-9 {
+		t.Errorf("limited pay in full = %.2f leaving %.2f, want 600.00 leaving 420.00", payment, debt.Balance)
+	}
+
+	// With enough cash the interest and the new charge are cleared too.
+	debt.Available = 10000
+	payment, _ := debt.GetPaymentForDate("2025-03")
+	if math.Abs(payment-528.4) > 1e-9 || debt.Balance != 0 {
+		t.Errorf("pay in full = %.2f leaving %.2f, want 528.40 leaving 0", payment, debt.Balance)
+	}
+
+	// Without spare cash the minimum is still paid.
+	debt.Balance = 500
+	debt.Available = -50
+	if payment, _ := debt.GetPaymentForDate("2025-04"); math.Abs(payment-10.2) > 1e-9 {
+		t.Errorf("pay in full without cash = %.2f, want the 10.20 minimum", payment)
+	}
+}
@@ -0,0 +1,68 @@
+package loans
+
+import (
+	"math"
+)
+
+// DefaultAdjustmentInterval is the number of months between rate resets when
+// an adjustable-rate loan does not set one.
+const DefaultAdjustmentInterval = 12
+
+// IndexStep sets the index rate from Date onward.
+type IndexStep struct {
+	Date string
+	Rate float64
+}
+
+// AdjustableRate describes an adjustable-rate loan. The loan's InterestRate
+// holds for the first FixedMonths payments. The rate then resets every
+// AdjustmentInterval months to the index plus Margin, moving at most
+// PeriodicCap from the previous rate and never more than LifetimeCap above the
+// initial rate. Caps of zero leave the rate uncapped. Rates are percentages.
+type AdjustableRate struct {
+	FixedMonths        int
+	AdjustmentInterval int
+	Index              float64
+	IndexSchedule      []IndexStep        // ordered by date; each step replaces Index from its date
+	IndexPath          map[string]float64 // per-month index rates that override Index and IndexSchedule
+	Margin             float64
+	PeriodicCap        float64
+	LifetimeCap        float64
+}
+
+// IndexAt returns the index rate in effect for date.
+func (arm *AdjustableRate) IndexAt(date string) float64 {
+	if rate, ok := arm.IndexPath[date]; ok {
+		return rate
+	}
+	index := arm.Index
+	for _, step := range arm.IndexSchedule {
+		if step.Date > date {
+			break
+		}
+		index = step.Rate
+	}
+	return index
+}
+
+// resets reports whether the rate resets with the given payment number, where
+// the first payment is 1.
+func (arm *AdjustableRate) resets(payment int) bool {
+	interval := arm.AdjustmentInterval
+	if interval <= 0 {
+		interval = DefaultAdjustmentInterval
+	}
+	return payment > arm.FixedMonths && (payment-arm.FixedMonths-1)%interval == 0
+}
+
+// nextRate returns the capped rate for a reset on date.
+func (arm *AdjustableRate) nextRate(date string, current, initial float64) float64 {
+	rate := arm.IndexAt(date) + arm.Margin
+	if arm.PeriodicCap > 0 {
+		rate = math.Min(math.Max(rate, current-arm.PeriodicCap), current+arm.PeriodicCap)
+	}
+	if arm.LifetimeCap > 0 {
+		rate = math.Min(rate, initial+arm.LifetimeCap)
+	}
+	return math.Max(rate, 0)
+}
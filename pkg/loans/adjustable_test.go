@@ -0,0 +1,102 @@
+package loans
+
+import (
+	"math"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestAdjustableRateIndexAt(t *testing.T) {
+	arm := &AdjustableRate{
+		Index:         4,
+		IndexSchedule: []IndexStep{{Date: "2026-01", Rate: 5}, {Date: "2027-01", Rate: 3}},
+		IndexPath:     map[string]float64{"2027-06": 7},
+	}
+	tests := []struct {
+		date string
+		want float64
+	}{
+		{"2025-06", 4},
+		{"2026-01", 5},
+		{"2026-12", 5},
+		{"2027-03", 3},
+		{"2027-06", 7},
+	}
+	for _, tt := range tests {
+		if got := arm.IndexAt(tt.date); got != tt.want {
+			t.Errorf("IndexAt(%s) = %.2f, want %.2f", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestAmortizationScheduleGenerator_AdjustableRate(t *testing.T) {
+	generator := NewAmortizationScheduleGenerator(zap.NewNop())
+	loan := &LoanConfig{
+		Name:         "ARM",
+		StartDate:    "2025-01",
+		Principal:    12000,
+		InterestRate: 3,
+		Term:         24,
+		Adjustable: &AdjustableRate{
+			FixedMonths:        6,
+			AdjustmentInterval: 6,
+			Index:              4,
+			Margin:             2,
+			PeriodicCap:        2,
+			LifetimeCap:        2.5,
+		},
+	}
+	schedule, err := generator.GenerateSchedule(loan, "2030-01")
+	if err != nil {
+		t.Fatalf("GenerateSchedule() error = %v", err)
+	}
+
+	// The 6% fully indexed rate is held to 5% by the periodic cap at the
+	// first reset and to 5.5% by the lifetime cap afterward.
+	rates := []struct {
+		date string
+		want float64
+	}{
+		{"2025-01", 3},
+		{"2025-06", 3},
+		{"2025-07", 5},
+		{"2025-12", 5},
+		{"2026-01", 5.5},
+		{"2026-07", 5.5},
+	}
+	for _, tt := range rates {
+		if got := schedule[tt.date].InterestRate; got != tt.want {
+			t.Errorf("InterestRate[%s] = %.2f, want %.2f", tt.date, got, tt.want)
+		}
+	}
+
+	want := CalculateMonthlyPayment(schedule["2025-06"].RemainingPrincipal, 0, 5, 18)
+	if got := schedule["2025-07"].Payment; math.Abs(got-want) > 1e-6 {
+		t.Errorf("payment after first reset = %.2f, want %.2f", got, want)
+	}
+	if got := schedule["2025-07"].Interest; math.Abs(got-CalculateInterestPayment(schedule["2025-06"].RemainingPrincipal, 5)) > 1e-6 {
+		t.Errorf("interest after first reset = %.2f", got)
+	}
+	if got := schedule["2026-12"].RemainingPrincipal; got != 0 {
+		t.Errorf("RemainingPrincipal at the end of the term = %.2f, want 0", got)
+	}
+	if got := schedule["2026-11"].RemainingPrincipal; math.Abs(got-schedule["2026-12"].Principal) > 0.01 {
+		t.Errorf("final payment principal %.2f should clear the balance %.2f", schedule["2026-12"].Principal, got)
+	}
+	if notes := loan.Notes["2025-07"]; len(notes) != 1 {
+		t.Errorf("expected a rate reset note in 2025-07, got %v", loan.Notes)
+	}
+	if _, ok := loan.Notes["2025-08"]; ok {
+		t.Error("expected no rate reset between adjustments")
+	}
+
+	loan.Refinances = []Refinance{{Date: "2025-04", InterestRate: 4, Term: 21}}
+	schedule, err = generator.GenerateSchedule(loan, "2030-01")
+	if err != nil {
+		t.Fatalf("GenerateSchedule() error = %v", err)
+	}
+	if got := schedule["2026-01"].InterestRate; got != 4 {
+		t.Errorf("refinanced loan rate = %.2f, want a fixed 4.00", got)
+	}
+}
@@ -4,6 +4,7 @@ package loans
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/iwvelando/finance-forecast/pkg/constants"
 	"github.com/iwvelando/finance-forecast/pkg/datetime"
@@ -18,6 +19,7 @@ type Payment struct {
 	Interest           float64
 	RemainingPrincipal float64
 	RefundableEscrow   float64
+	InterestRate       float64 // annual rate in effect for the payment
 }
 
 // CalculateMonthlyPayment calculates the monthly payment for a loan using the standard amortization formula.
@@ -33,6 +35,22 @@ func CalculateMonthlyPayment(principal, downPayment, annualInterestRate float64,
 	return (principal - downPayment) * periodicInterestRate / discountFactor
 }
 
+// TermForPayment returns the number of monthly payments needed to repay
+// balance at annualInterestRate, the last of them usually partial. It returns
+// an error when payment does not cover the first month's interest.
+func TermForPayment(balance, annualInterestRate, payment float64) (int, error) {
+	interest := CalculateInterestPayment(balance, annualInterestRate)
+	if payment <= interest {
+		return 0, fmt.Errorf("payment %.2f does not cover the %.2f monthly interest", payment, interest)
+	}
+	if annualInterestRate == 0 {
+		return int(math.Ceil(balance/payment - 1e-9)), nil
+	}
+	periodicInterestRate := annualInterestRate / (constants.PercentageMultiplier * constants.MonthsPerYear)
+	months := -math.Log(1-periodicInterestRate*balance/payment) / math.Log(1+periodicInterestRate)
+	return int(math.Ceil(months - 1e-9)), nil
+}
+
 // CalculateInterestPayment calculates the interest portion of a payment.
 func CalculateInterestPayment(remainingPrincipal, annualInterestRate float64) float64 {
 	return remainingPrincipal * annualInterestRate / (constants.PercentageMultiplier * constants.MonthsPerYear)
@@ -68,23 +86,38 @@ func CheckEarlyPayoffThreshold(loanName, startDate, currentMonth string, thresho
 
 // Event represents an extra principal payment event
 type Event struct {
-	Name      string
-	Amount    float64
-	StartDate string
-	EndDate   string
-	Frequency int      // months
-	DateList  []string // dates in YYYY-MM format
+	Name       string
+	Amount     float64
+	StartDate  string
+	EndDate    string
+	Frequency  int       // months
+	DateList   []string  // dates in YYYY-MM format
+	AmountList []float64 // optional per-date amounts when the payment grows
+}
+
+// AmountAt returns the amount for the index-th occurrence of the event.
+func (e Event) AmountAt(index int) float64 {
+	if index >= 0 && index < len(e.AmountList) {
+		return e.AmountList[index]
+	}
+	return e.Amount
 }
 
 // LoanConfig represents loan configuration parameters
 type LoanConfig struct {
 	Name                    string
+	Type                    string // one of the Type constants; empty for amortizing
 	StartDate               string
 	Principal               float64
 	InterestRate            float64
 	Term                    int
+	Payment                 float64 // replaces Term; the term follows from the payment
+	PaymentFrequency        string  // one of the Payment constants; empty for monthly
+	InterestOnlyMonths      int     // TypeInterestOnly
+	AmortizationTerm        int     // TypeBalloon
 	DownPayment             float64
 	Escrow                  float64
+	EscrowGrowthRate        float64 // annual percentage applied to escrow each loan year
 	MortgageInsurance       float64
 	MortgageInsuranceCutoff float64
 	EarlyPayoffThreshold    float64
@@ -93,7 +126,37 @@ type LoanConfig struct {
 	SellPrice               float64
 	SellCostsNet            float64
 	ExtraPrincipalPayments  []Event
+	Refinances              []Refinance
+	Adjustable              *AdjustableRate
 	AmortizationSchedule    map[string]Payment
+	// Notes holds what GenerateSchedule reports by month, such as refinances.
+	Notes map[string][]string
+}
+
+// EscrowForDate returns the monthly escrow in effect for date. Escrow grows once
+// per year elapsed since the loan start date.
+func (loan *LoanConfig) EscrowForDate(date string) float64 {
+	if loan.EscrowGrowthRate == 0 || loan.Escrow == 0 {
+		return loan.Escrow
+	}
+	start, err := time.Parse(datetime.DateTimeLayout, loan.StartDate)
+	if err != nil {
+		return loan.Escrow
+	}
+	current, err := time.Parse(datetime.DateTimeLayout, date)
+	if err != nil {
+		return loan.Escrow
+	}
+	years := datetime.MonthsBetween(start, current) / constants.MonthsPerYear
+	return mathutil.CompoundGrowth(loan.Escrow, loan.EscrowGrowthRate, years)
+}
+
+// addNote records a schedule note for month.
+func (loan *LoanConfig) addNote(month, note string) {
+	if loan.Notes == nil {
+		loan.Notes = make(map[string][]string)
+	}
+	loan.Notes[month] = append(loan.Notes[month], note)
 }
 
 // AmortizationScheduleGenerator provides utilities for generating loan amortization schedules
@@ -112,30 +175,58 @@ func NewAmortizationScheduleGenerator(logger *zap.Logger) *AmortizationScheduleG
 // GenerateSchedule creates a complete amortization schedule for a loan
 func (g *AmortizationScheduleGenerator) GenerateSchedule(loan *LoanConfig, deathDate string) (map[string]Payment, error) {
 	schedule := make(map[string]Payment)
-
-	// Calculate basic loan parameters
-	monthlyPayment := CalculateMonthlyPayment(loan.Principal, loan.DownPayment, loan.InterestRate, loan.Term)
+	loan.Notes = nil
+
+	// Calculate basic loan parameters; refinances replace the rate, payment,
+	// and final month of the term, and adjustable rates replace the rate and
+	// payment at each reset until the loan is refinanced. A refinance also
+	// ends interest-only and balloon terms, though simple interest continues.
+	// A loan given by its payment runs until that payment repays it, with a
+	// partial final payment.
+	loanType := loan.Type
+	interestRate := loan.InterestRate
+	lastMonth := loan.Term
+	adjustable := loan.Adjustable
+	if loan.Payment > 0 {
+		term, err := TermForPayment(loan.Principal-loan.DownPayment, interestRate, loan.Payment)
+		if err != nil {
+			return nil, err
+		}
+		lastMonth = term
+	}
+	monthlyPayment := loan.regularPayment(loanType, loan.Principal-loan.DownPayment, interestRate, 1, lastMonth)
+	if loan.Payment > 0 {
+		monthlyPayment = loan.Payment
+	}
+	clearsFinalBalance := loanType == TypeBalloon || loanType == TypeSimpleInterest || loan.Payment > 0
 
 	// Handle first payment
 	var firstPayment Payment
 	extraPrincipal := 0.0
 	for _, event := range loan.ExtraPrincipalPayments {
-		for _, eventDate := range event.DateList {
+		for i, eventDate := range event.DateList {
 			if eventDate == loan.StartDate {
 				g.logger.Debug(fmt.Sprintf("%s: applying extra principal payment %.2f for loan %s",
-					loan.StartDate, event.Amount, loan.Name),
+					loan.StartDate, event.AmountAt(i), loan.Name),
 					zap.String("op", "loans.GenerateSchedule"),
 				)
-				extraPrincipal += event.Amount
+				extraPrincipal += event.AmountAt(i)
 			}
 		}
 	}
 
-	firstPayment.Payment = monthlyPayment + loan.Escrow + loan.DownPayment + extraPrincipal
-	firstPayment.Interest = CalculateInterestPayment(loan.Principal-loan.DownPayment, loan.InterestRate)
-	firstPayment.Principal = monthlyPayment - firstPayment.Interest + extraPrincipal
+	scheduled := loan.scheduledPayment(monthlyPayment, loan.StartDate)
+	firstPayment.Payment = scheduled + loan.Escrow + loan.DownPayment + extraPrincipal
+	firstPayment.Interest = interestFor(loanType, loan.Principal-loan.DownPayment, interestRate, loan.StartDate)
+	firstPayment.Principal = scheduled - firstPayment.Interest + extraPrincipal
+	if lastMonth == 1 && clearsFinalBalance {
+		due := (loan.Principal - loan.DownPayment) - firstPayment.Principal
+		firstPayment.Principal += due
+		firstPayment.Payment += due
+	}
 	firstPayment.RemainingPrincipal = (loan.Principal - loan.DownPayment) - firstPayment.Principal
 	firstPayment.RefundableEscrow = loan.Escrow
+	firstPayment.InterestRate = interestRate
 	schedule[loan.StartDate] = firstPayment
 
 	// Iterate over the remainder of the term.
@@ -145,7 +236,7 @@ func (g *AmortizationScheduleGenerator) GenerateSchedule(loan *LoanConfig, death
 		return nil, err
 	}
 
-	for month := 2; month <= loan.Term; month++ {
+	for month := 2; month <= lastMonth; month++ {
 		// Check if we've reached or passed the death date
 		if currentMonth == deathDate {
 			g.logger.Debug(fmt.Sprintf("Loan %s reached death date %s, stopping payment generation",
@@ -169,6 +260,7 @@ func (g *AmortizationScheduleGenerator) GenerateSchedule(loan *LoanConfig, death
 		}
 
 		var currentPayment Payment
+		escrow := loan.EscrowForDate(currentMonth)
 
 		// Calculate refundable escrow
 		january, err := datetime.CheckMonth(currentMonth, "01")
@@ -178,7 +270,7 @@ func (g *AmortizationScheduleGenerator) GenerateSchedule(loan *LoanConfig, death
 		if january {
 			currentPayment.RefundableEscrow = 0.00
 		} else {
-			currentPayment.RefundableEscrow = schedule[previousMonth].RefundableEscrow + loan.Escrow
+			currentPayment.RefundableEscrow = schedule[previousMonth].RefundableEscrow + escrow
 		}
 
 		if loan.EarlyPayoffDate == currentMonth {
@@ -206,7 +298,7 @@ func (g *AmortizationScheduleGenerator) GenerateSchedule(loan *LoanConfig, death
 					}
 					if december {
 						var escrowPayment Payment
-						escrowPayment.Payment = loan.Escrow * 12
+						escrowPayment.Payment = loan.EscrowForDate(currentMonth) * 12
 						schedule[currentMonth] = escrowPayment
 					}
 					currentMonth, err = datetime.OffsetDate(currentMonth, datetime.DateTimeLayout, 1)
@@ -217,22 +309,87 @@ func (g *AmortizationScheduleGenerator) GenerateSchedule(loan *LoanConfig, death
 			}
 			break
 		} else {
+			balance := schedule[previousMonth].RemainingPrincipal
+			refinanceCosts := 0.0
+			if refinance, ok := loan.refinanceAt(currentMonth); ok {
+				balance += refinance.CashOut
+				newPayment := CalculateMonthlyPayment(balance, 0, refinance.InterestRate, refinance.Term)
+				note, err := refinanceNote(refinance, balance, monthlyPayment, newPayment)
+				if err != nil {
+					return nil, err
+				}
+				g.logger.Debug(fmt.Sprintf("%s: loan %s %s", currentMonth, loan.Name, note),
+					zap.String("op", "loans.GenerateSchedule"),
+				)
+				loan.addNote(currentMonth, note)
+				monthlyPayment = newPayment
+				interestRate = refinance.InterestRate
+				lastMonth = month + refinance.Term - 1
+				refinanceCosts = refinance.ClosingCosts - refinance.CashOut
+				adjustable = nil
+				if loanType != TypeSimpleInterest {
+					loanType = ""
+				}
+				clearsFinalBalance = loanType == TypeSimpleInterest
+			} else if adjustable != nil && adjustable.resets(month) {
+				rate := adjustable.nextRate(currentMonth, interestRate, loan.InterestRate)
+				newPayment := loan.regularPayment(loanType, balance, rate, month, lastMonth)
+				note := fmt.Sprintf("rate reset to %.2f%%, payment %.2f -> %.2f", rate, monthlyPayment, newPayment)
+				g.logger.Debug(fmt.Sprintf("%s: loan %s %s", currentMonth, loan.Name, note),
+					zap.String("op", "loans.GenerateSchedule"),
+				)
+				loan.addNote(currentMonth, note)
+				monthlyPayment = newPayment
+				interestRate = rate
+			}
+			if loanType == TypeInterestOnly && month <= loan.InterestOnlyMonths+1 {
+				// Interest-only payments follow the balance, which extra
+				// principal may have reduced, until amortization begins.
+				newPayment := loan.regularPayment(loanType, balance, interestRate, month, lastMonth)
+				if month == loan.InterestOnlyMonths+1 {
+					note := fmt.Sprintf("interest-only period ends, payment %.2f -> %.2f", monthlyPayment, newPayment)
+					g.logger.Debug(fmt.Sprintf("%s: loan %s %s", currentMonth, loan.Name, note),
+						zap.String("op", "loans.GenerateSchedule"),
+					)
+					loan.addNote(currentMonth, note)
+				}
+				monthlyPayment = newPayment
+			}
+
 			// Check for extra principal using the advanced calculation with overpayment prevention
 			var loanEvents []Event
 			loanEvents = append(loanEvents, loan.ExtraPrincipalPayments...)
 
+			scheduled := loan.scheduledPayment(monthlyPayment, currentMonth)
 			extraPrincipal, err := CalculateExtraPrincipalWithOverpaymentPrevention(
-				g.logger, loanEvents, currentMonth, monthlyPayment,
-				schedule[previousMonth].RemainingPrincipal, loan.InterestRate, loan.Name)
+				g.logger, loanEvents, currentMonth, scheduled,
+				balance, interestRate, loan.Name)
 			if err != nil {
 				return nil, err
 			}
 
-			currentPayment.Payment = monthlyPayment + loan.Escrow + extraPrincipal
-			currentPayment.Interest = CalculateInterestPayment(schedule[previousMonth].RemainingPrincipal, loan.InterestRate)
-			currentPayment.Principal = monthlyPayment - currentPayment.Interest + extraPrincipal
+			currentPayment.Payment = scheduled + escrow + extraPrincipal + refinanceCosts
+			currentPayment.InterestRate = interestRate
+			currentPayment.Interest = interestFor(loanType, balance, interestRate, currentMonth)
+			currentPayment.Principal = scheduled - currentPayment.Interest + extraPrincipal
+			if month < lastMonth && loan.PaymentFrequency == PaymentBiweekly && currentPayment.Principal >= balance {
+				// The extra biweekly payments clear the balance before the
+				// term ends, with a partial final payment.
+				due := balance - currentPayment.Principal
+				currentPayment.Principal += due
+				currentPayment.Payment += due
+				loan.addNote(currentMonth, fmt.Sprintf("paid off with biweekly payments, %d months early", lastMonth-month))
+			} else if month == lastMonth && clearsFinalBalance {
+				// The final payment clears whatever principal remains.
+				due := balance - currentPayment.Principal
+				currentPayment.Principal += due
+				currentPayment.Payment += due
+				if loanType == TypeBalloon {
+					loan.addNote(currentMonth, fmt.Sprintf("balloon payment of %.2f due", due))
+				}
+			}
 
-			if month == loan.Term || mathutil.Round(schedule[previousMonth].RemainingPrincipal-currentPayment.Principal) == 0 {
+			if month == lastMonth || mathutil.Round(balance-currentPayment.Principal) == 0 {
 				// We will get machine error otherwise so just set to 0.
 				currentPayment.RemainingPrincipal = 0.00
 				december, err := datetime.CheckMonth(currentMonth, "12")
@@ -245,10 +402,10 @@ func (g *AmortizationScheduleGenerator) GenerateSchedule(loan *LoanConfig, death
 					// reduce further by an escrow payment. Note that here we assume that
 					// if a loan matures naturally then escrow will be applied that year
 					// on december; this is not the assumption we use for early payoffs.
-					currentPayment.Payment = currentPayment.Payment - currentPayment.RefundableEscrow - loan.Escrow
+					currentPayment.Payment = currentPayment.Payment - currentPayment.RefundableEscrow - escrow
 				}
 			} else {
-				currentPayment.RemainingPrincipal = schedule[previousMonth].RemainingPrincipal - currentPayment.Principal
+				currentPayment.RemainingPrincipal = balance - currentPayment.Principal
 			}
 			if loan.MortgageInsuranceCutoff > 0 {
 				if currentPayment.RemainingPrincipal/loan.Principal <= loan.MortgageInsuranceCutoff/100.0 {
@@ -258,15 +415,15 @@ func (g *AmortizationScheduleGenerator) GenerateSchedule(loan *LoanConfig, death
 			schedule[currentMonth] = currentPayment
 			// Since the loan matured we will extrapolate the escrow to be paid on
 			// Decembers.
-			if month == loan.Term || mathutil.Round(schedule[previousMonth].RemainingPrincipal-currentPayment.Principal) == 0 {
+			if month == lastMonth || mathutil.Round(balance-currentPayment.Principal) == 0 {
 				for currentMonth != deathDate {
 					december, err := datetime.CheckMonth(currentMonth, "12")
 					if err != nil {
 						return nil, err
 					}
-					if december && loan.Escrow > 0 && month != loan.Term {
+					if december && loan.Escrow > 0 && month != lastMonth {
 						var escrowPayment Payment
-						escrowPayment.Payment = loan.Escrow * 12
+						escrowPayment.Payment = loan.EscrowForDate(currentMonth) * 12
 						schedule[currentMonth] = escrowPayment
 					}
 					currentMonth, err = datetime.OffsetDate(currentMonth, datetime.DateTimeLayout, 1)
@@ -342,7 +499,7 @@ func (g *AmortizationScheduleGenerator) CheckEarlyPayoffThresholdAndUpdate(
 				}
 				if december && loan.Escrow > 0 {
 					var escrowPayment Payment
-					escrowPayment.Payment = loan.Escrow * 12
+					escrowPayment.Payment = loan.EscrowForDate(currentMonth) * 12
 					schedule[currentMonth] = escrowPayment
 				} else {
 					delete(schedule, currentMonth)
@@ -358,9 +515,9 @@ func CalculateExtraPrincipal(extraPrincipalPayments []Event, date string) float6
 	amount := 0.00
 
 	for _, event := range extraPrincipalPayments {
-		for _, eventDate := range event.DateList {
+		for i, eventDate := range event.DateList {
 			if eventDate == date {
-				amount += event.Amount
+				amount += event.AmountAt(i)
 			}
 		}
 	}
@@ -385,14 +542,16 @@ func CalculateExtraPrincipalWithOverpaymentPrevention(
 ) (float64, error) {
 	totalExtra := CalculateExtraPrincipal(events, date)
 
-	// Prevent overpayment by capping extra payment to current balance
-	if totalExtra > currentBalance {
+	// Prevent overpayment by capping extra payment to the balance left after
+	// the regular payment's principal.
+	remaining := math.Max(currentBalance-(monthlyPayment-CalculateInterestPayment(currentBalance, interestRate)), 0)
+	if totalExtra > remaining {
 		logger.Debug("Capping extra principal payment to prevent overpayment",
 			zap.String("date", date),
 			zap.String("loan", loanName),
 			zap.Float64("requested", totalExtra),
-			zap.Float64("capped_to_balance", currentBalance))
-		return currentBalance, nil
+			zap.Float64("capped_to_balance", remaining))
+		return remaining, nil
 	}
 
 	return totalExtra, nil
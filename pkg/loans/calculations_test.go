@@ -70,6 +70,35 @@ func TestCalculateMonthlyPayment(t *testing.T) {
 	}
 }
 
+func TestTermForPayment(t *testing.T) {
+	tests := []struct {
+		name      string
+		balance   float64
+		rate      float64
+		payment   float64
+		wantTerm  int
+		wantError bool
+	}{
+		{name: "zero interest, even", balance: 1200, payment: 100, wantTerm: 12},
+		{name: "zero interest, partial final payment", balance: 1000, payment: 300, wantTerm: 4},
+		{name: "matches the amortization payment", balance: 10000, rate: 6, payment: CalculateMonthlyPayment(10000, 0, 6, 36), wantTerm: 36},
+		{name: "rounds up to a partial payment", balance: 10000, rate: 6, payment: 500, wantTerm: 22},
+		{name: "payment equal to interest", balance: 10000, rate: 6, payment: 50, wantError: true},
+		{name: "payment below interest", balance: 10000, rate: 6, payment: 40, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term, err := TermForPayment(tt.balance, tt.rate, tt.payment)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("TermForPayment() error = %v, wantError %v", err, tt.wantError)
+			}
+			if term != tt.wantTerm {
+				t.Errorf("TermForPayment() = %d, want %d", term, tt.wantTerm)
+			}
+		})
+	}
+}
+
 func TestCalculateInterestPayment(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -314,6 +343,52 @@ func TestAmortizationScheduleGenerator_GenerateSchedule(t *testing.T) {
 	}
 }
 
+func TestAmortizationScheduleGenerator_EscrowGrowth(t *testing.T) {
+	generator := NewAmortizationScheduleGenerator(zap.NewNop())
+
+	loan := &LoanConfig{
+		Name:             "Escrow Loan",
+		StartDate:        "2025-01",
+		Principal:        100000,
+		InterestRate:     6.0,
+		Term:             36,
+		Escrow:           500,
+		EscrowGrowthRate: 10,
+		EarlyPayoffDate:  "2027-06",
+	}
+
+	schedule, err := generator.GenerateSchedule(loan, "2040-01")
+	if err != nil {
+		t.Fatalf("GenerateSchedule() error = %v", err)
+	}
+
+	monthlyPayment := CalculateMonthlyPayment(loan.Principal, loan.DownPayment, loan.InterestRate, loan.Term)
+	tests := []struct {
+		date   string
+		escrow float64
+	}{
+		{"2025-06", 500},
+		{"2025-12", 500},
+		{"2026-01", 550},
+		{"2027-03", 605},
+	}
+	for _, tt := range tests {
+		payment := schedule[tt.date]
+		if got := payment.Payment - monthlyPayment; math.Abs(got-tt.escrow) > 1e-6 {
+			t.Errorf("escrow portion for %s = %.2f, want %.2f", tt.date, got, tt.escrow)
+		}
+	}
+
+	if refundable := schedule["2026-02"].RefundableEscrow; math.Abs(refundable-550) > 1e-6 {
+		t.Errorf("refundable escrow for 2026-02 = %.2f, want 550.00", refundable)
+	}
+
+	// After an early payoff the grown escrow is paid annually in December.
+	if annual := schedule["2029-12"].Payment; math.Abs(annual-500*math.Pow(1.1, 4)*12) > 1e-6 {
+		t.Errorf("annual escrow for 2029-12 = %.2f, want %.2f", annual, 500*math.Pow(1.1, 4)*12)
+	}
+}
+
 func TestAmortizationScheduleGenerator_WithEarlyPayoff(t *testing.T) {
 	logger := zap.NewNop()
 	generator := NewAmortizationScheduleGenerator(logger)
@@ -504,3 +579,31 @@ func TestPaymentStruct(t *testing.T) {
 			calculatedPayment, payment.Payment)
 	}
 }
+
+func TestAmortizationScheduleGenerator_PaymentDefined(t *testing.T) {
+	generator := NewAmortizationScheduleGenerator(zap.NewNop())
+	loan := &LoanConfig{Name: "Family loan", StartDate: "2025-01", Principal: 10000, InterestRate: 6, Payment: 500}
+	schedule, err := generator.GenerateSchedule(loan, "2030-01")
+	if err != nil {
+		t.Fatalf("GenerateSchedule() error = %v", err)
+	}
+	if len(schedule) != 22 {
+		t.Fatalf("expected 22 payments, got %d", len(schedule))
+	}
+	if got := schedule["2025-06"].Payment; got != 500 {
+		t.Errorf("regular payment = %.2f, want 500.00", got)
+	}
+	balance := schedule["2026-09"].RemainingPrincipal
+	final := schedule["2026-10"]
+	if want := balance + CalculateInterestPayment(balance, 6); math.Abs(final.Payment-want) > 0.01 || final.Payment >= 500 {
+		t.Errorf("final payment = %.2f, want a partial payment of %.2f", final.Payment, want)
+	}
+	if final.RemainingPrincipal != 0 {
+		t.Errorf("remaining principal after the final payment = %.2f, want 0", final.RemainingPrincipal)
+	}
+
+	short := &LoanConfig{Name: "Underpaid", StartDate: "2025-01", Principal: 10000, InterestRate: 6, Payment: 50}
+	if _, err := generator.GenerateSchedule(short, "2030-01"); err == nil {
+		t.Error("expected an error when the payment does not cover the interest")
+	}
+}
@@ -0,0 +1,269 @@
+package loans
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+	"github.com/iwvelando/finance-forecast/pkg/mathutil"
+)
+
+// Debt payoff strategies.
+const (
+	// StrategyAvalanche pays the highest interest rate first.
+	StrategyAvalanche = "avalanche"
+	// StrategySnowball pays the smallest balance first.
+	StrategySnowball = "snowball"
+	// StrategyCustom pays loans in a given order.
+	StrategyCustom = "custom"
+)
+
+// plannedPaymentName names the extra principal payments a PayoffPlan adds.
+const plannedPaymentName = "debt payoff plan"
+
+// PayoffPlan spends Budget each month from StartDate on extra principal. The
+// budget goes to the first unpaid loan in strategy order and anything it
+// cannot absorb spills to the next. Once a loan is paid off, the regular
+// payments it no longer needs join the budget, so the total spent on the
+// loans stays level until they are all paid off.
+type PayoffPlan struct {
+	Strategy  string
+	Budget    float64
+	StartDate string
+	Order     []string // loan names for StrategyCustom; unlisted loans follow
+}
+
+// PayoffOutcome compares one loan with and without a payoff plan.
+type PayoffOutcome struct {
+	Name             string
+	BaselinePayoff   string // empty when not paid off within the schedule
+	Payoff           string
+	BaselineInterest float64
+	Interest         float64
+}
+
+// PayoffReport is the result of a payoff plan using one strategy.
+type PayoffReport struct {
+	Strategy string
+	Loans    []PayoffOutcome // in the order the strategy pays them
+}
+
+// Interest returns the interest paid across the loans with the plan.
+func (r PayoffReport) Interest() float64 {
+	total := 0.0
+	for _, loan := range r.Loans {
+		total += loan.Interest
+	}
+	return total
+}
+
+// InterestSaved returns the interest the plan saves over minimum payments.
+func (r PayoffReport) InterestSaved() float64 {
+	saved := 0.0
+	for _, loan := range r.Loans {
+		saved += loan.BaselineInterest - loan.Interest
+	}
+	return saved
+}
+
+// DebtFree returns the month the last loan is paid off, or false when a loan
+// is not paid off within the schedule.
+func (r PayoffReport) DebtFree() (string, bool) {
+	last := ""
+	for _, loan := range r.Loans {
+		if loan.Payoff == "" {
+			return "", false
+		}
+		if loan.Payoff > last {
+			last = loan.Payoff
+		}
+	}
+	return last, true
+}
+
+// PlanPayoff applies plan to loans, adding its extra principal payments and
+// regenerating each loan's AmortizationSchedule and Notes. Schedules stop at
+// deathDate.
+func (g *AmortizationScheduleGenerator) PlanPayoff(plan PayoffPlan, loans []*LoanConfig, deathDate string) (PayoffReport, error) {
+	report := PayoffReport{Strategy: plan.Strategy}
+
+	baselines := make([]map[string]Payment, len(loans))
+	for i, loan := range loans {
+		schedule, err := g.GenerateSchedule(loan, deathDate)
+		if err != nil {
+			return report, err
+		}
+		baselines[i] = schedule
+	}
+	order, err := plan.order(loans, baselines)
+	if err != nil {
+		return report, err
+	}
+
+	var months []string
+	pool := make(map[string]float64)
+	for month := plan.StartDate; month < deathDate; {
+		months = append(months, month)
+		pool[month] = plan.Budget
+		month, err = datetime.OffsetDate(month, datetime.DateTimeLayout, 1)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	for _, i := range order {
+		loan := loans[i]
+		planned := Event{Name: plannedPaymentName}
+		for _, month := range months {
+			if month > loan.StartDate && pool[month] > 0 {
+				planned.DateList = append(planned.DateList, month)
+				planned.AmountList = append(planned.AmountList, pool[month])
+			}
+		}
+		configured := loan.ExtraPrincipalPayments
+		loan.ExtraPrincipalPayments = append(append([]Event(nil), configured...), planned)
+		schedule, err := g.GenerateSchedule(loan, deathDate)
+		loan.ExtraPrincipalPayments = configured
+		if err != nil {
+			return report, err
+		}
+		loan.AmortizationSchedule = schedule
+
+		// Whatever this loan's payments no longer need passes to the next.
+		for _, month := range months {
+			pool[month] += paidTowardLoan(baselines[i][month]) - paidTowardLoan(schedule[month])
+		}
+		outcome := PayoffOutcome{
+			Name:             loan.Name,
+			BaselinePayoff:   payoffMonth(baselines[i]),
+			Payoff:           payoffMonth(schedule),
+			BaselineInterest: totalInterest(baselines[i]),
+			Interest:         totalInterest(schedule),
+		}
+		if note := outcome.note(plan.Strategy); note != "" {
+			loan.addNote(outcome.Payoff, note)
+		}
+		report.Loans = append(report.Loans, outcome)
+	}
+	return report, nil
+}
+
+// note describes a payoff the plan brings forward, or returns an empty string.
+func (o PayoffOutcome) note(strategy string) string {
+	if o.Payoff == "" || o.Payoff == o.BaselinePayoff {
+		return ""
+	}
+	if o.BaselinePayoff == "" {
+		return fmt.Sprintf("paid off by the %s plan", strategy)
+	}
+	payoff, err := time.Parse(datetime.DateTimeLayout, o.Payoff)
+	if err != nil {
+		return ""
+	}
+	baseline, err := time.Parse(datetime.DateTimeLayout, o.BaselinePayoff)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("paid off by the %s plan, %d months early", strategy, datetime.MonthsBetween(payoff, baseline))
+}
+
+// order returns the indexes of loans in the order the plan pays them, using
+// the balances outstanding when the plan starts.
+func (plan PayoffPlan) order(loans []*LoanConfig, schedules []map[string]Payment) ([]int, error) {
+	order := make([]int, len(loans))
+	for i := range order {
+		order[i] = i
+	}
+	balances := make([]float64, len(loans))
+	for i, loan := range loans {
+		balances[i] = balanceBefore(loan, schedules[i], plan.StartDate)
+	}
+
+	switch plan.Strategy {
+	case StrategyAvalanche:
+		sort.SliceStable(order, func(a, b int) bool {
+			x, y := loans[order[a]], loans[order[b]]
+			if x.InterestRate != y.InterestRate {
+				return x.InterestRate > y.InterestRate
+			}
+			return balances[order[a]] < balances[order[b]]
+		})
+	case StrategySnowball:
+		sort.SliceStable(order, func(a, b int) bool {
+			if balances[order[a]] != balances[order[b]] {
+				return balances[order[a]] < balances[order[b]]
+			}
+			return loans[order[a]].InterestRate > loans[order[b]].InterestRate
+		})
+	case StrategyCustom:
+		rank := make(map[string]int, len(plan.Order))
+		for i, name := range plan.Order {
+			rank[name] = i
+		}
+		for _, name := range plan.Order {
+			found := false
+			for _, loan := range loans {
+				found = found || loan.Name == name
+			}
+			if !found {
+				return nil, fmt.Errorf("debt payoff order names unknown loan %q", name)
+			}
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			x, xListed := rank[loans[order[a]].Name]
+			y, yListed := rank[loans[order[b]].Name]
+			if xListed != yListed {
+				return xListed
+			}
+			return xListed && x < y
+		})
+	default:
+		return nil, fmt.Errorf("unknown debt payoff strategy %q", plan.Strategy)
+	}
+	return order, nil
+}
+
+// balanceBefore returns the principal owed going into month, or the amount
+// borrowed when the loan has not started by then.
+func balanceBefore(loan *LoanConfig, schedule map[string]Payment, month string) float64 {
+	balance := loan.Principal - loan.DownPayment
+	latest := ""
+	for date, payment := range schedule {
+		if date < month && date > latest {
+			latest = date
+			balance = payment.RemainingPrincipal
+		}
+	}
+	return balance
+}
+
+// paidTowardLoan returns the principal and interest in a payment, leaving out
+// escrow, insurance, and refinance costs.
+func paidTowardLoan(payment Payment) float64 {
+	return payment.Principal + payment.Interest
+}
+
+// payoffMonth returns the first month a schedule's principal reaches zero.
+func payoffMonth(schedule map[string]Payment) string {
+	dates := make([]string, 0, len(schedule))
+	for date := range schedule {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	for _, date := range dates {
+		if mathutil.Round(schedule[date].RemainingPrincipal) == 0 {
+			return date
+		}
+	}
+	return ""
+}
+
+// totalInterest sums the interest paid over a schedule.
+func totalInterest(schedule map[string]Payment) float64 {
+	total := 0.0
+	for _, payment := range schedule {
+		total += payment.Interest
+	}
+	return total
+}
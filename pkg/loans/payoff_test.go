@@ -0,0 +1,105 @@
+package loans
+
+import (
+	"math"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func payoffTestLoans() []*LoanConfig {
+	return []*LoanConfig{
+		{Name: "Car", StartDate: "2025-01", Principal: 1200, Term: 12},
+		{Name: "Card loan", StartDate: "2025-01", Principal: 2400, InterestRate: 12, Term: 24},
+	}
+}
+
+func TestPlanPayoffSnowball(t *testing.T) {
+	generator := NewAmortizationScheduleGenerator(zap.NewNop())
+	loans := payoffTestLoans()
+	baseline := make([]map[string]Payment, len(loans))
+	for i, loan := range loans {
+		schedule, err := generator.GenerateSchedule(loan, "2030-01")
+		if err != nil {
+			t.Fatalf("GenerateSchedule() error = %v", err)
+		}
+		baseline[i] = schedule
+	}
+
+	report, err := generator.PlanPayoff(PayoffPlan{Strategy: StrategySnowball, Budget: 100, StartDate: "2025-02"}, loans, "2030-01")
+	if err != nil {
+		t.Fatalf("PlanPayoff() error = %v", err)
+	}
+	if report.Loans[0].Name != "Car" {
+		t.Fatalf("snowball should pay the smaller balance first, got order %v", report.Loans)
+	}
+
+	// The car's 1,100 balance clears at 200 a month, with the regular 100
+	// payment finishing it in July.
+	car := report.Loans[0]
+	if car.BaselinePayoff != "2025-12" || car.Payoff != "2025-07" {
+		t.Errorf("car payoff = %s (baseline %s), want 2025-07 (baseline 2025-12)", car.Payoff, car.BaselinePayoff)
+	}
+	if card := report.Loans[1]; card.Payoff >= card.BaselinePayoff || card.Interest >= card.BaselineInterest {
+		t.Errorf("card loan should be paid off sooner with less interest, got %+v", card)
+	}
+	if report.InterestSaved() <= 0 {
+		t.Errorf("InterestSaved() = %.2f, want a saving", report.InterestSaved())
+	}
+	if got, ok := report.DebtFree(); !ok || got != report.Loans[1].Payoff {
+		t.Errorf("DebtFree() = %s, %v, want %s", got, ok, report.Loans[1].Payoff)
+	}
+
+	// Total spending on the loans stays at the minimums plus the budget
+	// while both are being repaid, including the freed car payment.
+	for _, month := range []string{"2025-03", "2025-07", "2025-09"} {
+		planned, minimum := 0.0, 0.0
+		for i, loan := range loans {
+			planned += paidTowardLoan(loan.AmortizationSchedule[month])
+			minimum += paidTowardLoan(baseline[i][month])
+		}
+		if math.Abs(planned-(minimum+100)) > 1e-6 {
+			t.Errorf("%s: paid %.2f toward the loans, want %.2f", month, planned, minimum+100)
+		}
+	}
+	if notes := loans[0].Notes["2025-07"]; len(notes) != 1 || notes[0] != "paid off by the snowball plan, 5 months early" {
+		t.Errorf("expected a payoff note in 2025-07, got %v", loans[0].Notes)
+	}
+	if len(loans[0].ExtraPrincipalPayments) != 0 {
+		t.Error("PlanPayoff should not keep the planned payments on the loan")
+	}
+}
+
+func TestPlanPayoffStrategies(t *testing.T) {
+	generator := NewAmortizationScheduleGenerator(zap.NewNop())
+
+	avalanche, err := generator.PlanPayoff(PayoffPlan{Strategy: StrategyAvalanche, Budget: 100, StartDate: "2025-02"}, payoffTestLoans(), "2030-01")
+	if err != nil {
+		t.Fatalf("PlanPayoff() error = %v", err)
+	}
+	if avalanche.Loans[0].Name != "Card loan" {
+		t.Errorf("avalanche should pay the higher rate first, got %s", avalanche.Loans[0].Name)
+	}
+	snowball, err := generator.PlanPayoff(PayoffPlan{Strategy: StrategySnowball, Budget: 100, StartDate: "2025-02"}, payoffTestLoans(), "2030-01")
+	if err != nil {
+		t.Fatalf("PlanPayoff() error = %v", err)
+	}
+	if avalanche.Interest() > snowball.Interest() {
+		t.Errorf("avalanche interest %.2f should not exceed snowball interest %.2f", avalanche.Interest(), snowball.Interest())
+	}
+
+	custom, err := generator.PlanPayoff(PayoffPlan{Strategy: StrategyCustom, Budget: 100, StartDate: "2025-02", Order: []string{"Card loan"}}, payoffTestLoans(), "2030-01")
+	if err != nil {
+		t.Fatalf("PlanPayoff() error = %v", err)
+	}
+	if custom.Loans[0].Name != "Card loan" || custom.Loans[1].Name != "Car" {
+		t.Errorf("custom order = %s, %s, want Card loan, Car", custom.Loans[0].Name, custom.Loans[1].Name)
+	}
+
+	if _, err := generator.PlanPayoff(PayoffPlan{Strategy: StrategyCustom, Order: []string{"Boat"}}, payoffTestLoans(), "2030-01"); err == nil {
+		t.Error("expected an error for an unknown loan in the custom order")
+	}
+	if _, err := generator.PlanPayoff(PayoffPlan{Strategy: "fastest"}, payoffTestLoans(), "2030-01"); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
@@ -0,0 +1,59 @@
+package loans
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+// Refinance replaces a loan's interest rate and term from Date. The remaining
+// principal plus CashOut is re-amortized over Term months starting with that
+// month's payment, which also carries ClosingCosts and pays out CashOut.
+type Refinance struct {
+	Date         string
+	InterestRate float64
+	Term         int
+	ClosingCosts float64
+	CashOut      float64
+}
+
+// BreakEvenMonths returns how many months of the payment reduction from
+// oldPayment to newPayment it takes to recover closingCosts. It returns false
+// when the new payment is not lower.
+func BreakEvenMonths(oldPayment, newPayment, closingCosts float64) (int, bool) {
+	savings := oldPayment - newPayment
+	if savings <= 0 {
+		return 0, false
+	}
+	return int(math.Ceil(closingCosts / savings)), true
+}
+
+// refinanceAt returns the refinance taking effect in month, if any.
+func (loan *LoanConfig) refinanceAt(month string) (Refinance, bool) {
+	for _, refinance := range loan.Refinances {
+		if refinance.Date == month {
+			return refinance, true
+		}
+	}
+	return Refinance{}, false
+}
+
+// refinanceNote describes a refinance and when its closing costs are
+// recovered by the lower payment.
+func refinanceNote(refinance Refinance, balance, oldPayment, newPayment float64) (string, error) {
+	note := fmt.Sprintf("refinanced %.2f at %.2f%% for %d months, payment %.2f -> %.2f, closing costs %.2f",
+		balance, refinance.InterestRate, refinance.Term, oldPayment, newPayment, refinance.ClosingCosts)
+	if refinance.CashOut > 0 {
+		note += fmt.Sprintf(", cash out %.2f", refinance.CashOut)
+	}
+	months, ok := BreakEvenMonths(oldPayment, newPayment, refinance.ClosingCosts)
+	if !ok {
+		return note + ", never breaks even", nil
+	}
+	breakEven, err := datetime.OffsetDate(refinance.Date, datetime.DateTimeLayout, months)
+	if err != nil {
+		return "", err
+	}
+	return note + fmt.Sprintf(", breaks even %s (%d months)", breakEven, months), nil
+}
@@ -0,0 +1,119 @@
+package loans
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestBreakEvenMonths(t *testing.T) {
+	tests := []struct {
+		name         string
+		oldPayment   float64
+		newPayment   float64
+		closingCosts float64
+		wantMonths   int
+		wantOK       bool
+	}{
+		{"exact", 1000, 800, 2000, 10, true},
+		{"rounds up", 1000, 700, 1000, 4, true},
+		{"no closing costs", 1000, 900, 0, 0, true},
+		{"same payment", 1000, 1000, 500, 0, false},
+		{"higher payment", 900, 1000, 500, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			months, ok := BreakEvenMonths(tt.oldPayment, tt.newPayment, tt.closingCosts)
+			if months != tt.wantMonths || ok != tt.wantOK {
+				t.Errorf("BreakEvenMonths() = %d, %v, want %d, %v", months, ok, tt.wantMonths, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAmortizationScheduleGenerator_Refinance(t *testing.T) {
+	generator := NewAmortizationScheduleGenerator(zap.NewNop())
+
+	t.Run("lower rate and longer term", func(t *testing.T) {
+		loan := &LoanConfig{
+			Name:         "Refinanced",
+			StartDate:    "2025-01",
+			Principal:    12000,
+			InterestRate: 0,
+			Term:         12,
+			Refinances: []Refinance{
+				{Date: "2025-03", InterestRate: 0, Term: 20, ClosingCosts: 500},
+			},
+		}
+		schedule, err := generator.GenerateSchedule(loan, "2030-01")
+		if err != nil {
+			t.Fatalf("GenerateSchedule() error = %v", err)
+		}
+
+		tests := []struct {
+			date          string
+			wantPayment   float64
+			wantRemaining float64
+		}{
+			{"2025-02", 1000, 10000},
+			{"2025-03", 1000, 9500},
+			{"2025-04", 500, 9000},
+			{"2026-10", 500, 0},
+		}
+		for _, tt := range tests {
+			payment, ok := schedule[tt.date]
+			if !ok {
+				t.Fatalf("missing payment for %s", tt.date)
+			}
+			if math.Abs(payment.Payment-tt.wantPayment) > 1e-6 {
+				t.Errorf("Payment[%s] = %.2f, want %.2f", tt.date, payment.Payment, tt.wantPayment)
+			}
+			if math.Abs(payment.RemainingPrincipal-tt.wantRemaining) > 1e-6 {
+				t.Errorf("RemainingPrincipal[%s] = %.2f, want %.2f", tt.date, payment.RemainingPrincipal, tt.wantRemaining)
+			}
+		}
+		if _, ok := schedule["2026-11"]; ok {
+			t.Error("expected no payments after the refinanced term")
+		}
+
+		want := "refinanced 10000.00 at 0.00% for 20 months, payment 1000.00 -> 500.00, closing costs 500.00, breaks even 2025-04 (1 months)"
+		if notes := loan.Notes["2025-03"]; len(notes) != 1 || notes[0] != want {
+			t.Errorf("Notes[2025-03] = %q, want %q", notes, want)
+		}
+	})
+
+	t.Run("new rate and cash out", func(t *testing.T) {
+		loan := &LoanConfig{
+			Name:         "Cash out",
+			StartDate:    "2025-01",
+			Principal:    12000,
+			InterestRate: 6,
+			Term:         12,
+			Refinances: []Refinance{
+				{Date: "2025-02", InterestRate: 3, Term: 24, ClosingCosts: 300, CashOut: 2000},
+			},
+		}
+		schedule, err := generator.GenerateSchedule(loan, "2030-01")
+		if err != nil {
+			t.Fatalf("GenerateSchedule() error = %v", err)
+		}
+
+		balance := schedule["2025-01"].RemainingPrincipal + 2000
+		newPayment := CalculateMonthlyPayment(balance, 0, 3, 24)
+		payment := schedule["2025-02"]
+		if want := newPayment + 300 - 2000; math.Abs(payment.Payment-want) > 1e-6 {
+			t.Errorf("refinance month payment = %.2f, want %.2f", payment.Payment, want)
+		}
+		if want := balance * 0.0025; math.Abs(payment.Interest-want) > 1e-6 {
+			t.Errorf("refinance month interest = %.2f, want %.2f", payment.Interest, want)
+		}
+		if _, ok := schedule["2027-01"]; !ok {
+			t.Error("expected payments through the end of the refinanced term")
+		}
+		if notes := strings.Join(loan.Notes["2025-02"], "; "); !strings.Contains(notes, "cash out 2000.00, breaks even 2025-03 (1 months)") {
+			t.Errorf("expected cash out and break-even in notes, got %q", notes)
+		}
+	})
+}
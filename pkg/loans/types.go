@@ -0,0 +1,84 @@
+package loans
+
+import (
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+// Loan types. An empty type is a fully amortizing loan.
+const (
+	// TypeAmortizing pays the loan down evenly over its term.
+	TypeAmortizing = "amortizing"
+	// TypeInterestOnly pays only interest for InterestOnlyMonths, then
+	// amortizes the balance over the rest of the term.
+	TypeInterestOnly = "interestOnly"
+	// TypeBalloon pays as if amortizing over AmortizationTerm months, with the
+	// remaining balance due with the final payment of the shorter term.
+	TypeBalloon = "balloon"
+	// TypeSimpleInterest accrues interest daily on the balance, so each
+	// payment's interest follows the length of the month, and the final
+	// payment clears whatever balance remains.
+	TypeSimpleInterest = "simpleInterest"
+)
+
+// DaysPerYear is the day count used by simple interest loans.
+const DaysPerYear = 365
+
+// Payment frequencies. An empty frequency is monthly.
+const (
+	// PaymentMonthly pays the full payment once a month.
+	PaymentMonthly = "monthly"
+	// PaymentBiweekly pays half the monthly payment every two weeks from the
+	// first of the start month, 26 half payments a year, so two months a
+	// year carry a third half payment that goes to principal.
+	PaymentBiweekly = "biweekly"
+)
+
+// biweeklyPeriodDays is the number of days between biweekly payments.
+const biweeklyPeriodDays = 14
+
+// interestFor returns the interest accrued on balance over month.
+func interestFor(loanType string, balance, annualInterestRate float64, month string) float64 {
+	if loanType != TypeSimpleInterest {
+		return CalculateInterestPayment(balance, annualInterestRate)
+	}
+	date, err := time.Parse(datetime.DateTimeLayout, month)
+	if err != nil {
+		return CalculateInterestPayment(balance, annualInterestRate)
+	}
+	days := date.AddDate(0, 1, -1).Day()
+	return balance * annualInterestRate / constants.PercentageMultiplier * float64(days) / DaysPerYear
+}
+
+// regularPayment returns the principal and interest payment due from payment
+// number month onward on balance, where lastMonth is the final payment of the
+// term.
+func (loan *LoanConfig) regularPayment(loanType string, balance, annualInterestRate float64, month, lastMonth int) float64 {
+	switch {
+	case loanType == TypeInterestOnly && month <= loan.InterestOnlyMonths:
+		return CalculateInterestPayment(balance, annualInterestRate)
+	case loanType == TypeBalloon:
+		return CalculateMonthlyPayment(balance, 0, annualInterestRate, loan.AmortizationTerm-month+1)
+	default:
+		return CalculateMonthlyPayment(balance, 0, annualInterestRate, lastMonth-month+1)
+	}
+}
+
+// scheduledPayment returns the principal and interest paid in month for a
+// regular monthly payment of monthlyPayment.
+func (loan *LoanConfig) scheduledPayment(monthlyPayment float64, month string) float64 {
+	if loan.PaymentFrequency != PaymentBiweekly {
+		return monthlyPayment
+	}
+	start, err := time.Parse(datetime.DateTimeLayout, loan.StartDate)
+	if err != nil {
+		return monthlyPayment
+	}
+	date, err := time.Parse(datetime.DateTimeLayout, month)
+	if err != nil {
+		return monthlyPayment
+	}
+	return monthlyPayment / 2 * float64(datetime.OccurrencesInMonth(start, biweeklyPeriodDays, date))
+}
@@ -0,0 +1,141 @@
+package loans
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func totalPrincipal(schedule map[string]Payment) float64 {
+	total := 0.0
+	for _, payment := range schedule {
+		total += payment.Principal
+	}
+	return total
+}
+
+func TestAmortizationScheduleGenerator_LoanTypes(t *testing.T) {
+	generator := NewAmortizationScheduleGenerator(zap.NewNop())
+
+	t.Run("interest only", func(t *testing.T) {
+		loan := &LoanConfig{Name: "Interest only", Type: TypeInterestOnly, StartDate: "2025-01", Principal: 12000, InterestRate: 12, Term: 24, InterestOnlyMonths: 12}
+		schedule, err := generator.GenerateSchedule(loan, "2030-01")
+		if err != nil {
+			t.Fatalf("GenerateSchedule() error = %v", err)
+		}
+		for _, month := range []string{"2025-01", "2025-12"} {
+			if got := schedule[month]; math.Abs(got.Payment-120) > 0.01 || got.RemainingPrincipal != 12000 {
+				t.Errorf("%s: payment %.2f with %.2f remaining, want 120.00 with 12000.00", month, got.Payment, got.RemainingPrincipal)
+			}
+		}
+		want := CalculateMonthlyPayment(12000, 0, 12, 12)
+		if got := schedule["2026-01"].Payment; math.Abs(got-want) > 0.01 {
+			t.Errorf("first amortizing payment = %.2f, want %.2f", got, want)
+		}
+		if len(loan.Notes["2026-01"]) != 1 || !strings.Contains(loan.Notes["2026-01"][0], "interest-only period ends") {
+			t.Errorf("expected a note when amortization begins, got %v", loan.Notes)
+		}
+		if got := schedule["2026-12"].RemainingPrincipal; math.Abs(got) > 0.01 {
+			t.Errorf("remaining principal at maturity = %.2f, want 0", got)
+		}
+	})
+
+	t.Run("interest only early payoff", func(t *testing.T) {
+		loan := &LoanConfig{Name: "Interest only", Type: TypeInterestOnly, StartDate: "2025-01", Principal: 12000, InterestRate: 12, Term: 24, InterestOnlyMonths: 12, EarlyPayoffDate: "2025-06"}
+		schedule, err := generator.GenerateSchedule(loan, "2030-01")
+		if err != nil {
+			t.Fatalf("GenerateSchedule() error = %v", err)
+		}
+		// The full balance is still owed when paying off during the
+		// interest-only period.
+		if got := schedule["2025-06"].Payment; math.Abs(got-12000) > 0.01 {
+			t.Errorf("payoff payment = %.2f, want 12000.00", got)
+		}
+	})
+
+	t.Run("balloon", func(t *testing.T) {
+		loan := &LoanConfig{Name: "Balloon", Type: TypeBalloon, StartDate: "2025-01", Principal: 12000, InterestRate: 6, Term: 12, AmortizationTerm: 60}
+		schedule, err := generator.GenerateSchedule(loan, "2030-01")
+		if err != nil {
+			t.Fatalf("GenerateSchedule() error = %v", err)
+		}
+		regular := CalculateMonthlyPayment(12000, 0, 6, 60)
+		if got := schedule["2025-06"].Payment; math.Abs(got-regular) > 0.01 {
+			t.Errorf("regular payment = %.2f, want %.2f", got, regular)
+		}
+		balance := schedule["2025-11"].RemainingPrincipal
+		final := schedule["2025-12"]
+		if want := balance + CalculateInterestPayment(balance, 6); math.Abs(final.Payment-want) > 0.01 || final.RemainingPrincipal != 0 {
+			t.Errorf("final payment %.2f with %.2f remaining, want %.2f with 0", final.Payment, final.RemainingPrincipal, want)
+		}
+		if len(schedule) != 12 || math.Abs(totalPrincipal(schedule)-12000) > 0.01 {
+			t.Errorf("expected 12 payments repaying 12000, got %d repaying %.2f", len(schedule), totalPrincipal(schedule))
+		}
+		if len(loan.Notes["2025-12"]) != 1 || !strings.Contains(loan.Notes["2025-12"][0], "balloon payment") {
+			t.Errorf("expected a balloon payment note, got %v", loan.Notes)
+		}
+	})
+
+	t.Run("simple interest", func(t *testing.T) {
+		loan := &LoanConfig{Name: "Auto", Type: TypeSimpleInterest, StartDate: "2025-01", Principal: 12000, InterestRate: 7.3, Term: 12}
+		schedule, err := generator.GenerateSchedule(loan, "2030-01")
+		if err != nil {
+			t.Fatalf("GenerateSchedule() error = %v", err)
+		}
+		// 7.3% a year accrues 0.02% a day.
+		balance := schedule["2025-01"].RemainingPrincipal
+		if want := balance * 0.0002 * 28; math.Abs(schedule["2025-02"].Interest-want) > 0.01 {
+			t.Errorf("February interest = %.2f, want %.2f", schedule["2025-02"].Interest, want)
+		}
+		balance = schedule["2025-02"].RemainingPrincipal
+		if want := balance * 0.0002 * 31; math.Abs(schedule["2025-03"].Interest-want) > 0.01 {
+			t.Errorf("March interest = %.2f, want %.2f", schedule["2025-03"].Interest, want)
+		}
+		if got := schedule["2025-12"].RemainingPrincipal; got != 0 {
+			t.Errorf("remaining principal at maturity = %.2f, want 0", got)
+		}
+		if math.Abs(totalPrincipal(schedule)-12000) > 0.01 {
+			t.Errorf("principal repaid = %.2f, want 12000.00", totalPrincipal(schedule))
+		}
+	})
+}
+
+func TestAmortizationScheduleGenerator_Biweekly(t *testing.T) {
+	generator := NewAmortizationScheduleGenerator(zap.NewNop())
+	monthly := &LoanConfig{Name: "Mortgage", StartDate: "2025-01", Principal: 100000, InterestRate: 6, Term: 120}
+	biweekly := &LoanConfig{Name: "Mortgage", StartDate: "2025-01", Principal: 100000, InterestRate: 6, Term: 120, PaymentFrequency: PaymentBiweekly}
+	baseline, err := generator.GenerateSchedule(monthly, "2040-01")
+	if err != nil {
+		t.Fatalf("GenerateSchedule() error = %v", err)
+	}
+	schedule, err := generator.GenerateSchedule(biweekly, "2040-01")
+	if err != nil {
+		t.Fatalf("GenerateSchedule() error = %v", err)
+	}
+
+	// Half payments fall every 14 days from 2025-01-01: three in January,
+	// two in February.
+	payment := CalculateMonthlyPayment(100000, 0, 6, 120)
+	if got := schedule["2025-01"].Payment; math.Abs(got-1.5*payment) > 0.01 {
+		t.Errorf("January payment = %.2f, want %.2f", got, 1.5*payment)
+	}
+	if got := schedule["2025-02"].Payment; math.Abs(got-payment) > 0.01 {
+		t.Errorf("February payment = %.2f, want %.2f", got, payment)
+	}
+
+	payoff, baselinePayoff := payoffMonth(schedule), payoffMonth(baseline)
+	if payoff == "" || payoff >= baselinePayoff {
+		t.Fatalf("biweekly payoff %s should come before the monthly payoff %s", payoff, baselinePayoff)
+	}
+	if totalInterest(schedule) >= totalInterest(baseline) {
+		t.Errorf("biweekly interest %.2f should be less than monthly interest %.2f", totalInterest(schedule), totalInterest(baseline))
+	}
+	if math.Abs(totalPrincipal(schedule)-100000) > 0.01 {
+		t.Errorf("principal repaid = %.2f, want 100000.00", totalPrincipal(schedule))
+	}
+	if notes := biweekly.Notes[payoff]; len(notes) != 1 || !strings.Contains(notes[0], "paid off with biweekly payments") {
+		t.Errorf("expected a biweekly payoff note in %s, got %v", payoff, biweekly.Notes)
+	}
+}
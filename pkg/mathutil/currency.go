@@ -57,6 +57,33 @@ func CalculatePercentage(value, total float64) float64 {
 	return (value / total) * 100
 }
 
+// CompoundGrowth grows value by annualPercent compounded once per whole year.
+func CompoundGrowth(value, annualPercent float64, years int) float64 {
+	if annualPercent == 0 || years <= 0 {
+		return value
+	}
+	return value * math.Pow(1+annualPercent/constants.PercentageMultiplier, float64(years))
+}
+
+// Appreciate grows value by annualPercent per year over the given number of
+// months; a negative rate depreciates it.
+func Appreciate(value, annualPercent float64, months int) float64 {
+	if annualPercent == 0 || months <= 0 {
+		return value
+	}
+	return value * math.Pow(1+annualPercent/constants.PercentageMultiplier, float64(months)/constants.MonthsPerYear)
+}
+
+// Deflate expresses a nominal value in starting-date dollars, discounting it by
+// annualPercent once for each whole year in the given number of months, the
+// same steps CompoundGrowth grows inflation-indexed amounts by.
+func Deflate(value, annualPercent float64, months int) float64 {
+	if annualPercent == 0 || months <= 0 {
+		return value
+	}
+	return value / math.Pow(1+annualPercent/constants.PercentageMultiplier, float64(months/constants.MonthsPerYear))
+}
+
 // ApplyPercentage applies a percentage to a value
 func ApplyPercentage(value, percentage float64) float64 {
 	return value * (percentage / constants.PercentageMultiplier)
@@ -252,6 +252,92 @@ func TestApplyPercentage(t *testing.T) {
 }
 
 // Test edge cases and boundary conditions
+func TestCompoundGrowth(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		percent  float64
+		years    int
+		expected float64
+	}{
+		{"no growth", 100, 0, 5, 100},
+		{"first year", 100, 3, 0, 100},
+		{"one year", 100, 3, 1, 103},
+		{"two years", 100, 3, 2, 106.09},
+		{"negative amount", -200, 10, 1, -220},
+		{"negative years", 100, 3, -1, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CompoundGrowth(tt.value, tt.percent, tt.years)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("CompoundGrowth(%v, %v, %d) = %v, expected %v", tt.value, tt.percent, tt.years, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDeflate(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		percent  float64
+		months   int
+		expected float64
+	}{
+		{"no inflation", 100, 0, 24, 100},
+		{"start month", 100, 3, 0, 100},
+		{"one year", 103, 3, 12, 100},
+		{"two years", 106.09, 3, 24, 100},
+		{"half year", 100, 4, 6, 100},
+		{"eleven months", 100, 4, 11, 100},
+		{"thirteen months", 104, 4, 13, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Deflate(tt.value, tt.percent, tt.months)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("Deflate(%v, %v, %d) = %v, expected %v", tt.value, tt.percent, tt.months, result, tt.expected)
+			}
+		})
+	}
+
+	// An amount indexed to inflation stays flat in starting-date dollars.
+	for months := 0; months <= 36; months++ {
+		indexed := CompoundGrowth(100, 3, months/12)
+		if result := Deflate(indexed, 3, months); math.Abs(result-100) > 1e-9 {
+			t.Errorf("Deflate of indexed amount at month %d = %v, expected 100", months, result)
+		}
+	}
+}
+
+func TestAppreciate(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		percent  float64
+		months   int
+		expected float64
+	}{
+		{"no growth", 100, 0, 24, 100},
+		{"start month", 100, 3, 0, 100},
+		{"one year", 100, 3, 12, 103},
+		{"two years depreciation", 100, -10, 24, 81},
+		{"half year", 100, 4, 6, 100 * math.Sqrt(1.04)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Appreciate(tt.value, tt.percent, tt.months)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("Appreciate(%v, %v, %d) = %v, expected %v", tt.value, tt.percent, tt.months, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestRoundingEdgeCases(t *testing.T) {
 	// Test very large numbers
 	largeNum := 999999999.999
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iwvelando/finance-forecast/internal/simulation"
+	formatutil "github.com/iwvelando/finance-forecast/pkg/format"
+)
+
+// PrettyBacktest formats historical backtest results in a human-readable format.
+func PrettyBacktest(result *simulation.BacktestResult) {
+	if result == nil || len(result.Scenarios) == 0 {
+		fmt.Println("No backtest results to display.")
+		return
+	}
+
+	dates := backtestDates(result)
+	firstYear, lastYear := result.StartYears[0], result.StartYears[len(result.StartYears)-1]
+
+	for _, scenario := range result.Scenarios {
+		fmt.Printf("--- Backtest results for scenario %s (%d windows starting %d-%d) ---\n",
+			scenario.Name, scenario.Windows, firstYear, lastYear)
+		fmt.Printf("Success rate: %.1f%% (%d of %d windows kept total net worth non-negative)\n",
+			scenario.SuccessRate*100, scenario.Successes, scenario.Windows)
+		fmt.Printf("Worst path: starting %d, ending total %s\n",
+			scenario.Worst.StartYear, formatutil.Currency(scenario.Worst.EndingTotal))
+		fmt.Printf("Median path: starting %d, ending total %s\n",
+			scenario.Median.StartYear, formatutil.Currency(scenario.Median.EndingTotal))
+		fmt.Printf("Date    | Worst liquid | Worst total | Median liquid | Median total\n")
+		fmt.Printf("____    | ____________ | ___________ | _____________ | ____________\n")
+
+		for _, date := range dates {
+			if _, ok := scenario.Worst.Total[date]; !ok {
+				continue
+			}
+			fmt.Printf("%s | %s | %s | %s | %s\n",
+				date,
+				formatutil.Currency(scenario.Worst.Liquid[date]),
+				formatutil.Currency(scenario.Worst.Total[date]),
+				formatutil.Currency(scenario.Median.Liquid[date]),
+				formatutil.Currency(scenario.Median.Total[date]),
+			)
+		}
+		fmt.Println()
+	}
+}
+
+// BacktestCsvFormat outputs historical backtest paths in comma-separated value format.
+func BacktestCsvFormat(result *simulation.BacktestResult) {
+	for _, line := range buildBacktestCsvLines(result) {
+		fmt.Println(line)
+	}
+}
+
+// BacktestCsvString converts historical backtest paths into a CSV string.
+func BacktestCsvString(result *simulation.BacktestResult) string {
+	lines := buildBacktestCsvLines(result)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func buildBacktestCsvLines(result *simulation.BacktestResult) []string {
+	header := []string{"\"date\""}
+	if result == nil || len(result.Scenarios) == 0 {
+		return []string{strings.Join(header, ",")}
+	}
+
+	for _, scenario := range result.Scenarios {
+		header = append(header,
+			fmt.Sprintf("\"worst liquid (%s, %d)\"", scenario.Name, scenario.Worst.StartYear),
+			fmt.Sprintf("\"worst total (%s, %d)\"", scenario.Name, scenario.Worst.StartYear),
+			fmt.Sprintf("\"median liquid (%s, %d)\"", scenario.Name, scenario.Median.StartYear),
+			fmt.Sprintf("\"median total (%s, %d)\"", scenario.Name, scenario.Median.StartYear),
+		)
+	}
+	lines := []string{strings.Join(header, ",")}
+
+	for _, date := range backtestDates(result) {
+		row := []string{fmt.Sprintf("\"%s\"", date)}
+		for _, scenario := range result.Scenarios {
+			row = append(row,
+				pathCell(scenario.Worst.Liquid, date),
+				pathCell(scenario.Worst.Total, date),
+				pathCell(scenario.Median.Liquid, date),
+				pathCell(scenario.Median.Total, date),
+			)
+		}
+		lines = append(lines, strings.Join(row, ","))
+	}
+
+	return lines
+}
+
+func pathCell(values map[string]float64, date string) string {
+	value, ok := values[date]
+	if !ok {
+		return "\"\""
+	}
+	return fmt.Sprintf("\"%.2f\"", value)
+}
+
+func backtestDates(result *simulation.BacktestResult) []string {
+	dateSet := make(map[string]struct{})
+	for _, scenario := range result.Scenarios {
+		for date := range scenario.Worst.Total {
+			dateSet[date] = struct{}{}
+		}
+		for date := range scenario.Median.Total {
+			dateSet[date] = struct{}{}
+		}
+	}
+	dates := make([]string, 0, len(dateSet))
+	for date := range dateSet {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates
+}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/iwvelando/finance-forecast/internal/simulation"
+)
+
+func sampleBacktestResult() *simulation.BacktestResult {
+	return &simulation.BacktestResult{
+		StartYears: []int{1928, 1929, 1930},
+		Scenarios: []simulation.BacktestScenario{
+			{
+				Name:        "Base",
+				Windows:     3,
+				Successes:   2,
+				SuccessRate: 2.0 / 3.0,
+				Worst: simulation.BacktestPath{
+					StartYear:   1929,
+					EndingTotal: -500,
+					Liquid:      map[string]float64{"2025-01": 100, "2025-02": -50},
+					Total:       map[string]float64{"2025-01": 1000, "2025-02": -500},
+				},
+				Median: simulation.BacktestPath{
+					StartYear:   1930,
+					EndingTotal: 1200,
+					Liquid:      map[string]float64{"2025-01": 100, "2025-02": 150},
+					Total:       map[string]float64{"2025-01": 1000, "2025-02": 1200},
+				},
+			},
+		},
+	}
+}
+
+func TestPrettyBacktest(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettyBacktest(sampleBacktestResult())
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	expected := []string{
+		"--- Backtest results for scenario Base (3 windows starting 1928-1930) ---",
+		"Success rate: 66.7% (2 of 3 windows kept total net worth non-negative)",
+		"Worst path: starting 1929, ending total -$500.00",
+		"Median path: starting 1930, ending total $1,200.00",
+		"2025-02 | -$50.00 | -$500.00 | $150.00 | $1,200.00",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("PrettyBacktest output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestBacktestCsvString(t *testing.T) {
+	csv := BacktestCsvString(sampleBacktestResult())
+	lines := strings.Split(strings.TrimSpace(csv), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and two rows, got %d lines:\n%s", len(lines), csv)
+	}
+
+	expectedHeader := `"date","worst liquid (Base, 1929)","worst total (Base, 1929)","median liquid (Base, 1930)","median total (Base, 1930)"`
+	if lines[0] != expectedHeader {
+		t.Errorf("header = %s, want %s", lines[0], expectedHeader)
+	}
+	expectedRow := `"2025-02","-50.00","-500.00","150.00","1200.00"`
+	if lines[2] != expectedRow {
+		t.Errorf("row = %s, want %s", lines[2], expectedRow)
+	}
+
+	if got := BacktestCsvString(nil); got != "\"date\"\n" {
+		t.Errorf("unexpected CSV for nil result: %q", got)
+	}
+}
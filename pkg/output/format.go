@@ -9,6 +9,7 @@ import (
 
 	"github.com/iwvelando/finance-forecast/internal/forecast"
 	formatutil "github.com/iwvelando/finance-forecast/pkg/format"
+	"github.com/iwvelando/finance-forecast/pkg/loans"
 	"github.com/iwvelando/finance-forecast/pkg/optimization"
 )
 
@@ -80,21 +81,56 @@ func PrettyFormat(results []forecast.Forecast) {
 		fmt.Printf("--- Results for scenario %s ---\n", scenario.Name)
 		printEmergencyFundSummary(scenario.Metrics.EmergencyFund)
 		printOptimizationSummary(scenario.Metrics.Optimizations)
-		fmt.Printf("Date    | Liquid Net Worth | Total Net Worth | Notes\n")
-		fmt.Printf("____    | ________________ | _______________ | _____\n")
+		printTaxSummary(scenario.Metrics.Taxes)
+		printDebtPayoffSummary(scenario.Metrics.DebtPayoff)
+		showReal := scenario.RealData != nil
+		showNetWorth := scenario.NetWorth != nil
+		columns := []string{"Date   "}
+		for _, member := range scenario.Ages {
+			columns = append(columns, fmt.Sprintf("%s age", member.Name))
+		}
+		columns = append(columns, "Liquid Net Worth", "Total Net Worth")
+		if showNetWorth {
+			columns = append(columns, "Liabilities", "Net Worth")
+		}
+		if showReal {
+			columns = append(columns, "Real Liquid", "Real Total")
+			if showNetWorth {
+				columns = append(columns, "Real Net Worth")
+			}
+		}
+		for _, account := range scenario.Accounts {
+			columns = append(columns, account.Name)
+		}
+		columns = append(columns, "Notes")
+		underlines := make([]string, len(columns))
+		for i, column := range columns {
+			underlines[i] = strings.Repeat("_", len(strings.TrimRight(column, " "))) + strings.Repeat(" ", len(column)-len(strings.TrimRight(column, " ")))
+		}
+		fmt.Println(strings.Join(columns, " | "))
+		fmt.Println(strings.Join(underlines, " | "))
 
 		for _, date := range dates {
-			liquidDisplay := "—"
-			if liquid, ok := scenario.Liquid[date]; ok {
-				liquidDisplay = formatutil.Currency(liquid)
-			}
+			liquidDisplay := currencyOrDash(scenario.Liquid, date)
+			totalDisplay := currencyOrDash(scenario.Data, date)
 
-			totalDisplay := "—"
-			if total, ok := scenario.Data[date]; ok {
-				totalDisplay = formatutil.Currency(total)
+			fmt.Printf("%s | ", date)
+			for _, member := range scenario.Ages {
+				fmt.Printf("%s | ", ageOrDash(member.Ages, date))
+			}
+			fmt.Printf("%s | %s | ", liquidDisplay, totalDisplay)
+			if showNetWorth {
+				fmt.Printf("%s | %s | ", currencyOrDash(scenario.Liabilities, date), currencyOrDash(scenario.NetWorth, date))
+			}
+			if showReal {
+				fmt.Printf("%s | %s | ", currencyOrDash(scenario.RealLiquid, date), currencyOrDash(scenario.RealData, date))
+				if showNetWorth {
+					fmt.Printf("%s | ", currencyOrDash(scenario.RealNetWorth, date))
+				}
+			}
+			for _, account := range scenario.Accounts {
+				fmt.Printf("%s | ", currencyOrDash(account.Balances, date))
 			}
-
-			fmt.Printf("%s | %s | %s | ", date, liquidDisplay, totalDisplay)
 			if notes, hasNotes := scenario.Notes[date]; hasNotes && len(notes) > 0 {
 				fmt.Printf("%s", strings.Join(notes, ", "))
 			}
@@ -104,6 +140,22 @@ func PrettyFormat(results []forecast.Forecast) {
 	}
 }
 
+// currencyOrDash formats the value for date or returns a dash when it is missing.
+func currencyOrDash(values map[string]float64, date string) string {
+	if value, ok := values[date]; ok {
+		return formatutil.Currency(value)
+	}
+	return "—"
+}
+
+// ageOrDash formats the age for date or returns a dash when it is missing.
+func ageOrDash(ages map[string]int, date string) string {
+	if age, ok := ages[date]; ok {
+		return fmt.Sprintf("%d", age)
+	}
+	return "—"
+}
+
 func printEmergencyFundSummary(ef *forecast.EmergencyFundRecommendation) {
 	if ef == nil {
 		return
@@ -123,6 +175,49 @@ func printEmergencyFundSummary(ef *forecast.EmergencyFundRecommendation) {
 	fmt.Println(line)
 }
 
+func printTaxSummary(taxes *forecast.TaxSummary) {
+	if taxes == nil {
+		return
+	}
+	fmt.Printf("Income taxes paid: %s (federal %s, state %s)\n",
+		formatutil.Currency(taxes.Total()),
+		formatutil.Currency(taxes.Federal),
+		formatutil.Currency(taxes.State),
+	)
+}
+
+func printDebtPayoffSummary(plans []forecast.DebtPayoffSummary) {
+	for _, plan := range plans {
+		fmt.Printf("Debt payoff plan (%s loans, %s, %s/month extra): %s, interest saved %s\n",
+			plan.Scope, plan.Report.Strategy, formatutil.Currency(plan.Budget),
+			debtFreeDisplay(plan.Report), formatutil.Currency(plan.Report.InterestSaved()))
+		for _, loan := range plan.Report.Loans {
+			fmt.Printf("  %s: paid off %s (minimum payments %s), interest %s (minimum payments %s)\n",
+				loan.Name, monthOrBeyond(loan.Payoff), monthOrBeyond(loan.BaselinePayoff),
+				formatutil.Currency(loan.Interest), formatutil.Currency(loan.BaselineInterest))
+		}
+		for _, report := range plan.Comparison {
+			fmt.Printf("  Compare %s: %s, interest %s\n", report.Strategy, debtFreeDisplay(report), formatutil.Currency(report.Interest()))
+		}
+	}
+}
+
+// debtFreeDisplay describes when a payoff report clears all of its loans.
+func debtFreeDisplay(report loans.PayoffReport) string {
+	if month, ok := report.DebtFree(); ok {
+		return "debt free " + month
+	}
+	return "not debt free within the forecast"
+}
+
+// monthOrBeyond returns the month or notes that it falls after the forecast.
+func monthOrBeyond(month string) string {
+	if month == "" {
+		return "after the forecast"
+	}
+	return month
+}
+
 func printOptimizationSummary(summaries []optimization.Summary) {
 	if len(summaries) == 0 {
 		return
@@ -192,10 +287,36 @@ func buildCsvLines(results []forecast.Forecast) []string {
 	}
 	sort.Strings(dates)
 
+	// Household ages are the same in every scenario, so they follow the date once.
+	var ages []forecast.MemberAges
+	for _, scenario := range results {
+		if len(scenario.Ages) > 0 {
+			ages = scenario.Ages
+			break
+		}
+	}
+
 	header := []string{"\"date\""}
+	for _, member := range ages {
+		header = append(header, fmt.Sprintf("\"age (%s)\"", member.Name))
+	}
 	for _, scenario := range results {
 		header = append(header, fmt.Sprintf("\"liquid (%s)\"", scenario.Name))
 		header = append(header, fmt.Sprintf("\"total (%s)\"", scenario.Name))
+		if scenario.NetWorth != nil {
+			header = append(header, fmt.Sprintf("\"liabilities (%s)\"", scenario.Name))
+			header = append(header, fmt.Sprintf("\"net worth (%s)\"", scenario.Name))
+		}
+		if scenario.RealData != nil {
+			header = append(header, fmt.Sprintf("\"real liquid (%s)\"", scenario.Name))
+			header = append(header, fmt.Sprintf("\"real total (%s)\"", scenario.Name))
+			if scenario.RealNetWorth != nil {
+				header = append(header, fmt.Sprintf("\"real net worth (%s)\"", scenario.Name))
+			}
+		}
+		for _, account := range scenario.Accounts {
+			header = append(header, fmt.Sprintf("\"%s (%s)\"", account.Name, scenario.Name))
+		}
 		header = append(header, fmt.Sprintf("\"notes (%s)\"", scenario.Name))
 	}
 
@@ -203,17 +324,27 @@ func buildCsvLines(results []forecast.Forecast) []string {
 
 	for _, date := range dates {
 		row := []string{fmt.Sprintf("\"%s\"", date)}
-		for _, scenario := range results {
-			if liquid, lOK := scenario.Liquid[date]; lOK {
-				row = append(row, fmt.Sprintf("\"%.2f\"", liquid))
+		for _, member := range ages {
+			if age, ok := member.Ages[date]; ok {
+				row = append(row, fmt.Sprintf("\"%d\"", age))
 			} else {
 				row = append(row, "\"\"")
 			}
+		}
+		for _, scenario := range results {
+			row = append(row, csvValue(scenario.Liquid, date), csvValue(scenario.Data, date))
+			if scenario.NetWorth != nil {
+				row = append(row, csvValue(scenario.Liabilities, date), csvValue(scenario.NetWorth, date))
+			}
 
-			if total, tOK := scenario.Data[date]; tOK {
-				row = append(row, fmt.Sprintf("\"%.2f\"", total))
-			} else {
-				row = append(row, "\"\"")
+			if scenario.RealData != nil {
+				row = append(row, csvValue(scenario.RealLiquid, date), csvValue(scenario.RealData, date))
+				if scenario.RealNetWorth != nil {
+					row = append(row, csvValue(scenario.RealNetWorth, date))
+				}
+			}
+			for _, account := range scenario.Accounts {
+				row = append(row, csvValue(account.Balances, date))
 			}
 
 			if notes, hasNotes := scenario.Notes[date]; hasNotes && len(notes) > 0 {
@@ -227,3 +358,11 @@ func buildCsvLines(results []forecast.Forecast) []string {
 
 	return lines
 }
+
+// csvValue formats the value for date as a quoted CSV cell, empty when missing.
+func csvValue(values map[string]float64, date string) string {
+	if value, ok := values[date]; ok {
+		return fmt.Sprintf("\"%.2f\"", value)
+	}
+	return "\"\""
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/iwvelando/finance-forecast/internal/forecast"
+	"github.com/iwvelando/finance-forecast/pkg/loans"
 	"github.com/iwvelando/finance-forecast/pkg/optimization"
 )
 
@@ -104,6 +105,85 @@ func TestPrettyFormatEmergencyFundSummary(t *testing.T) {
 	}
 }
 
+func TestPrettyFormatTaxSummary(t *testing.T) {
+	results := []forecast.Forecast{
+		{
+			Name:   "Scenario A",
+			Data:   map[string]float64{"2025-01": 1000},
+			Liquid: map[string]float64{"2025-01": 800},
+			Metrics: forecast.ForecastMetrics{
+				Taxes: &forecast.TaxSummary{Federal: 12000, State: 3000},
+			},
+		},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettyFormat(results)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	if !strings.Contains(output, "Income taxes paid: $15,000.00 (federal $12,000.00, state $3,000.00)") {
+		t.Fatalf("expected tax summary, got %q", output)
+	}
+}
+
+func TestPrettyFormatDebtPayoffSummary(t *testing.T) {
+	report := loans.PayoffReport{
+		Strategy: "avalanche",
+		Loans: []loans.PayoffOutcome{
+			{Name: "Card loan", Payoff: "2026-03", BaselinePayoff: "2026-12", Interest: 150, BaselineInterest: 250},
+			{Name: "Car", Payoff: "2026-05", BaselinePayoff: "2025-12"},
+		},
+	}
+	results := []forecast.Forecast{
+		{
+			Name:   "Scenario A",
+			Data:   map[string]float64{"2025-01": 1000},
+			Liquid: map[string]float64{"2025-01": 800},
+			Metrics: forecast.ForecastMetrics{
+				DebtPayoff: []forecast.DebtPayoffSummary{{
+					Scope:      "scenario",
+					Budget:     100,
+					Report:     report,
+					Comparison: []loans.PayoffReport{report, {Strategy: "snowball", Loans: []loans.PayoffOutcome{{Name: "Car"}}}},
+				}},
+			},
+		},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettyFormat(results)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	for _, want := range []string{
+		"Debt payoff plan (scenario loans, avalanche, $100.00/month extra): debt free 2026-05, interest saved $100.00",
+		"  Card loan: paid off 2026-03 (minimum payments 2026-12), interest $150.00 (minimum payments $250.00)",
+		"  Compare avalanche: debt free 2026-05, interest $150.00",
+		"  Compare snowball: not debt free within the forecast, interest $0.00",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got %q", want, output)
+		}
+	}
+}
+
 func TestPrettyFormatOptimizationSummary(t *testing.T) {
 	results := []forecast.Forecast{
 		{
@@ -322,6 +402,201 @@ func TestCsvFormat(t *testing.T) {
 	}
 }
 
+func TestRealColumns(t *testing.T) {
+	results := []forecast.Forecast{
+		{
+			Name:       "Indexed",
+			Data:       map[string]float64{"2025-01": 1000.00, "2026-01": 1030.00},
+			Liquid:     map[string]float64{"2025-01": 500.00, "2026-01": 515.00},
+			RealData:   map[string]float64{"2025-01": 1000.00, "2026-01": 1000.00},
+			RealLiquid: map[string]float64{"2025-01": 500.00, "2026-01": 500.00},
+			Notes:      map[string][]string{},
+		},
+	}
+
+	csv := CsvString(results)
+	lines := strings.Split(strings.TrimSpace(csv), "\n")
+	wantHeader := `"date","liquid (Indexed)","total (Indexed)","real liquid (Indexed)","real total (Indexed)","notes (Indexed)"`
+	if lines[0] != wantHeader {
+		t.Errorf("CsvString header = %s, want %s", lines[0], wantHeader)
+	}
+	if want := `"2026-01","515.00","1030.00","500.00","1000.00",""`; lines[2] != want {
+		t.Errorf("CsvString row = %s, want %s", lines[2], want)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettyFormat(results)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	if !strings.Contains(output, "Real Liquid | Real Total") {
+		t.Errorf("PrettyFormat missing real columns header:\n%s", output)
+	}
+	if !strings.Contains(output, "2026-01 | $515.00 | $1,030.00 | $500.00 | $1,000.00 |") {
+		t.Errorf("PrettyFormat missing real values:\n%s", output)
+	}
+
+	results[0].RealData = nil
+	results[0].RealLiquid = nil
+	if strings.Contains(CsvString(results), "real") {
+		t.Errorf("CsvString should omit real columns without inflation")
+	}
+}
+
+func TestNetWorthColumns(t *testing.T) {
+	results := []forecast.Forecast{
+		{
+			Name:         "House",
+			Data:         map[string]float64{"2025-01": 300000.00},
+			Liquid:       map[string]float64{"2025-01": 20000.00},
+			RealData:     map[string]float64{"2025-01": 300000.00},
+			RealLiquid:   map[string]float64{"2025-01": 20000.00},
+			Liabilities:  map[string]float64{"2025-01": 240000.00},
+			NetWorth:     map[string]float64{"2025-01": 60000.00},
+			RealNetWorth: map[string]float64{"2025-01": 60000.00},
+			Notes:        map[string][]string{},
+		},
+	}
+
+	lines := strings.Split(strings.TrimSpace(CsvString(results)), "\n")
+	wantHeader := `"date","liquid (House)","total (House)","liabilities (House)","net worth (House)","real liquid (House)","real total (House)","real net worth (House)","notes (House)"`
+	if lines[0] != wantHeader {
+		t.Errorf("CsvString header = %s, want %s", lines[0], wantHeader)
+	}
+	if want := `"2025-01","20000.00","300000.00","240000.00","60000.00","20000.00","300000.00","60000.00",""`; lines[1] != want {
+		t.Errorf("CsvString row = %s, want %s", lines[1], want)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettyFormat(results)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	if !strings.Contains(output, "Total Net Worth | Liabilities | Net Worth | Real Liquid | Real Total | Real Net Worth | Notes") {
+		t.Errorf("PrettyFormat missing net worth columns:\n%s", output)
+	}
+	if !strings.Contains(output, "2025-01 | $20,000.00 | $300,000.00 | $240,000.00 | $60,000.00 | $20,000.00 | $300,000.00 | $60,000.00 |") {
+		t.Errorf("PrettyFormat missing net worth values:\n%s", output)
+	}
+
+	results[0].Liabilities = nil
+	results[0].NetWorth = nil
+	results[0].RealNetWorth = nil
+	if strings.Contains(CsvString(results), "net worth") {
+		t.Errorf("CsvString should omit net worth columns without loans")
+	}
+}
+
+func TestCashAccountColumns(t *testing.T) {
+	results := []forecast.Forecast{
+		{
+			Name:   "Base",
+			Data:   map[string]float64{"2025-01": 3000.00},
+			Liquid: map[string]float64{"2025-01": 3000.00},
+			Accounts: []forecast.AccountSeries{
+				{Name: "Checking", Balances: map[string]float64{"2025-01": 1000.00}},
+				{Name: "Savings", Balances: map[string]float64{"2025-01": 2000.00}},
+			},
+			Notes: map[string][]string{},
+		},
+	}
+
+	lines := strings.Split(strings.TrimSpace(CsvString(results)), "\n")
+	if want := `"date","liquid (Base)","total (Base)","Checking (Base)","Savings (Base)","notes (Base)"`; lines[0] != want {
+		t.Errorf("CsvString header = %s, want %s", lines[0], want)
+	}
+	if want := `"2025-01","3000.00","3000.00","1000.00","2000.00",""`; lines[1] != want {
+		t.Errorf("CsvString row = %s, want %s", lines[1], want)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettyFormat(results)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	if !strings.Contains(output, "Date    | Liquid Net Worth | Total Net Worth | Checking | Savings | Notes") {
+		t.Errorf("PrettyFormat missing account columns:\n%s", output)
+	}
+	if !strings.Contains(output, "2025-01 | $3,000.00 | $3,000.00 | $1,000.00 | $2,000.00 |") {
+		t.Errorf("PrettyFormat missing account balances:\n%s", output)
+	}
+}
+
+func TestMemberAgeColumns(t *testing.T) {
+	results := []forecast.Forecast{
+		{
+			Name:   "Base",
+			Data:   map[string]float64{"2025-01": 3000.00},
+			Liquid: map[string]float64{"2025-01": 3000.00},
+			Ages: []forecast.MemberAges{
+				{Name: "Alex", Ages: map[string]int{"2025-01": 44}},
+			},
+			Notes: map[string][]string{},
+		},
+		{
+			Name:   "Alt",
+			Data:   map[string]float64{"2025-01": 2000.00},
+			Liquid: map[string]float64{"2025-01": 2000.00},
+			Ages: []forecast.MemberAges{
+				{Name: "Alex", Ages: map[string]int{"2025-01": 44}},
+			},
+			Notes: map[string][]string{},
+		},
+	}
+
+	lines := strings.Split(strings.TrimSpace(CsvString(results)), "\n")
+	if want := `"date","age (Alex)","liquid (Base)","total (Base)","notes (Base)","liquid (Alt)","total (Alt)","notes (Alt)"`; lines[0] != want {
+		t.Errorf("CsvString header = %s, want %s", lines[0], want)
+	}
+	if want := `"2025-01","44","3000.00","3000.00","","2000.00","2000.00",""`; lines[1] != want {
+		t.Errorf("CsvString row = %s, want %s", lines[1], want)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettyFormat(results[:1])
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	if !strings.Contains(output, "Date    | Alex age | Liquid Net Worth | Total Net Worth | Notes") {
+		t.Errorf("PrettyFormat missing age column:\n%s", output)
+	}
+	if !strings.Contains(output, "2025-01 | 44 | $3,000.00 | $3,000.00 |") {
+		t.Errorf("PrettyFormat missing ages:\n%s", output)
+	}
+}
+
 func TestCsvStringMatchesCsvFormat(t *testing.T) {
 	results := []forecast.Forecast{
 		{
@@ -0,0 +1,120 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iwvelando/finance-forecast/internal/simulation"
+	formatutil "github.com/iwvelando/finance-forecast/pkg/format"
+)
+
+// PrettyMonteCarlo formats Monte Carlo percentile bands in a human-readable format.
+func PrettyMonteCarlo(result *simulation.MonteCarloResult) {
+	if result == nil || len(result.Scenarios) == 0 {
+		fmt.Println("No Monte Carlo results to display.")
+		return
+	}
+
+	dates := monteCarloDates(result)
+
+	for _, scenario := range result.Scenarios {
+		fmt.Printf("--- Monte Carlo results for scenario %s (%d iterations, seed %d) ---\n",
+			scenario.Name, result.Iterations, result.Seed)
+		fmt.Printf("Probability liquid net worth goes negative: %.1f%%\n", scenario.ProbabilityLiquidNegative*100)
+		fmt.Printf("Probability total net worth goes negative: %.1f%%\n", scenario.ProbabilityTotalNegative*100)
+		fmt.Printf("Date    | Liquid p10 | Liquid p50 | Liquid p90 | Total p10 | Total p50 | Total p90\n")
+		fmt.Printf("____    | __________ | __________ | __________ | _________ | _________ | _________\n")
+
+		for _, date := range dates {
+			liquid, liquidOK := scenario.Liquid[date]
+			total, totalOK := scenario.Total[date]
+			if !liquidOK && !totalOK {
+				continue
+			}
+			fmt.Printf("%s | %s | %s | %s | %s | %s | %s\n",
+				date,
+				formatutil.Currency(liquid.P10),
+				formatutil.Currency(liquid.P50),
+				formatutil.Currency(liquid.P90),
+				formatutil.Currency(total.P10),
+				formatutil.Currency(total.P50),
+				formatutil.Currency(total.P90),
+			)
+		}
+		fmt.Println()
+	}
+}
+
+// MonteCarloCsvFormat outputs Monte Carlo percentile bands in comma-separated value format.
+func MonteCarloCsvFormat(result *simulation.MonteCarloResult) {
+	for _, line := range buildMonteCarloCsvLines(result) {
+		fmt.Println(line)
+	}
+}
+
+// MonteCarloCsvString converts Monte Carlo percentile bands into a CSV string.
+func MonteCarloCsvString(result *simulation.MonteCarloResult) string {
+	lines := buildMonteCarloCsvLines(result)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func buildMonteCarloCsvLines(result *simulation.MonteCarloResult) []string {
+	header := []string{"\"date\""}
+	if result == nil || len(result.Scenarios) == 0 {
+		return []string{strings.Join(header, ",")}
+	}
+
+	for _, scenario := range result.Scenarios {
+		for _, series := range []string{"liquid", "total"} {
+			for _, pct := range []string{"p10", "p50", "p90"} {
+				header = append(header, fmt.Sprintf("\"%s %s (%s)\"", series, pct, scenario.Name))
+			}
+		}
+	}
+	lines := []string{strings.Join(header, ",")}
+
+	for _, date := range monteCarloDates(result) {
+		row := []string{fmt.Sprintf("\"%s\"", date)}
+		for _, scenario := range result.Scenarios {
+			row = append(row, bandCells(scenario.Liquid, date)...)
+			row = append(row, bandCells(scenario.Total, date)...)
+		}
+		lines = append(lines, strings.Join(row, ","))
+	}
+
+	return lines
+}
+
+func bandCells(bands map[string]simulation.PercentileBand, date string) []string {
+	band, ok := bands[date]
+	if !ok {
+		return []string{"\"\"", "\"\"", "\"\""}
+	}
+	return []string{
+		fmt.Sprintf("\"%.2f\"", band.P10),
+		fmt.Sprintf("\"%.2f\"", band.P50),
+		fmt.Sprintf("\"%.2f\"", band.P90),
+	}
+}
+
+func monteCarloDates(result *simulation.MonteCarloResult) []string {
+	dateSet := make(map[string]struct{})
+	for _, scenario := range result.Scenarios {
+		for date := range scenario.Total {
+			dateSet[date] = struct{}{}
+		}
+		for date := range scenario.Liquid {
+			dateSet[date] = struct{}{}
+		}
+	}
+	dates := make([]string, 0, len(dateSet))
+	for date := range dateSet {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates
+}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/iwvelando/finance-forecast/internal/simulation"
+)
+
+func sampleMonteCarloResult() *simulation.MonteCarloResult {
+	return &simulation.MonteCarloResult{
+		Iterations: 100,
+		Seed:       42,
+		Scenarios: []simulation.ScenarioDistribution{
+			{
+				Name: "Base",
+				Liquid: map[string]simulation.PercentileBand{
+					"2025-02": {P10: 900, P50: 1000, P90: 1100},
+					"2025-01": {P10: 1000, P50: 1000, P90: 1000},
+				},
+				Total: map[string]simulation.PercentileBand{
+					"2025-02": {P10: 1900, P50: 2000, P90: 2300},
+					"2025-01": {P10: 2000, P50: 2000, P90: 2000},
+				},
+				ProbabilityLiquidNegative: 0.125,
+				ProbabilityTotalNegative:  0.05,
+			},
+		},
+	}
+}
+
+func TestPrettyMonteCarlo(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettyMonteCarlo(sampleMonteCarloResult())
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	expected := []string{
+		"--- Monte Carlo results for scenario Base (100 iterations, seed 42) ---",
+		"Probability liquid net worth goes negative: 12.5%",
+		"Probability total net worth goes negative: 5.0%",
+		"Date    | Liquid p10 | Liquid p50 | Liquid p90 | Total p10 | Total p50 | Total p90",
+		"2025-02 | $900.00 | $1,000.00 | $1,100.00 | $1,900.00 | $2,000.00 | $2,300.00",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("PrettyMonteCarlo output missing %q\n%s", want, output)
+		}
+	}
+	if strings.Index(output, "2025-01 |") > strings.Index(output, "2025-02 |") {
+		t.Error("expected dates to be sorted")
+	}
+}
+
+func TestPrettyMonteCarloEmpty(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettyMonteCarlo(nil)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	if !strings.Contains(buf.String(), "No Monte Carlo results to display.") {
+		t.Errorf("unexpected output for empty result: %q", buf.String())
+	}
+}
+
+func TestMonteCarloCsvString(t *testing.T) {
+	csv := MonteCarloCsvString(sampleMonteCarloResult())
+	lines := strings.Split(strings.TrimSpace(csv), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and two rows, got %d lines:\n%s", len(lines), csv)
+	}
+
+	expectedHeader := `"date","liquid p10 (Base)","liquid p50 (Base)","liquid p90 (Base)","total p10 (Base)","total p50 (Base)","total p90 (Base)"`
+	if lines[0] != expectedHeader {
+		t.Errorf("header = %s, want %s", lines[0], expectedHeader)
+	}
+	expectedRow := `"2025-02","900.00","1000.00","1100.00","1900.00","2000.00","2300.00"`
+	if lines[2] != expectedRow {
+		t.Errorf("row = %s, want %s", lines[2], expectedRow)
+	}
+
+	if got := MonteCarloCsvString(nil); got != "\"date\"\n" {
+		t.Errorf("unexpected CSV for nil result: %q", got)
+	}
+}
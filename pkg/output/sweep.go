@@ -0,0 +1,146 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iwvelando/finance-forecast/internal/simulation"
+	formatutil "github.com/iwvelando/finance-forecast/pkg/format"
+)
+
+// sweepMetrics lists the matrices shown for every sweep, in order.
+var sweepMetrics = []struct {
+	key    string
+	title  string
+	pretty func(simulation.SweepCell) string
+	csv    func(simulation.SweepCell) string
+}{
+	{
+		key:    "endNetWorth",
+		title:  "End net worth",
+		pretty: func(cell simulation.SweepCell) string { return formatutil.Currency(cell.EndNetWorth) },
+		csv:    func(cell simulation.SweepCell) string { return fmt.Sprintf("%.2f", cell.EndNetWorth) },
+	},
+	{
+		key:    "minLiquid",
+		title:  "Minimum liquid cash",
+		pretty: func(cell simulation.SweepCell) string { return formatutil.Currency(cell.MinLiquid) },
+		csv:    func(cell simulation.SweepCell) string { return fmt.Sprintf("%.2f", cell.MinLiquid) },
+	},
+	{
+		key:   "depletionDate",
+		title: "Depletion date",
+		pretty: func(cell simulation.SweepCell) string {
+			if cell.DepletionDate == "" {
+				return "never"
+			}
+			return cell.DepletionDate
+		},
+		csv: func(cell simulation.SweepCell) string { return cell.DepletionDate },
+	},
+}
+
+// PrettySweeps formats parameter sweep grids in a human-readable format, one
+// matrix per summary value.
+func PrettySweeps(result *simulation.SweepResult) {
+	if result == nil || len(result.Sweeps) == 0 {
+		fmt.Println("No sweep results to display.")
+		return
+	}
+
+	for _, grid := range result.Sweeps {
+		fmt.Printf("--- Sweep %s over scenario %s (%d scenarios) ---\n",
+			grid.Name, grid.Scenario, sweepCellCount(grid))
+		header := sweepHeader(grid)
+		for _, metric := range sweepMetrics {
+			fmt.Println(metric.title)
+			fmt.Println(strings.Join(header, " | "))
+			underline := make([]string, len(header))
+			for i, title := range header {
+				underline[i] = strings.Repeat("_", len(title))
+			}
+			fmt.Println(strings.Join(underline, " | "))
+			for i, row := range grid.Cells {
+				line := []string{sweepValue(grid.Rows.Values[i])}
+				for _, cell := range row {
+					line = append(line, metric.pretty(cell))
+				}
+				fmt.Println(strings.Join(line, " | "))
+			}
+			fmt.Println()
+		}
+	}
+}
+
+// SweepCsvFormat outputs parameter sweep grids in comma-separated value format.
+func SweepCsvFormat(result *simulation.SweepResult) {
+	for _, line := range buildSweepCsvLines(result) {
+		fmt.Println(line)
+	}
+}
+
+// SweepCsvString converts parameter sweep grids into a CSV string. Each sweep
+// starts with its own header row, followed by one matrix per summary value
+// with a row for each value of the sweep's first axis.
+func SweepCsvString(result *simulation.SweepResult) string {
+	lines := buildSweepCsvLines(result)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func buildSweepCsvLines(result *simulation.SweepResult) []string {
+	if result == nil || len(result.Sweeps) == 0 {
+		return []string{`"sweep","metric"`}
+	}
+
+	var lines []string
+	for _, grid := range result.Sweeps {
+		header := []string{`"sweep"`, `"metric"`}
+		for _, title := range sweepHeader(grid) {
+			header = append(header, csvQuote(title))
+		}
+		lines = append(lines, strings.Join(header, ","))
+		for _, metric := range sweepMetrics {
+			for i, row := range grid.Cells {
+				line := []string{csvQuote(grid.Name), csvQuote(metric.key), csvQuote(sweepValue(grid.Rows.Values[i]))}
+				for _, cell := range row {
+					line = append(line, csvQuote(metric.csv(cell)))
+				}
+				lines = append(lines, strings.Join(line, ","))
+			}
+		}
+	}
+	return lines
+}
+
+// sweepHeader returns the matrix column titles: the axis labels, then the
+// values of the second axis.
+func sweepHeader(grid simulation.SweepGrid) []string {
+	if len(grid.Columns.Values) == 0 {
+		return []string{grid.Rows.Label, "value"}
+	}
+	header := []string{grid.Rows.Label + ` \ ` + grid.Columns.Label}
+	for _, value := range grid.Columns.Values {
+		header = append(header, sweepValue(value))
+	}
+	return header
+}
+
+func sweepCellCount(grid simulation.SweepGrid) int {
+	count := 0
+	for _, row := range grid.Cells {
+		count += len(row)
+	}
+	return count
+}
+
+func sweepValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func csvQuote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/iwvelando/finance-forecast/internal/simulation"
+)
+
+func sampleSweepResult() *simulation.SweepResult {
+	return &simulation.SweepResult{
+		Sweeps: []simulation.SweepGrid{
+			{
+				Name:     "House",
+				Scenario: "Buy",
+				Rows:     simulation.SweepAxisValues{Label: "price", Values: []float64{300000, 350000}},
+				Columns:  simulation.SweepAxisValues{Label: "rate", Values: []float64{5, 5.5}},
+				Cells: [][]simulation.SweepCell{
+					{
+						{Scenario: "House [price 300000, rate 5]", EndNetWorth: 900000, MinLiquid: 2500},
+						{Scenario: "House [price 300000, rate 5.5]", EndNetWorth: 850000, MinLiquid: 1500},
+					},
+					{
+						{Scenario: "House [price 350000, rate 5]", EndNetWorth: 700000, MinLiquid: -100, DepletionDate: "2031-04"},
+						{Scenario: "House [price 350000, rate 5.5]", EndNetWorth: 650000, MinLiquid: -900, DepletionDate: "2030-11"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPrettySweeps(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrettySweeps(sampleSweepResult())
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	expected := []string{
+		"--- Sweep House over scenario Buy (4 scenarios) ---",
+		"End net worth\nprice \\ rate | 5 | 5.5",
+		"300000 | $900,000.00 | $850,000.00",
+		"Minimum liquid cash",
+		"350000 | -$100.00 | -$900.00",
+		"Depletion date",
+		"300000 | never | never",
+		"350000 | 2031-04 | 2030-11",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("PrettySweeps output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestSweepCsvString(t *testing.T) {
+	csv := SweepCsvString(sampleSweepResult())
+	lines := strings.Split(strings.TrimSpace(csv), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("expected header and two rows per metric, got %d lines:\n%s", len(lines), csv)
+	}
+
+	expected := map[int]string{
+		0: `"sweep","metric","price \ rate","5","5.5"`,
+		1: `"House","endNetWorth","300000","900000.00","850000.00"`,
+		4: `"House","minLiquid","350000","-100.00","-900.00"`,
+		5: `"House","depletionDate","300000","",""`,
+		6: `"House","depletionDate","350000","2031-04","2030-11"`,
+	}
+	for i, want := range expected {
+		if lines[i] != want {
+			t.Errorf("line %d = %s, want %s", i, lines[i], want)
+		}
+	}
+
+	if got := SweepCsvString(nil); got != "\"sweep\",\"metric\"\n" {
+		t.Errorf("unexpected CSV for nil result: %q", got)
+	}
+}
@@ -0,0 +1,110 @@
+package returns
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+)
+
+// Historical series are approximate calendar-year total returns for US large-cap
+// stocks (S&P 500 with dividends) and 10-year Treasury bonds, compiled from
+// public data published by Aswath Damodaran (NYU Stern). The 60/40 series is an
+// annually rebalanced blend of the two.
+//
+//go:embed data/*.csv
+var historicalFiles embed.FS
+
+// Series is a named sequence of consecutive calendar-year returns.
+type Series struct {
+	Name      string
+	FirstYear int
+	// Annual holds one return per year starting at FirstYear, as percentages.
+	Annual []float64
+}
+
+// SeriesNames lists the embedded historical return series.
+func SeriesNames() []string {
+	entries, err := historicalFiles.ReadDir("data")
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadSeries reads the named embedded historical return series.
+func LoadSeries(name string) (*Series, error) {
+	canonical := strings.ToLower(strings.TrimSpace(name))
+	data, err := historicalFiles.ReadFile(path.Join("data", canonical+".csv"))
+	if err != nil {
+		return nil, fmt.Errorf("historical return series %q is not available (choose from %s)", name, strings.Join(SeriesNames(), ", "))
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read historical return series %s: %w", canonical, err)
+	}
+
+	series := &Series{Name: canonical}
+	for i, record := range records {
+		if i == 0 {
+			continue // header
+		}
+		if len(record) != 2 {
+			return nil, fmt.Errorf("historical return series %s line %d: expected 2 columns, got %d", canonical, i+1, len(record))
+		}
+		year, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("historical return series %s line %d: invalid year: %w", canonical, i+1, err)
+		}
+		value, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("historical return series %s line %d: invalid return: %w", canonical, i+1, err)
+		}
+		if series.FirstYear == 0 {
+			series.FirstYear = year
+		} else if year != series.FirstYear+len(series.Annual) {
+			return nil, fmt.Errorf("historical return series %s line %d: year %d is not consecutive", canonical, i+1, year)
+		}
+		series.Annual = append(series.Annual, value)
+	}
+
+	if len(series.Annual) == 0 {
+		return nil, fmt.Errorf("historical return series %s is empty", canonical)
+	}
+	return series, nil
+}
+
+// LastYear returns the final calendar year covered by the series.
+func (s *Series) LastYear() int {
+	return s.FirstYear + len(s.Annual) - 1
+}
+
+// MonthlyPath replays the series beginning at startYear: the first twelve dates
+// use that year's return spread evenly across its months, the next twelve use
+// the following year, and so on. Returns are decimals keyed by date.
+func (s *Series) MonthlyPath(dates []string, startYear int) (map[string]float64, error) {
+	years := (len(dates) + constants.MonthsPerYear - 1) / constants.MonthsPerYear
+	if startYear < s.FirstYear || startYear+years-1 > s.LastYear() {
+		return nil, fmt.Errorf("historical return series %s covers %d-%d, cannot replay %d years from %d",
+			s.Name, s.FirstYear, s.LastYear(), years, startYear)
+	}
+
+	path := make(map[string]float64, len(dates))
+	for i, date := range dates {
+		annual := s.Annual[startYear-s.FirstYear+i/constants.MonthsPerYear] / constants.PercentageMultiplier
+		path[date] = math.Pow(1+annual, 1.0/constants.MonthsPerYear) - 1
+	}
+	return path, nil
+}
@@ -0,0 +1,80 @@
+package returns
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSeriesNames(t *testing.T) {
+	names := SeriesNames()
+	expected := []string{"60-40", "us-bonds", "us-stocks"}
+	if len(names) != len(expected) {
+		t.Fatalf("SeriesNames() = %v, want %v", names, expected)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("SeriesNames() = %v, want %v", names, expected)
+		}
+	}
+}
+
+func TestLoadSeries(t *testing.T) {
+	stocks, err := LoadSeries("US-Stocks")
+	if err != nil {
+		t.Fatalf("LoadSeries returned error: %v", err)
+	}
+	if stocks.FirstYear != 1928 || stocks.LastYear() != 2023 {
+		t.Fatalf("unexpected coverage %d-%d", stocks.FirstYear, stocks.LastYear())
+	}
+	if got := stocks.Annual[2008-stocks.FirstYear]; got != -36.55 {
+		t.Fatalf("2008 return = %.2f, want -36.55", got)
+	}
+
+	bonds, err := LoadSeries("us-bonds")
+	if err != nil {
+		t.Fatalf("LoadSeries returned error: %v", err)
+	}
+	blend, err := LoadSeries("60-40")
+	if err != nil {
+		t.Fatalf("LoadSeries returned error: %v", err)
+	}
+	for i := range blend.Annual {
+		want := 0.6*stocks.Annual[i] + 0.4*bonds.Annual[i]
+		if math.Abs(blend.Annual[i]-want) > 0.006 {
+			t.Fatalf("60/40 return for %d = %.2f, want %.2f", blend.FirstYear+i, blend.Annual[i], want)
+		}
+	}
+
+	if _, err := LoadSeries("gold"); err == nil {
+		t.Fatal("expected error for unknown series")
+	}
+}
+
+func TestSeriesMonthlyPath(t *testing.T) {
+	series := &Series{Name: "test", FirstYear: 2000, Annual: []float64{12, -10, 5}}
+	dates := []string{"2025-02", "2025-03", "2025-04", "2025-05", "2025-06", "2025-07",
+		"2025-08", "2025-09", "2025-10", "2025-11", "2025-12", "2026-01", "2026-02"}
+
+	path, err := series.MonthlyPath(dates, 2001)
+	if err != nil {
+		t.Fatalf("MonthlyPath returned error: %v", err)
+	}
+
+	compounded := 1.0
+	for _, date := range dates[:12] {
+		compounded *= 1 + path[date]
+	}
+	if math.Abs(compounded-0.9) > 1e-9 {
+		t.Fatalf("first twelve months compound to %.6f, want 0.9", compounded)
+	}
+	if want := math.Pow(1.05, 1.0/12) - 1; math.Abs(path["2026-02"]-want) > 1e-12 {
+		t.Fatalf("thirteenth month return = %.6f, want %.6f", path["2026-02"], want)
+	}
+
+	if _, err := series.MonthlyPath(dates, 2002); err == nil {
+		t.Fatal("expected error when the window runs past the end of the series")
+	}
+	if _, err := series.MonthlyPath(dates, 1999); err == nil {
+		t.Fatal("expected error when the window starts before the series")
+	}
+}
@@ -0,0 +1,140 @@
+// Package returns provides investment return models used by stochastic simulations.
+package returns
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+)
+
+const (
+	// DistributionNormal draws arithmetic monthly returns from a normal distribution.
+	DistributionNormal = "normal"
+
+	// DistributionLognormal draws monthly log returns from a normal distribution so
+	// that a single month can never lose more than the full balance.
+	DistributionLognormal = "lognormal"
+)
+
+// Model describes the distribution used to sample investment returns. Mean and
+// Volatility are annual figures expressed as percentages.
+type Model struct {
+	Distribution string
+	Mean         float64
+	Volatility   float64
+}
+
+// CanonicalDistribution returns the canonical identifier for a distribution name.
+func CanonicalDistribution(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "lognormal", "log-normal", "log_normal":
+		return DistributionLognormal
+	case "normal", "gaussian":
+		return DistributionNormal
+	default:
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+}
+
+// Validate returns an error when the model cannot be sampled.
+func (m Model) Validate() error {
+	switch CanonicalDistribution(m.Distribution) {
+	case DistributionNormal, DistributionLognormal:
+	default:
+		return fmt.Errorf("return distribution %q is not supported", m.Distribution)
+	}
+	if m.Volatility < 0 {
+		return fmt.Errorf("return volatility %.2f cannot be negative", m.Volatility)
+	}
+	if m.Mean <= -constants.PercentageMultiplier {
+		return fmt.Errorf("return mean %.2f must be greater than -100", m.Mean)
+	}
+	return nil
+}
+
+// Sampler draws monthly returns for a single model from a seeded source.
+type Sampler struct {
+	model Model
+	rng   *rand.Rand
+	mu    float64
+	sigma float64
+}
+
+// NewSampler constructs a Sampler for the model using the provided seed.
+func NewSampler(model Model, seed int64) (*Sampler, error) {
+	if err := model.Validate(); err != nil {
+		return nil, err
+	}
+	model.Distribution = CanonicalDistribution(model.Distribution)
+
+	mean := model.Mean / constants.PercentageMultiplier
+	volatility := model.Volatility / constants.PercentageMultiplier
+
+	sampler := &Sampler{
+		model: model,
+		rng:   rand.New(rand.NewSource(seed)),
+	}
+
+	switch model.Distribution {
+	case DistributionNormal:
+		sampler.mu = mean / constants.MonthsPerYear
+		sampler.sigma = volatility / math.Sqrt(constants.MonthsPerYear)
+	case DistributionLognormal:
+		// Match the annual arithmetic mean and standard deviation, then scale the
+		// resulting log-space parameters down to a single month.
+		variance := math.Log(1 + (volatility*volatility)/((1+mean)*(1+mean)))
+		sampler.mu = (math.Log(1+mean) - variance/2) / constants.MonthsPerYear
+		sampler.sigma = math.Sqrt(variance / constants.MonthsPerYear)
+	}
+
+	return sampler, nil
+}
+
+// Monthly draws the next monthly return as a decimal (0.01 == 1%).
+func (s *Sampler) Monthly() float64 {
+	draw := s.mu + s.sigma*s.rng.NormFloat64()
+	if s.model.Distribution == DistributionLognormal {
+		return math.Exp(draw) - 1
+	}
+	if draw < -1 {
+		return -1
+	}
+	return draw
+}
+
+// Path draws one monthly return for each date, keyed by the date string.
+func (s *Sampler) Path(dates []string) map[string]float64 {
+	path := make(map[string]float64, len(dates))
+	for _, date := range dates {
+		path[date] = s.Monthly()
+	}
+	return path
+}
+
+// DeriveSeed combines a base seed with an iteration number and a per-stream
+// identifier so that each investment in each iteration receives an independent
+// but reproducible random stream.
+func DeriveSeed(base int64, iteration int, stream string) int64 {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+	hash := uint64(offset)
+	mix := func(value uint64) {
+		for i := 0; i < 8; i++ {
+			hash ^= value & 0xff
+			hash *= prime
+			value >>= 8
+		}
+	}
+	mix(uint64(base))
+	mix(uint64(iteration))
+	for i := 0; i < len(stream); i++ {
+		hash ^= uint64(stream[i])
+		hash *= prime
+	}
+	return int64(hash & math.MaxInt64)
+}
@@ -0,0 +1,126 @@
+package returns
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCanonicalDistribution(t *testing.T) {
+	tests := map[string]string{
+		"":           DistributionLognormal,
+		"LogNormal":  DistributionLognormal,
+		"log-normal": DistributionLognormal,
+		"normal":     DistributionNormal,
+		" Gaussian ": DistributionNormal,
+		"uniform":    "uniform",
+	}
+	for input, expected := range tests {
+		if got := CanonicalDistribution(input); got != expected {
+			t.Errorf("CanonicalDistribution(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestModelValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   Model
+		wantErr bool
+	}{
+		{name: "default lognormal", model: Model{Mean: 7, Volatility: 15}},
+		{name: "normal", model: Model{Distribution: "normal", Mean: 5, Volatility: 10}},
+		{name: "unsupported distribution", model: Model{Distribution: "uniform"}, wantErr: true},
+		{name: "negative volatility", model: Model{Volatility: -1}, wantErr: true},
+		{name: "mean at total loss", model: Model{Mean: -100}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSamplerIsDeterministicForSeed(t *testing.T) {
+	model := Model{Mean: 7, Volatility: 15}
+	first, err := NewSampler(model, 42)
+	if err != nil {
+		t.Fatalf("NewSampler returned error: %v", err)
+	}
+	second, err := NewSampler(model, 42)
+	if err != nil {
+		t.Fatalf("NewSampler returned error: %v", err)
+	}
+
+	dates := []string{"2025-01", "2025-02", "2025-03"}
+	a := first.Path(dates)
+	b := second.Path(dates)
+	for _, date := range dates {
+		if a[date] != b[date] {
+			t.Fatalf("expected identical draws for %s, got %.6f and %.6f", date, a[date], b[date])
+		}
+	}
+}
+
+func TestSamplerZeroVolatilityMatchesMean(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    Model
+		expected float64
+	}{
+		{name: "normal", model: Model{Distribution: DistributionNormal, Mean: 12}, expected: 0.01},
+		{name: "lognormal", model: Model{Distribution: DistributionLognormal, Mean: 12}, expected: math.Pow(1.12, 1.0/12) - 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler, err := NewSampler(tt.model, 1)
+			if err != nil {
+				t.Fatalf("NewSampler returned error: %v", err)
+			}
+			if got := sampler.Monthly(); math.Abs(got-tt.expected) > 1e-12 {
+				t.Fatalf("Monthly() = %.10f, want %.10f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSamplerLognormalMatchesAnnualMean(t *testing.T) {
+	sampler, err := NewSampler(Model{Mean: 8, Volatility: 18}, 7)
+	if err != nil {
+		t.Fatalf("NewSampler returned error: %v", err)
+	}
+
+	const years = 20000
+	total := 0.0
+	for i := 0; i < years; i++ {
+		growth := 1.0
+		for m := 0; m < 12; m++ {
+			growth *= 1 + sampler.Monthly()
+		}
+		total += growth - 1
+	}
+	mean := total / years
+	if math.Abs(mean-0.08) > 0.005 {
+		t.Fatalf("expected sampled annual mean near 8%%, got %.4f", mean*100)
+	}
+}
+
+func TestDeriveSeed(t *testing.T) {
+	if DeriveSeed(1, 0, "a") != DeriveSeed(1, 0, "a") {
+		t.Fatal("expected DeriveSeed to be deterministic")
+	}
+	if DeriveSeed(1, 0, "a") == DeriveSeed(1, 1, "a") {
+		t.Fatal("expected different iterations to produce different seeds")
+	}
+	if DeriveSeed(1, 0, "a") == DeriveSeed(1, 0, "b") {
+		t.Fatal("expected different streams to produce different seeds")
+	}
+	if DeriveSeed(1, 0, "a") < 0 {
+		t.Fatal("expected non-negative seed")
+	}
+}
@@ -0,0 +1,151 @@
+// Package socialsecurity computes monthly Social Security retirement,
+// spousal, and survivor benefits from a primary insurance amount and claiming age.
+package socialsecurity
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+	"github.com/iwvelando/finance-forecast/pkg/datetime"
+)
+
+const (
+	// MinClaimingAge is the earliest age retirement benefits can be claimed.
+	MinClaimingAge = 62
+	// MaxClaimingAge is the age after which delayed retirement credits stop accruing.
+	MaxClaimingAge = 70
+
+	// survivorFloor is the minimum share of the deceased's PIA paid to a
+	// survivor when the deceased claimed early.
+	survivorFloor = 0.825
+	// spousalShare is the share of the other spouse's PIA available as a spousal benefit.
+	spousalShare = 0.5
+)
+
+// FullRetirementAgeMonths returns the full retirement age, in months, for a
+// birth year.
+func FullRetirementAgeMonths(birthYear int) int {
+	switch {
+	case birthYear <= 1937:
+		return 65 * constants.MonthsPerYear
+	case birthYear <= 1942:
+		return 65*constants.MonthsPerYear + 2*(birthYear-1937)
+	case birthYear <= 1954:
+		return 66 * constants.MonthsPerYear
+	case birthYear <= 1959:
+		return 66*constants.MonthsPerYear + 2*(birthYear-1954)
+	default:
+		return 67 * constants.MonthsPerYear
+	}
+}
+
+// ClaimingFactor returns the multiple of the PIA paid as a retirement benefit
+// claimed at claimMonths of age. Early claims lose 5/9% per month for the first
+// 36 months and 5/12% per month beyond; delayed claims earn 2/3% per month up
+// to age 70.
+func ClaimingFactor(claimMonths, fraMonths int) float64 {
+	if claimMonths < fraMonths {
+		early := fraMonths - claimMonths
+		return 1 - earlyReduction(early, 5.0/9.0)
+	}
+	delayed := min(claimMonths, MaxClaimingAge*constants.MonthsPerYear) - fraMonths
+	return 1 + float64(delayed)*(2.0/3.0)/constants.PercentageMultiplier
+}
+
+// SpousalFactor returns the multiple of the spousal benefit paid when it is
+// claimed at claimMonths of age. Early claims lose 25/36% per month for the
+// first 36 months and 5/12% per month beyond; there are no delayed credits.
+func SpousalFactor(claimMonths, fraMonths int) float64 {
+	if claimMonths >= fraMonths {
+		return 1
+	}
+	return 1 - earlyReduction(fraMonths-claimMonths, 25.0/36.0)
+}
+
+func earlyReduction(months int, firstRate float64) float64 {
+	first := math.Min(float64(months), 36)
+	rest := math.Max(float64(months)-36, 0)
+	return (first*firstRate + rest*5.0/12.0) / constants.PercentageMultiplier
+}
+
+// Person is one beneficiary. ClaimMonths is the claiming age in months and
+// Death is the month benefits stop; a zero Death means benefits never stop.
+type Person struct {
+	Birth       time.Time
+	PIA         float64
+	ClaimMonths int
+	FRAMonths   int
+	Death       time.Time
+}
+
+// Validate returns an error when the person cannot receive benefits.
+func (p Person) Validate() error {
+	if p.PIA < 0 {
+		return fmt.Errorf("pia cannot be negative")
+	}
+	if p.ClaimMonths < MinClaimingAge*constants.MonthsPerYear || p.ClaimMonths > MaxClaimingAge*constants.MonthsPerYear {
+		return fmt.Errorf("claiming age must be between %d and %d", MinClaimingAge, MaxClaimingAge)
+	}
+	return nil
+}
+
+// ClaimDate returns the month benefits start.
+func (p Person) ClaimDate() time.Time {
+	return p.Birth.AddDate(0, p.ClaimMonths, 0)
+}
+
+func (p Person) alive(date time.Time) bool {
+	return p.Death.IsZero() || date.Before(p.Death)
+}
+
+func (p Person) claimed(date time.Time) bool {
+	return !date.Before(p.ClaimDate())
+}
+
+// ownBenefit is the person's retirement benefit once claimed.
+func (p Person) ownBenefit() float64 {
+	return p.PIA * ClaimingFactor(p.ClaimMonths, p.FRAMonths)
+}
+
+// survivorBenefit is the benefit the person leaves a surviving spouse. A
+// person who dies before claiming leaves the PIA plus any delayed credits
+// earned by death; an early claim leaves at least 82.5% of the PIA.
+func (p Person) survivorBenefit() float64 {
+	claimMonths := p.ClaimMonths
+	if !p.claimed(p.Death) {
+		claimMonths = max(datetime.MonthsBetween(p.Birth, p.Death), p.FRAMonths)
+	}
+	return p.PIA * math.Max(ClaimingFactor(claimMonths, p.FRAMonths), survivorFloor)
+}
+
+// benefit returns what p receives on date given the other spouse, if any:
+// the larger of p's own benefit and the spousal or survivor benefit based on
+// the other spouse's record.
+func (p Person) benefit(other *Person, date time.Time) float64 {
+	if !p.alive(date) || !p.claimed(date) {
+		return 0
+	}
+	own := p.ownBenefit()
+	if other == nil {
+		return own
+	}
+	if !other.alive(date) {
+		return math.Max(own, other.survivorBenefit())
+	}
+	if other.claimed(date) {
+		return math.Max(own, spousalShare*other.PIA*SpousalFactor(p.ClaimMonths, p.FRAMonths))
+	}
+	return own
+}
+
+// HouseholdBenefit returns the combined monthly benefit paid on date, in
+// PIA dollars, to the worker and optional spouse.
+func HouseholdBenefit(worker Person, spouse *Person, date time.Time) float64 {
+	total := worker.benefit(spouse, date)
+	if spouse != nil {
+		total += spouse.benefit(&worker, date)
+	}
+	return total
+}
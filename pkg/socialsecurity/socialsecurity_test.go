@@ -0,0 +1,114 @@
+package socialsecurity
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func month(value string) time.Time {
+	date, err := time.Parse("2006-01", value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+func TestFullRetirementAgeMonths(t *testing.T) {
+	tests := []struct {
+		birthYear int
+		want      int
+	}{
+		{1935, 780},
+		{1940, 786},
+		{1950, 792},
+		{1957, 798},
+		{1960, 804},
+		{1985, 804},
+	}
+	for _, tt := range tests {
+		if got := FullRetirementAgeMonths(tt.birthYear); got != tt.want {
+			t.Errorf("FullRetirementAgeMonths(%d) = %d, want %d", tt.birthYear, got, tt.want)
+		}
+	}
+}
+
+func TestClaimingFactors(t *testing.T) {
+	const fra = 67 * 12
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"retirement at 62", ClaimingFactor(62*12, fra), 0.70},
+		{"retirement at 64", ClaimingFactor(64*12, fra), 0.80},
+		{"retirement at full retirement age", ClaimingFactor(fra, fra), 1.00},
+		{"retirement at 70", ClaimingFactor(70*12, fra), 1.24},
+		{"retirement credits stop at 70", ClaimingFactor(72*12, fra), 1.24},
+		{"spousal at 62", SpousalFactor(62*12, fra), 0.65},
+		{"spousal at 70", SpousalFactor(70*12, fra), 1.00},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-9 {
+			t.Errorf("%s = %.4f, want %.4f", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestPersonValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		person  Person
+		wantErr bool
+	}{
+		{"valid", Person{PIA: 2000, ClaimMonths: 67 * 12}, false},
+		{"no work record", Person{PIA: 0, ClaimMonths: 67 * 12}, false},
+		{"negative pia", Person{PIA: -1, ClaimMonths: 67 * 12}, true},
+		{"too early", Person{PIA: 2000, ClaimMonths: 61 * 12}, true},
+		{"too late", Person{PIA: 2000, ClaimMonths: 71 * 12}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.person.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHouseholdBenefit(t *testing.T) {
+	const fra = 67 * 12
+	worker := Person{Birth: month("1960-01"), PIA: 2000, ClaimMonths: fra, FRAMonths: fra}
+	spouse := Person{Birth: month("1960-01"), PIA: 500, ClaimMonths: fra, FRAMonths: fra}
+	earlyWorker := worker
+	earlyWorker.ClaimMonths = 62 * 12
+	earlyWorker.Death = month("2030-01")
+	delayedWorker := worker
+	delayedWorker.ClaimMonths = 70 * 12
+	delayedWorker.Death = month("2028-01")
+	deadSpouse := spouse
+	deadSpouse.Death = month("2030-01")
+
+	tests := []struct {
+		name   string
+		worker Person
+		spouse *Person
+		date   string
+		want   float64
+	}{
+		{"before claiming", worker, &spouse, "2026-12", 0},
+		{"single worker", worker, nil, "2027-01", 2000},
+		{"spousal top-up", worker, &spouse, "2027-01", 3000},
+		{"survivor of early claimer", earlyWorker, &spouse, "2031-01", 1650},
+		{"survivor of worker who died before claiming", delayedWorker, &spouse, "2029-01", 2160},
+		{"worker outlives spouse", worker, &deadSpouse, "2031-01", 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HouseholdBenefit(tt.worker, tt.spouse, month(tt.date))
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("HouseholdBenefit() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
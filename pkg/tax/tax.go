@@ -0,0 +1,268 @@
+// Package tax provides a progressive income tax model and a monthly engine that
+// withholds or settles the resulting liability.
+package tax
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iwvelando/finance-forecast/pkg/constants"
+)
+
+const (
+	// FilingSingle is the single filing status.
+	FilingSingle = "single"
+	// FilingMarriedJoint is the married filing jointly status.
+	FilingMarriedJoint = "married-joint"
+	// FilingMarriedSeparate is the married filing separately status.
+	FilingMarriedSeparate = "married-separate"
+	// FilingHeadOfHousehold is the head of household status.
+	FilingHeadOfHousehold = "head-of-household"
+
+	// SettlementMonthly withholds the estimated liability every month and trues
+	// it up in December.
+	SettlementMonthly = "monthly"
+	// SettlementAnnual pays each calendar year's liability in one lump sum the
+	// following April.
+	SettlementAnnual = "annual"
+
+	annualSettlementMonth = time.April
+)
+
+// Bracket is one step of a progressive schedule. Rate (percent) applies to
+// taxable income above Threshold up to the next bracket's threshold.
+type Bracket struct {
+	Threshold float64
+	Rate      float64
+}
+
+// Schedule is a progressive federal tax schedule with a standard deduction.
+type Schedule struct {
+	Brackets          []Bracket
+	StandardDeduction float64
+}
+
+// federal2024 holds the 2024 IRS brackets and standard deductions.
+var federal2024 = map[string]Schedule{
+	FilingSingle: {
+		StandardDeduction: 14600,
+		Brackets: []Bracket{
+			{0, 10}, {11600, 12}, {47150, 22}, {100525, 24}, {191950, 32}, {243725, 35}, {609350, 37},
+		},
+	},
+	FilingMarriedJoint: {
+		StandardDeduction: 29200,
+		Brackets: []Bracket{
+			{0, 10}, {23200, 12}, {94300, 22}, {201050, 24}, {383900, 32}, {487450, 35}, {731200, 37},
+		},
+	},
+	FilingMarriedSeparate: {
+		StandardDeduction: 14600,
+		Brackets: []Bracket{
+			{0, 10}, {11600, 12}, {47150, 22}, {100525, 24}, {191950, 32}, {243725, 35}, {365600, 37},
+		},
+	},
+	FilingHeadOfHousehold: {
+		StandardDeduction: 21900,
+		Brackets: []Bracket{
+			{0, 10}, {16550, 12}, {63100, 22}, {100500, 24}, {191950, 32}, {243700, 35}, {609350, 37},
+		},
+	},
+}
+
+// CanonicalFilingStatus normalizes a filing status name, defaulting to single.
+func CanonicalFilingStatus(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "single":
+		return FilingSingle
+	case "married-joint", "married_joint", "mfj", "joint":
+		return FilingMarriedJoint
+	case "married-separate", "married_separate", "mfs":
+		return FilingMarriedSeparate
+	case "head-of-household", "head_of_household", "hoh":
+		return FilingHeadOfHousehold
+	default:
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+}
+
+// FederalSchedule returns the built-in schedule for a filing status.
+func FederalSchedule(filingStatus string) (Schedule, error) {
+	schedule, ok := federal2024[CanonicalFilingStatus(filingStatus)]
+	if !ok {
+		return Schedule{}, fmt.Errorf("filing status %q is not supported", filingStatus)
+	}
+	schedule.Brackets = append([]Bracket(nil), schedule.Brackets...)
+	return schedule, nil
+}
+
+// Validate returns an error when the brackets are unusable.
+func (s Schedule) Validate() error {
+	if len(s.Brackets) == 0 {
+		return fmt.Errorf("tax schedule requires at least one bracket")
+	}
+	if s.StandardDeduction < 0 {
+		return fmt.Errorf("standard deduction %.2f cannot be negative", s.StandardDeduction)
+	}
+	for i, bracket := range s.Brackets {
+		if bracket.Rate < 0 || bracket.Rate > constants.PercentageMultiplier {
+			return fmt.Errorf("bracket %d rate %.2f must be between 0 and 100", i, bracket.Rate)
+		}
+		if i > 0 && bracket.Threshold <= s.Brackets[i-1].Threshold {
+			return fmt.Errorf("bracket thresholds must be strictly increasing")
+		}
+	}
+	return nil
+}
+
+// TaxableIncome returns income reduced by the standard deduction, never below zero.
+func (s Schedule) TaxableIncome(income float64) float64 {
+	return math.Max(income-s.StandardDeduction, 0)
+}
+
+// Tax returns the federal tax owed on a year's gross income. Brackets must be
+// in ascending threshold order, as enforced by Validate.
+func (s Schedule) Tax(income float64) float64 {
+	taxable := s.TaxableIncome(income)
+	brackets := s.Brackets
+
+	total := 0.0
+	for i, bracket := range brackets {
+		if taxable <= bracket.Threshold {
+			break
+		}
+		upper := taxable
+		if i+1 < len(brackets) && brackets[i+1].Threshold < upper {
+			upper = brackets[i+1].Threshold
+		}
+		total += (upper - bracket.Threshold) * bracket.Rate / constants.PercentageMultiplier
+	}
+	return total
+}
+
+// Liability splits a year's tax into federal and state portions.
+type Liability struct {
+	Federal float64
+	State   float64
+}
+
+// Total returns the combined federal and state amount.
+func (l Liability) Total() float64 {
+	return l.Federal + l.State
+}
+
+// Engine tracks taxable income through each calendar year and reports the tax
+// to pay every month according to the settlement mode. A forecast that ends
+// before a year's settlement calls Settle in its final month to pay what is
+// still owed.
+type Engine struct {
+	schedule   Schedule
+	stateRate  float64
+	settlement string
+
+	year     int
+	months   int
+	income   float64
+	withheld Liability
+	pending  Liability
+}
+
+// NewEngine creates an engine. stateRate is a flat percentage applied to the
+// same taxable income as the federal schedule.
+func NewEngine(schedule Schedule, stateRate float64, settlement string) (*Engine, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	if stateRate < 0 || stateRate > constants.PercentageMultiplier {
+		return nil, fmt.Errorf("state rate %.2f must be between 0 and 100", stateRate)
+	}
+	settlement = strings.ToLower(strings.TrimSpace(settlement))
+	if settlement == "" {
+		settlement = SettlementMonthly
+	}
+	if settlement != SettlementMonthly && settlement != SettlementAnnual {
+		return nil, fmt.Errorf("tax settlement %q is not supported", settlement)
+	}
+	return &Engine{schedule: schedule, stateRate: stateRate, settlement: settlement}, nil
+}
+
+// Liability returns the federal and state tax on a year's gross income.
+func (e *Engine) Liability(income float64) Liability {
+	return Liability{
+		Federal: e.schedule.Tax(income),
+		State:   e.schedule.TaxableIncome(income) * e.stateRate / constants.PercentageMultiplier,
+	}
+}
+
+// YearIncome returns the taxable income recorded so far in the current year.
+func (e *Engine) YearIncome() float64 {
+	return e.income
+}
+
+// AddIncome records taxable income for the current year without computing a
+// payment, for income realized after the month's tax was settled. Any
+// liability it creates is collected at the next payment.
+func (e *Engine) AddIncome(taxableIncome float64) {
+	e.income += taxableIncome
+}
+
+// Month records a month's taxable income and returns the tax to pay that
+// month. Monthly settlement withholds the liability on annualized year-to-date
+// income, truing up in December; the amount can be negative when earlier
+// months over-withheld. Annual settlement pays the prior year's liability in
+// April. Liability left unpaid at year end is added to the next payment.
+func (e *Engine) Month(date time.Time, taxableIncome float64) Liability {
+	if date.Year() != e.year {
+		if e.months > 0 {
+			remaining := e.Liability(e.income)
+			e.pending.Federal += remaining.Federal - e.withheld.Federal
+			e.pending.State += remaining.State - e.withheld.State
+		}
+		e.year = date.Year()
+		e.months = 0
+		e.income = 0
+		e.withheld = Liability{}
+	}
+	e.months++
+	e.income += taxableIncome
+
+	if e.settlement == SettlementAnnual {
+		if date.Month() != annualSettlementMonth {
+			return Liability{}
+		}
+		due := e.pending
+		e.pending = Liability{}
+		return due
+	}
+
+	target := e.Liability(e.income)
+	if date.Month() != time.December {
+		scale := float64(e.months) / constants.MonthsPerYear
+		annualized := e.Liability(e.income / scale)
+		target = Liability{Federal: annualized.Federal * scale, State: annualized.State * scale}
+	}
+	due := Liability{
+		Federal: target.Federal - e.withheld.Federal + e.pending.Federal,
+		State:   target.State - e.withheld.State + e.pending.State,
+	}
+	e.withheld = target
+	e.pending = Liability{}
+	return due
+}
+
+// Settle returns all tax still owed on the income recorded so far: the
+// current year's liability less what was withheld, plus any unpaid prior
+// year. It is the final payment of a forecast that ends mid-year or before
+// the April settlement.
+func (e *Engine) Settle() Liability {
+	owed := e.Liability(e.income)
+	due := Liability{
+		Federal: owed.Federal - e.withheld.Federal + e.pending.Federal,
+		State:   owed.State - e.withheld.State + e.pending.State,
+	}
+	e.withheld = owed
+	e.pending = Liability{}
+	return due
+}
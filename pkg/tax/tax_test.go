@@ -0,0 +1,186 @@
+package tax
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestScheduleTax(t *testing.T) {
+	single, err := FederalSchedule("single")
+	if err != nil {
+		t.Fatalf("FederalSchedule() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		income   float64
+		expected float64
+	}{
+		{"below deduction", 10000, 0},
+		{"first bracket", 14600 + 10000, 1000},
+		{"second bracket", 14600 + 20000, 1160 + 8400*0.12},
+		{"third bracket", 14600 + 60000, 1160 + 35550*0.12 + 12850*0.22},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := single.Tax(tt.income); math.Abs(got-tt.expected) > 1e-6 {
+				t.Errorf("Tax(%.2f) = %.4f, want %.4f", tt.income, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFederalSchedule(t *testing.T) {
+	joint, err := FederalSchedule("MFJ")
+	if err != nil {
+		t.Fatalf("FederalSchedule() error = %v", err)
+	}
+	if joint.StandardDeduction != 29200 {
+		t.Errorf("married-joint standard deduction = %.2f, want 29200", joint.StandardDeduction)
+	}
+	if _, err := FederalSchedule("widowed"); err == nil {
+		t.Error("expected error for unsupported filing status")
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	bad := Schedule{Brackets: []Bracket{{0, 10}, {0, 12}}}
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for non-increasing thresholds")
+	}
+	if err := (Schedule{}).Validate(); err == nil {
+		t.Error("expected error for empty brackets")
+	}
+}
+
+func TestEngineMonthlyWithholding(t *testing.T) {
+	schedule := Schedule{Brackets: []Bracket{{0, 10}, {60000, 20}}}
+	engine, err := NewEngine(schedule, 5, SettlementMonthly)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	total := Liability{}
+	for month := time.January; month <= time.December; month++ {
+		income := 5000.0
+		if month == time.December {
+			income += 12000 // year-end bonus
+		}
+		due := engine.Month(time.Date(2025, month, 1, 0, 0, 0, 0, time.UTC), income)
+		if month == time.January && math.Abs(due.Federal-500) > 1e-6 {
+			t.Errorf("January federal withholding = %.2f, want 500.00", due.Federal)
+		}
+		total.Federal += due.Federal
+		total.State += due.State
+	}
+
+	// 72000 of income: 6000 at 10% plus 12000 at 20%, and 5% state.
+	if math.Abs(total.Federal-8400) > 1e-6 {
+		t.Errorf("federal withheld over the year = %.2f, want 8400.00", total.Federal)
+	}
+	if math.Abs(total.State-3600) > 1e-6 {
+		t.Errorf("state withheld over the year = %.2f, want 3600.00", total.State)
+	}
+
+	due := engine.Month(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), 5000)
+	if math.Abs(due.Federal-500) > 1e-6 {
+		t.Errorf("new year federal withholding = %.2f, want 500.00", due.Federal)
+	}
+}
+
+func TestEngineAnnualSettlement(t *testing.T) {
+	schedule := Schedule{Brackets: []Bracket{{0, 10}}}
+	engine, err := NewEngine(schedule, 0, "annual")
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	for month := time.July; month <= time.December; month++ {
+		if due := engine.Month(time.Date(2025, month, 1, 0, 0, 0, 0, time.UTC), 1000); due.Total() != 0 {
+			t.Errorf("unexpected payment in %s: %.2f", month, due.Total())
+		}
+	}
+	for month := time.January; month <= time.April; month++ {
+		due := engine.Month(time.Date(2026, month, 1, 0, 0, 0, 0, time.UTC), 1000)
+		want := 0.0
+		if month == time.April {
+			want = 600
+		}
+		if math.Abs(due.Total()-want) > 1e-6 {
+			t.Errorf("payment in %s = %.2f, want %.2f", month, due.Total(), want)
+		}
+	}
+}
+
+func TestEngineAddIncomeAfterYearEnd(t *testing.T) {
+	schedule := Schedule{Brackets: []Bracket{{0, 10}}}
+	engine, err := NewEngine(schedule, 0, SettlementMonthly)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	if due := engine.Month(time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC), 1000); math.Abs(due.Total()-100) > 1e-6 {
+		t.Fatalf("December payment = %.2f, want 100.00", due.Total())
+	}
+	engine.AddIncome(500)
+
+	due := engine.Month(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), 0)
+	if math.Abs(due.Total()-50) > 1e-6 {
+		t.Errorf("January payment = %.2f, want 50.00 carried from late 2025 income", due.Total())
+	}
+}
+
+func TestEngineSettle(t *testing.T) {
+	schedule := Schedule{Brackets: []Bracket{{0, 10}}, StandardDeduction: 6000}
+
+	tests := []struct {
+		name       string
+		settlement string
+		settled    float64
+	}{
+		// Withholding on annualized 2026 income collected 150 that the
+		// partial year, within the deduction, does not owe.
+		{"monthly refunds over-withholding", SettlementMonthly, -150},
+		// The forecast ends before April, so all of 2025 is still owed.
+		{"annual pays the prior year", SettlementAnnual, 600},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := NewEngine(schedule, 0, tt.settlement)
+			if err != nil {
+				t.Fatalf("NewEngine() error = %v", err)
+			}
+			paid := 0.0
+			for month := time.January; month <= time.December; month++ {
+				paid += engine.Month(time.Date(2025, month, 1, 0, 0, 0, 0, time.UTC), 1000).Total()
+			}
+			for month := time.January; month <= time.March; month++ {
+				paid += engine.Month(time.Date(2026, month, 1, 0, 0, 0, 0, time.UTC), 1000).Total()
+			}
+
+			settled := engine.Settle().Total()
+			if math.Abs(settled-tt.settled) > 1e-6 {
+				t.Errorf("Settle() = %.2f, want %.2f", settled, tt.settled)
+			}
+			// 2025 owes 10% of 12000 less the deduction; 2026 owes nothing.
+			if math.Abs(paid+settled-600) > 1e-6 {
+				t.Errorf("total paid = %.2f, want 600.00", paid+settled)
+			}
+			if due := engine.Settle(); due.Total() != 0 {
+				t.Errorf("second Settle() = %.2f, want 0", due.Total())
+			}
+		})
+	}
+}
+
+func TestNewEngineInvalid(t *testing.T) {
+	schedule := Schedule{Brackets: []Bracket{{0, 10}}}
+	if _, err := NewEngine(schedule, 0, "quarterly"); err == nil {
+		t.Error("expected error for unsupported settlement")
+	}
+	if _, err := NewEngine(schedule, -1, ""); err == nil {
+		t.Error("expected error for negative state rate")
+	}
+}